@@ -0,0 +1,267 @@
+package alpacatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/*
+Response
+
+The response of a fake Alpaca device to a request, whose Value is JSON, or null if empty. An image array
+response has the element Type and Rank of its Value, which are omitted if the Rank is zero.
+*/
+type Response struct {
+	Value        string
+	ErrorNumber  int
+	ErrorMessage string
+	Type         int
+	Rank         int
+}
+
+// NotImplemented is the response of a device to a request for a member which it does not implement:
+var NotImplemented = Response{ErrorNumber: 0x400, ErrorMessage: "Not implemented"}
+
+/*
+Value()
+
+@returns the response of a device with the JSON value.
+*/
+func Value(value string) Response {
+	return Response{Value: value}
+}
+
+/*
+ImageArray()
+
+@returns the image array response of a camera with the JSON value, a rank 2 array of Int32 pixels indexed [x][y].
+*/
+func ImageArray(value string) Response {
+	return Response{Value: value, Type: 2, Rank: 2}
+}
+
+/*
+HandlerFunc
+
+The handler of requests for a member of a device, e.g., "startexposure", which simulates its behaviour.
+*/
+type HandlerFunc func(member string, r *http.Request) Response
+
+/*
+Server
+
+A fake Alpaca server, whose devices serve a value for each of their members, and handlers for those members which
+simulate behaviour. Members are addressed by their path below /api/v1/, e.g., "camera/0/gain".
+
+A PUT to a member with a value sets it to the form parameter of the same name, ignoring case, if any. A request
+for a member with neither a handler nor a value is served by the handler of its device, e.g., "camera/0/", if
+any, and is otherwise not implemented, or fails the test if the server is strict.
+*/
+type Server struct {
+	*httptest.Server
+	t        testing.TB
+	mu       sync.Mutex
+	strict   bool
+	values   map[string]string
+	handlers map[string]HandlerFunc
+	puts     []string
+}
+
+/*
+NewServer()
+
+@param t testing.TB (the test, at the end of which the server is closed)
+@param values map[string]string (the JSON values of the members of the devices, which are copied)
+@returns a started fake Alpaca server.
+*/
+func NewServer(t testing.TB, values map[string]string) *Server {
+	s := &Server{
+		t:        t,
+		values:   map[string]string{},
+		handlers: map[string]HandlerFunc{},
+	}
+
+	for member, value := range values {
+		s.values[member] = value
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+/*
+Strict()
+
+Fails the test on a request for a member which the server does not serve, rather than responding that it is not
+implemented.
+
+@returns the server.
+*/
+func (s *Server) Strict() *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.strict = true
+
+	return s
+}
+
+/*
+HandleFunc()
+
+@param pattern string (the member, e.g., "camera/0/startexposure", or the device, e.g., "camera/0/")
+@param handler HandlerFunc (the handler of requests for the member, or the members of the device)
+*/
+func (s *Server) HandleFunc(pattern string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[pattern] = handler
+}
+
+/*
+Set()
+
+@param member string (the member, e.g., "camera/0/gain")
+@param value string (the JSON value of the member)
+*/
+func (s *Server) Set(member string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[member] = value
+}
+
+/*
+Get()
+
+@param member string (the member, e.g., "camera/0/gain")
+@returns the JSON value of the member, or an empty string if it has none.
+*/
+func (s *Server) Get(member string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.values[member]
+}
+
+/*
+Puts()
+
+@returns every PUT to the server, in order, as the member and its form parameters, excluding the ClientID and
+ClientTransactionID, e.g., "camera/0/binx?BinX=2".
+*/
+func (s *Server) Puts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.puts...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	member := strings.TrimPrefix(r.URL.Path, "/api/v1/")
+
+	if r.Method == http.MethodPut {
+		r.ParseForm()
+	}
+
+	handler, response, ok := s.lookup(member, r)
+
+	if handler != nil {
+		// The handler is called without the lock, so that it may set the values of the server:
+		response, ok = handler(member[strings.LastIndex(member, "/")+1:], r), true
+	}
+
+	if !ok {
+		response = NotImplemented
+	}
+
+	write(w, r, response)
+}
+
+/*
+lookup()
+
+@returns the handler of the member, or else the response of its value, or else the handler of its device, with
+whether the member is served.
+*/
+func (s *Server) lookup(member string, r *http.Request) (HandlerFunc, Response, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method == http.MethodPut {
+		form := url.Values{}
+
+		for key, values := range r.PostForm {
+			if key != "ClientID" && key != "ClientTransactionID" {
+				form[key] = values
+			}
+		}
+
+		s.puts = append(s.puts, member+"?"+form.Encode())
+	}
+
+	if handler, ok := s.handlers[member]; ok {
+		return handler, Response{}, true
+	}
+
+	if value, ok := s.values[member]; ok {
+		if r.Method != http.MethodPut {
+			return nil, Value(value), true
+		}
+
+		name := member[strings.LastIndex(member, "/")+1:]
+
+		for key, values := range r.PostForm {
+			if strings.EqualFold(key, name) {
+				s.values[member] = values[0]
+			}
+		}
+
+		return nil, Value("null"), true
+	}
+
+	if handler, ok := s.handlers[member[:strings.LastIndex(member, "/")+1]]; ok {
+		return handler, Response{}, true
+	}
+
+	if s.strict {
+		s.t.Errorf("got an unexpected %s request for %s", r.Method, r.URL.Path)
+	}
+
+	return nil, Response{}, false
+}
+
+func write(w http.ResponseWriter, r *http.Request, response Response) {
+	id := r.FormValue("ClientTransactionID")
+
+	if id == "" {
+		id = "0"
+	}
+
+	value := response.Value
+
+	if value == "" {
+		value = "null"
+	}
+
+	message, _ := json.Marshal(response.ErrorMessage)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if response.Rank != 0 {
+		fmt.Fprintf(w, `{"Type":%d,"Rank":%d,"Value":%s,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":%d,"ErrorMessage":%s}`, response.Type, response.Rank, value, id, response.ErrorNumber, message)
+		return
+	}
+
+	fmt.Fprintf(w, `{"Value":%s,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":%d,"ErrorMessage":%s}`, value, id, response.ErrorNumber, message)
+}
@@ -0,0 +1,68 @@
+package alpacatest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+func TestServerValues(t *testing.T) {
+	s := NewServer(t, map[string]string{
+		"camera/0/gain": "100",
+	})
+
+	camera := alpacago.NewCameraWithOptions(s.URL, 0)
+
+	if err := camera.SetGain(200); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	got, err := camera.GetGain()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got != 200 {
+		t.Errorf("got %d, wanted %d", got, 200)
+	}
+
+	if want := []string{"camera/0/gain?Gain=200"}; fmt.Sprint(s.Puts()) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", s.Puts(), want)
+	}
+
+	if _, err := camera.GetOffset(); !errors.Is(err, alpacago.ErrNotImplemented) {
+		t.Errorf("got %v, wanted %v", err, alpacago.ErrNotImplemented)
+	}
+}
+
+func TestServerHandlers(t *testing.T) {
+	s := NewServer(t, map[string]string{
+		"camera/0/gain": "100",
+	})
+
+	s.HandleFunc("camera/0/", func(member string, r *http.Request) Response {
+		return Value("-10.5")
+	})
+
+	s.HandleFunc("camera/0/offset", func(member string, r *http.Request) Response {
+		return Value("50")
+	})
+
+	camera := alpacago.NewCameraWithOptions(s.URL, 0)
+
+	if got, err := camera.GetOffset(); err != nil || got != 50 {
+		t.Errorf("got %d (%v), wanted the offset of its handler", got, err)
+	}
+
+	if got, err := camera.GetGain(); err != nil || got != 100 {
+		t.Errorf("got %d (%v), wanted the gain of its value", got, err)
+	}
+
+	if got, err := camera.GetCCDTemperature(); err != nil || got != -10.5 {
+		t.Errorf("got %f (%v), wanted the temperature of the handler of the camera", got, err)
+	}
+}
@@ -2,8 +2,12 @@
 package alpacago
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
+	"sync"
+	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
 
@@ -20,12 +24,17 @@ const (
 )
 
 type ASCOMAlpacaAPIClient struct {
-	Client        *resty.Client
-	UrlBase       string
-	ClientId      uint32
+	Client  *resty.Client
+	UrlBase string
+	// An optional path prefix, for servers behind a reverse proxy:
+	BasePath string
+	ClientId uint32
+	// The last ClientTransactionID sent, this must only be modified atomically:
 	TransactionId uint32
 	ErrorNumber   int
 	ErrorMessage  string
+	Logger        log.FieldLogger
+	mu            sync.Mutex
 }
 
 func NewAlpacaAPI(clientId uint32, secure bool, domain string, ip string, port int32) *ASCOMAlpacaAPIClient {
@@ -41,21 +50,18 @@ func NewAlpacaAPI(clientId uint32, secure bool, domain string, ip string, port i
 		urlBase = fmt.Sprintf("%s://%s", protocol, domain)
 	}
 
-	// Create a new resty client:
-	resty := resty.New()
-
-	// Set the timeout to 60 seconds:
-	resty.SetTimeout(60 * time.Second)
+	return NewAlpacaAPIWithOptions(urlBase, WithClientID(clientId))
+}
 
-	// Create a new ASCOM Alpaca API client:
-	client := ASCOMAlpacaAPIClient{
-		Client:        resty,
-		UrlBase:       urlBase,
-		ClientId:      clientId,
-		TransactionId: 0,
-	}
+/*
+nextTransactionId()
 
-	return &client
+Atomically increments the client transaction counter, so that every request made by
+the client, including those made concurrently from several goroutines, is sent with
+a unique ClientTransactionID.
+*/
+func (a *ASCOMAlpacaAPIClient) nextTransactionId() uint32 {
+	return atomic.AddUint32(&a.TransactionId, 1)
 }
 
 /*
@@ -66,7 +72,42 @@ that use the HTTP GET verb should include parameters as
 query string name-value pairs.
 */
 func (a *ASCOMAlpacaAPIClient) getQueryString() string {
-	return fmt.Sprintf("ClientID=%d&ClientTransactionID=%d", a.ClientId, a.TransactionId)
+	return a.getTransactionQueryString(atomic.LoadUint32(&a.TransactionId))
+}
+
+/*
+getTransactionQueryString()
+
+@returns the ClientID and ClientTransactionID query string name-value pairs for the given transaction.
+*/
+func (a *ASCOMAlpacaAPIClient) getTransactionQueryString(transactionId uint32) string {
+	return fmt.Sprintf("ClientID=%d&ClientTransactionID=%d", a.ClientId, transactionId)
+}
+
+/*
+setError()
+
+Records the HTTP error status and message of the last failed request, guarded so that
+concurrent requests sharing the client do not race on the ErrorNumber and ErrorMessage.
+*/
+func (a *ASCOMAlpacaAPIClient) setError(errorNumber int, errorMessage string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.ErrorNumber = errorNumber
+	a.ErrorMessage = errorMessage
+}
+
+/*
+GetLastError()
+
+@returns the HTTP error status and message of the last failed request, if any.
+*/
+func (a *ASCOMAlpacaAPIClient) GetLastError() (int, string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.ErrorNumber, a.ErrorMessage
 }
 
 /*
@@ -74,54 +115,166 @@ getEndpoint()
 
 Alpaca Device API URLs are of the form http(s)://host:port/path
 where path comprises "/api/v1/" followed by one of the ASCOM
-method from https://ascom-standards.org/api/
+method from https://ascom-standards.org/api/, optionally prefixed
+by the client's BasePath.
 */
 func (a *ASCOMAlpacaAPIClient) getEndpoint(deviceType string, deviceNumber uint, method string) string {
-	return fmt.Sprintf("%s/api/v1/%s/%d/%s", a.UrlBase, deviceType, deviceNumber, method)
+	return fmt.Sprintf("%s%s/api/v1/%s/%d/%s", a.UrlBase, a.BasePath, deviceType, deviceNumber, method)
 }
 
-type stringResponse struct {
-	Value               string `json:"Value"`
+/*
+getManagementEndpoint()
+
+Alpaca Management API URLs are of the form http(s)://host:port/management/path
+where path is one of apiversions, v1/description or v1/configureddevices, optionally
+prefixed by the client's BasePath.
+*/
+func (a *ASCOMAlpacaAPIClient) getManagementEndpoint(path string) string {
+	return fmt.Sprintf("%s%s/management/%s", a.UrlBase, a.BasePath, path)
+}
+
+/*
+alpacaResponse
+
+The fields common to every ASCOM Alpaca JSON response, each typed response embeds this
+struct so that the transaction and error values are always decoded.
+*/
+type alpacaResponse struct {
 	ClientTransactionID uint32 `json:"ClientTransactionID"`
 	ServerTransactionID uint32 `json:"ServerTransactionID"`
 	ErrorNumber         int32  `json:"ErrorNumber"`
 	ErrorMessage        string `json:"ErrorMessage"`
 }
 
+type alpacaResponder interface {
+	response() *alpacaResponse
+}
+
+func (r *alpacaResponse) response() *alpacaResponse {
+	return r
+}
+
+type stringResponse struct {
+	Value string `json:"Value"`
+	alpacaResponse
+}
+
+type stringlistResponse struct {
+	Value []string `json:"Value"`
+	alpacaResponse
+}
+
+type booleanResponse struct {
+	Value bool `json:"Value"`
+	alpacaResponse
+}
+
+type float64Response struct {
+	Value float64 `json:"Value"`
+	alpacaResponse
+}
+
+type int32Response struct {
+	Value int32 `json:"Value"`
+	alpacaResponse
+}
+
+type uint32listResponse struct {
+	Value []uint32 `json:"Value"`
+	alpacaResponse
+}
+
+type uint32Rank2ArrayResponse struct {
+	Value [][]uint32 `json:"Value"`
+	Rank  uint32     `json:"Rank"`
+	alpacaResponse
+}
+
+type putResponse struct {
+	alpacaResponse
+}
+
 /*
-GetStringResponse()
+verifyResponse()
 
-Global public method to work with calls returning stringResponse
+@returns an error if the response does not echo the ClientTransactionID that was sent with
+the request (e.g., a stale response replayed by a caching proxy), otherwise the Alpaca error
+carried within the response, if any.
 */
-func (a *ASCOMAlpacaAPIClient) GetStringResponse(deviceType string, deviceNumber uint, method string) (string, error) {
+func verifyResponse(transactionId uint32, r *alpacaResponse) error {
+	if r.ClientTransactionID != transactionId {
+		return &TransactionMismatchError{
+			Sent:     transactionId,
+			Received: r.ClientTransactionID,
+		}
+	}
+
+	return newAlpacaError(r.ErrorNumber, r.ErrorMessage)
+}
+
+/*
+get()
+
+Performs a HTTP GET request against the ASCOM endpoint, decoding the JSON body into the
+result. Any additional params are appended to the query string, and the request is bound
+to the given context. An error is returned if
+the request fails, if the server responds with a HTTP error status, or if the response
+carries a non-zero Alpaca ErrorNumber (as an *AlpacaError).
+*/
+func (a *ASCOMAlpacaAPIClient) get(ctx context.Context, deviceType string, deviceNumber uint, method string, params map[string]string, result alpacaResponder) error {
 	// Build the ASCOM endpoint:
 	url := a.getEndpoint(deviceType, deviceNumber, method)
 
+	return a.getURL(ctx, url, params, result)
+}
+
+/*
+getURL()
+
+Performs a HTTP GET request against any Alpaca URL, e.g., a device or management API endpoint,
+reporting errors in the same manner as get().
+*/
+func (a *ASCOMAlpacaAPIClient) getURL(ctx context.Context, url string, params map[string]string, result alpacaResponder) error {
+	transactionId := a.nextTransactionId()
+
 	// Setup the resty client:
-	resp, err := a.Client.R().SetResult(&stringResponse{}).SetQueryString(a.getQueryString()).SetHeader("Accept", "application/json").Get(url)
+	resp, err := a.Client.R().SetContext(ctx).SetResult(result).SetQueryParams(params).SetQueryString(a.getTransactionQueryString(transactionId)).SetHeader("Accept", "application/json").Get(url)
 
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	// If the response object has a REST error:
 	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+		a.setError(resp.StatusCode(), resp.String())
+		return &HTTPError{StatusCode: resp.StatusCode(), Message: resp.String()}
 	}
 
-	// Return the result:
-	result := (resp.Result().(*stringResponse))
+	return verifyResponse(transactionId, result.response())
+}
 
-	return result.Value, nil
+/*
+GetStringResponse()
+
+Global public method to work with calls returning stringResponse
+*/
+func (a *ASCOMAlpacaAPIClient) GetStringResponse(deviceType string, deviceNumber uint, method string) (string, error) {
+	return a.GetStringResponseContext(context.Background(), deviceType, deviceNumber, method)
 }
 
-type stringlistResponse struct {
-	Value               []string `json:"Value"`
-	ClientTransactionID uint32   `json:"ClientTransactionID"`
-	ServerTransactionID uint32   `json:"ServerTransactionID"`
-	ErrorNumber         int32    `json:"ErrorNumber"`
-	ErrorMessage        string   `json:"ErrorMessage"`
+/*
+GetStringResponseContext()
+
+GetStringResponse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetStringResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) (string, error) {
+	result := &stringResponse{}
+
+	if err := a.get(ctx, deviceType, deviceNumber, method, nil, result); err != nil {
+		return "", err
+	}
+
+	return result.Value, nil
 }
 
 /*
@@ -130,215 +283,168 @@ GetStringListResponse()
 Global public method to work with calls returning stringListResponse
 */
 func (a *ASCOMAlpacaAPIClient) GetStringListResponse(deviceType string, deviceNumber uint, method string) ([]string, error) {
-	// Build the ASCOM endpoint:
-	url := a.getEndpoint(deviceType, deviceNumber, method)
+	return a.GetStringListResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
 
-	resp, err := a.Client.R().SetResult(&stringlistResponse{}).SetQueryString(a.getQueryString()).SetHeader("Accept", "application/json").Get(url)
+/*
+GetStringListResponseContext()
 
-	if err != nil {
-		return []string{""}, err
-	}
+GetStringListResponse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetStringListResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) ([]string, error) {
+	result := &stringlistResponse{}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+	if err := a.get(ctx, deviceType, deviceNumber, method, nil, result); err != nil {
+		return []string{}, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*stringlistResponse))
-
 	return result.Value, nil
 }
 
-type booleanResponse struct {
-	Value               bool   `json:"Value"`
-	ClientTransactionID uint32 `json:"ClientTransactionID"`
-	ServerTransactionID uint32 `json:"ServerTransactionID"`
-	ErrorNumber         int32  `json:"ErrorNumber"`
-	ErrorMessage        string `json:"ErrorMessage"`
-}
-
 /*
 GetBooleanResponse()
 
 Global public method to work with calls returning booleanResponse
 */
 func (a *ASCOMAlpacaAPIClient) GetBooleanResponse(deviceType string, deviceNumber uint, method string) (bool, error) {
-	// Build the ASCOM endpoint:
-	url := a.getEndpoint(deviceType, deviceNumber, method)
+	return a.GetBooleanResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
 
-	resp, err := a.Client.R().SetResult(&booleanResponse{}).SetQueryString(a.getQueryString()).SetHeader("Accept", "application/json").Get(url)
+/*
+GetBooleanResponseContext()
 
-	if err != nil {
-		return false, err
-	}
+GetBooleanResponse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetBooleanResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) (bool, error) {
+	result := &booleanResponse{}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+	if err := a.get(ctx, deviceType, deviceNumber, method, nil, result); err != nil {
+		return false, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*booleanResponse))
-
 	return result.Value, nil
 }
 
-type float64Response struct {
-	Value               float64 `json:"Value"`
-	ClientTransactionID int32   `json:"ClientTransactionID"`
-	ServerTransactionID int32   `json:"ServerTransactionID"`
-	ErrorNumber         int32   `json:"ErrorNumber"`
-	ErrorMessage        string  `json:"ErrorMessage"`
-}
-
 /*
 GetFloat64Response()
 
 Global public method to work with calls returning float64Response
 */
 func (a *ASCOMAlpacaAPIClient) GetFloat64Response(deviceType string, deviceNumber uint, method string) (float64, error) {
-	// Build the ASCOM endpoint:
-	url := a.getEndpoint(deviceType, deviceNumber, method)
+	return a.GetFloat64ResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
 
-	resp, err := a.Client.R().SetResult(&float64Response{}).SetQueryString(a.getQueryString()).SetHeader("Accept", "application/json").Get(url)
+/*
+GetFloat64ResponseContext()
 
-	if err != nil {
-		return 0, err
-	}
+GetFloat64Response() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetFloat64ResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) (float64, error) {
+	result := &float64Response{}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+	if err := a.get(ctx, deviceType, deviceNumber, method, nil, result); err != nil {
+		return 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*float64Response))
-
 	return result.Value, nil
 }
 
-type int32Response struct {
-	Value               int32  `json:"Value"`
-	ClientTransactionID uint32 `json:"ClientTransactionID"`
-	ServerTransactionID uint32 `json:"ServerTransactionID"`
-	ErrorNumber         int32  `json:"ErrorNumber"`
-	ErrorMessage        string `json:"ErrorMessage"`
-}
-
 /*
 GetInt32Response()
 
 Global public method to work with calls returning int32Response
 */
 func (a *ASCOMAlpacaAPIClient) GetInt32Response(deviceType string, deviceNumber uint, method string) (int32, error) {
-	// Build the ASCOM endpoint:
-	url := a.getEndpoint(deviceType, deviceNumber, method)
+	return a.GetInt32ResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
 
-	resp, err := a.Client.R().SetResult(&int32Response{}).SetQueryString(a.getQueryString()).SetHeader("Accept", "application/json").Get(url)
+/*
+GetInt32ResponseContext()
 
-	if err != nil {
-		return 0, err
-	}
+GetInt32Response() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetInt32ResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) (int32, error) {
+	result := &int32Response{}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+	if err := a.get(ctx, deviceType, deviceNumber, method, nil, result); err != nil {
+		return 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*int32Response))
-
 	return result.Value, nil
 }
 
-type uint32listResponse struct {
-	Value               []uint32 `json:"Value"`
-	ClientTransactionID int32    `json:"ClientTransactionID"`
-	ServerTransactionID int32    `json:"ServerTransactionID"`
-	ErrorNumber         int32    `json:"ErrorNumber"`
-	ErrorMessage        string   `json:"ErrorMessage"`
-}
-
 /*
-GetInt32ListResponse()
+GetUInt32ListResponse()
 
-Global public method to work with calls returning int32listResponse
+Global public method to work with calls returning uint32listResponse
 */
 func (a *ASCOMAlpacaAPIClient) GetUInt32ListResponse(deviceType string, deviceNumber uint, method string) ([]uint32, error) {
-	// Build the ASCOM endpoint:
-	url := a.getEndpoint(deviceType, deviceNumber, method)
+	return a.GetUInt32ListResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
 
-	resp, err := a.Client.R().SetResult(&uint32listResponse{}).SetQueryString(a.getQueryString()).SetHeader("Accept", "application/json").Get(url)
+/*
+GetUInt32ListResponseContext()
 
-	if err != nil {
-		return []uint32{}, err
-	}
+GetUInt32ListResponse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetUInt32ListResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) ([]uint32, error) {
+	result := &uint32listResponse{}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+	if err := a.get(ctx, deviceType, deviceNumber, method, nil, result); err != nil {
+		return []uint32{}, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*uint32listResponse))
-
 	return result.Value, nil
 }
 
-type uint32Rank2ArrayResponse struct {
-	Value               [][]uint32 `json:"Value"`
-	Rank                uint32     `json:"Rank"`
-	ClientTransactionID int32      `json:"ClientTransactionID"`
-	ServerTransactionID int32      `json:"ServerTransactionID"`
-	ErrorNumber         int32      `json:"ErrorNumber"`
-	ErrorMessage        string     `json:"ErrorMessage"`
-}
-
 /*
-GetUInt32RankArrayResponse()
+GetUInt32Rank2ArrayResponse()
 
 Global public method to work with calls returning a uint32Rank2ArrayResponse
 */
 func (a *ASCOMAlpacaAPIClient) GetUInt32Rank2ArrayResponse(deviceType string, deviceNumber uint, method string) ([][]uint32, uint32, error) {
-	// Build the ASCOM endpoint:
-	url := a.getEndpoint(deviceType, deviceNumber, method)
+	return a.GetUInt32Rank2ArrayResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
 
-	resp, err := a.Client.R().SetResult(&uint32Rank2ArrayResponse{}).SetQueryString(a.getQueryString()).SetHeader("Accept", "application/json").Get(url)
+/*
+GetUInt32Rank2ArrayResponseContext()
 
-	if err != nil {
-		return [][]uint32{}, 0, err
-	}
+GetUInt32Rank2ArrayResponse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetUInt32Rank2ArrayResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) ([][]uint32, uint32, error) {
+	result := &uint32Rank2ArrayResponse{}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+	if err := a.get(ctx, deviceType, deviceNumber, method, nil, result); err != nil {
+		return [][]uint32{}, 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*uint32Rank2ArrayResponse))
-
 	return result.Value, result.Rank, nil
 }
 
-type putResponse struct {
-	ClientTransactionID uint32 `json:"ClientTransactionID"`
-	ServerTransactionID uint32 `json:"ServerTransactionID"`
-	ErrorNumber         int32  `json:"ErrorNumber"`
-	ErrorMessage        string `json:"ErrorMessage"`
-}
+/*
+put()
 
-func (a *ASCOMAlpacaAPIClient) Put(deviceType string, deviceNumber uint, method string, form map[string]string) error {
+Performs a HTTP PUT request against the ASCOM endpoint with the form encoded parameters,
+decoding the JSON body into the result. Errors are reported in the same manner as get().
+*/
+func (a *ASCOMAlpacaAPIClient) put(ctx context.Context, deviceType string, deviceNumber uint, method string, form map[string]string, result alpacaResponder) error {
 	// Build the ASCOM endpoint:
 	url := a.getEndpoint(deviceType, deviceNumber, method)
 
-	resp, err := a.Client.R().SetHeader("Content-Type", "application/x-www-form-urlencoded").SetResult(&putResponse{}).SetHeader("Accept", "application/json").SetFormData(form).Put(url)
+	transactionId := a.nextTransactionId()
+
+	// Every PUT must identify the client and transaction within the form data, which is added to a copy so that
+	// the form of the caller is not modified:
+	data := make(map[string]string, len(form)+2)
+
+	for key, value := range form {
+		data[key] = value
+	}
+
+	data["ClientID"] = fmt.Sprintf("%d", a.ClientId)
+	data["ClientTransactionID"] = fmt.Sprintf("%d", transactionId)
+
+	resp, err := a.Client.R().SetContext(ctx).SetHeader("Content-Type", "application/x-www-form-urlencoded").SetResult(result).SetHeader("Accept", "application/json").SetFormData(data).Put(url)
 
 	if err != nil {
 		return err
@@ -346,20 +452,33 @@ func (a *ASCOMAlpacaAPIClient) Put(deviceType string, deviceNumber uint, method
 
 	// If the response object has a REST error:
 	if resp.IsError() {
-		a.ErrorNumber = resp.StatusCode()
-		a.ErrorMessage = resp.String()
+		a.setError(resp.StatusCode(), resp.String())
+		return &HTTPError{StatusCode: resp.StatusCode(), Message: resp.String()}
 	}
 
-	// Return the result:
-	result := (resp.Result().(*putResponse))
+	r := result.response()
 
-	if result.ErrorNumber != 0 {
-		return fmt.Errorf("%d: %s", result.ErrorNumber, result.ErrorMessage)
-	}
+	a.Logger.Debugf("%v", r)
 
-	log.Debugf("%v", result)
+	return verifyResponse(transactionId, r)
+}
 
-	return nil
+/*
+Put()
+
+Global public method to work with calls returning putResponse
+*/
+func (a *ASCOMAlpacaAPIClient) Put(deviceType string, deviceNumber uint, method string, form map[string]string) error {
+	return a.PutContext(context.Background(), deviceType, deviceNumber, method, form)
+}
+
+/*
+PutContext()
+
+Put() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) PutContext(ctx context.Context, deviceType string, deviceNumber uint, method string, form map[string]string) error {
+	return a.put(ctx, deviceType, deviceNumber, method, form, &putResponse{})
 }
 
 /*
@@ -369,7 +488,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (a *ASCOMAlpacaAPIClient) IsConnected(deviceType string, deviceNumber uint) (bool, error) {
-	return a.GetBooleanResponse(deviceType, deviceNumber, "connected")
+	return a.IsConnectedContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) IsConnectedContext(ctx context.Context, deviceType string, deviceNumber uint) (bool, error) {
+	return a.GetBooleanResponseContext(ctx, deviceType, deviceNumber, "connected")
 }
 
 /*
@@ -379,7 +507,16 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (a *ASCOMAlpacaAPIClient) GetDescription(deviceType string, deviceNumber uint) (string, error) {
-	return a.GetStringResponse(deviceType, deviceNumber, "description")
+	return a.GetDescriptionContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetDescriptionContext(ctx context.Context, deviceType string, deviceNumber uint) (string, error) {
+	return a.GetStringResponseContext(ctx, deviceType, deviceNumber, "description")
 }
 
 /*
@@ -389,7 +526,16 @@ GetDriverInfo() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__driverinfo
 */
 func (a *ASCOMAlpacaAPIClient) GetDriverInfo(deviceType string, deviceNumber uint) (string, error) {
-	return a.GetStringResponse(deviceType, deviceNumber, "driverinfo")
+	return a.GetDriverInfoContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+GetDriverInfoContext()
+
+GetDriverInfo() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetDriverInfoContext(ctx context.Context, deviceType string, deviceNumber uint) (string, error) {
+	return a.GetStringResponseContext(ctx, deviceType, deviceNumber, "driverinfo")
 }
 
 /*
@@ -399,7 +545,16 @@ GetDriverVersion() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__driverversion
 */
 func (a *ASCOMAlpacaAPIClient) GetDriverVersion(deviceType string, deviceNumber uint) (string, error) {
-	return a.GetStringResponse(deviceType, deviceNumber, "driverversion")
+	return a.GetDriverVersionContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+GetDriverVersionContext()
+
+GetDriverVersion() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetDriverVersionContext(ctx context.Context, deviceType string, deviceNumber uint) (string, error) {
+	return a.GetStringResponseContext(ctx, deviceType, deviceNumber, "driverversion")
 }
 
 /*
@@ -415,7 +570,16 @@ largest number of devices.
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__interfaceversion
 */
 func (a *ASCOMAlpacaAPIClient) GetInterfaceVersion(deviceType string, deviceNumber uint) (int32, error) {
-	return a.GetInt32Response(deviceType, deviceNumber, "interfaceversion")
+	return a.GetInterfaceVersionContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+GetInterfaceVersionContext()
+
+GetInterfaceVersion() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetInterfaceVersionContext(ctx context.Context, deviceType string, deviceNumber uint) (int32, error) {
+	return a.GetInt32ResponseContext(ctx, deviceType, deviceNumber, "interfaceversion")
 }
 
 /*
@@ -425,7 +589,16 @@ GetName() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__name
 */
 func (a *ASCOMAlpacaAPIClient) GetName(deviceType string, deviceNumber uint) (string, error) {
-	return a.GetStringResponse(deviceType, deviceNumber, "name")
+	return a.GetNameContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+GetNameContext()
+
+GetName() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetNameContext(ctx context.Context, deviceType string, deviceNumber uint) (string, error) {
+	return a.GetStringResponseContext(ctx, deviceType, deviceNumber, "name")
 }
 
 /*
@@ -435,5 +608,317 @@ GetSupportedActions() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
 */
 func (a *ASCOMAlpacaAPIClient) GetSupportedActions(deviceType string, deviceNumber uint) ([]string, error) {
-	return a.GetStringListResponse(deviceType, deviceNumber, "supportedactions")
+	return a.GetSupportedActionsContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetSupportedActionsContext(ctx context.Context, deviceType string, deviceNumber uint) ([]string, error) {
+	return a.GetStringListResponseContext(ctx, deviceType, deviceNumber, "supportedactions")
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, an error matching ErrActionNotImplemented is returned if
+the driver does not support the action.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (a *ASCOMAlpacaAPIClient) Action(deviceType string, deviceNumber uint, action string, parameters string) (string, error) {
+	return a.ActionContext(context.Background(), deviceType, deviceNumber, action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) ActionContext(ctx context.Context, deviceType string, deviceNumber uint, action string, parameters string) (string, error) {
+	var form map[string]string = map[string]string{
+		"Action":     action,
+		"Parameters": parameters,
+	}
+
+	result := &stringResponse{}
+
+	if err := a.put(ctx, deviceType, deviceNumber, "action", form, result); err != nil {
+		return "", actionError(err)
+	}
+
+	return result.Value, nil
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device and no response was expected.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (a *ASCOMAlpacaAPIClient) CommandBlind(deviceType string, deviceNumber uint, command string, raw bool) error {
+	return a.CommandBlindContext(context.Background(), deviceType, deviceNumber, command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) CommandBlindContext(ctx context.Context, deviceType string, deviceNumber uint, command string, raw bool) error {
+	var form map[string]string = map[string]string{
+		"Command": command,
+		"Raw":     fmt.Sprintf("%t", raw),
+	}
+
+	if err := a.put(ctx, deviceType, deviceNumber, "commandblind", form, &putResponse{}); err != nil {
+		return actionError(err)
+	}
+
+	return nil
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (a *ASCOMAlpacaAPIClient) CommandBool(deviceType string, deviceNumber uint, command string, raw bool) (bool, error) {
+	return a.CommandBoolContext(context.Background(), deviceType, deviceNumber, command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) CommandBoolContext(ctx context.Context, deviceType string, deviceNumber uint, command string, raw bool) (bool, error) {
+	var form map[string]string = map[string]string{
+		"Command": command,
+		"Raw":     fmt.Sprintf("%t", raw),
+	}
+
+	result := &booleanResponse{}
+
+	if err := a.put(ctx, deviceType, deviceNumber, "commandbool", form, result); err != nil {
+		return false, actionError(err)
+	}
+
+	return result.Value, nil
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (a *ASCOMAlpacaAPIClient) CommandString(deviceType string, deviceNumber uint, command string, raw bool) (string, error) {
+	return a.CommandStringContext(context.Background(), deviceType, deviceNumber, command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) CommandStringContext(ctx context.Context, deviceType string, deviceNumber uint, command string, raw bool) (string, error) {
+	var form map[string]string = map[string]string{
+		"Command": command,
+		"Raw":     fmt.Sprintf("%t", raw),
+	}
+
+	result := &stringResponse{}
+
+	if err := a.put(ctx, deviceType, deviceNumber, "commandstring", form, result); err != nil {
+		return "", actionError(err)
+	}
+
+	return result.Value, nil
+}
+
+/*
+actionError()
+
+Many drivers report an unsupported action or command with the generic NotImplemented error number,
+rather than ActionNotImplemented. Both are mapped to ErrActionNotImplemented, so that callers can
+distinguish an unsupported action from a failure of a supported one.
+*/
+func actionError(err error) error {
+	var alpacaErr *AlpacaError
+
+	if errors.As(err, &alpacaErr) && alpacaErr.ErrorNumber == ErrNotImplemented.ErrorNumber {
+		return &AlpacaError{
+			ErrorNumber:  ErrActionNotImplemented.ErrorNumber,
+			ErrorMessage: alpacaErr.ErrorMessage,
+		}
+	}
+
+	return err
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (a *ASCOMAlpacaAPIClient) Connect(deviceType string, deviceNumber uint) error {
+	return a.ConnectContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) ConnectContext(ctx context.Context, deviceType string, deviceNumber uint) error {
+	return a.PutContext(ctx, deviceType, deviceNumber, "connect", map[string]string{})
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (a *ASCOMAlpacaAPIClient) Disconnect(deviceType string, deviceNumber uint) error {
+	return a.DisconnectContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) DisconnectContext(ctx context.Context, deviceType string, deviceNumber uint) error {
+	return a.PutContext(ctx, deviceType, deviceNumber, "disconnect", map[string]string{})
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (a *ASCOMAlpacaAPIClient) IsConnecting(deviceType string, deviceNumber uint) (bool, error) {
+	return a.IsConnectingContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) IsConnectingContext(ctx context.Context, deviceType string, deviceNumber uint) (bool, error) {
+	return a.GetBooleanResponseContext(ctx, deviceType, deviceNumber, "connecting")
+}
+
+/*
+DeviceStateValue
+
+A single named operational property of the device, as returned by devicestate. The Value is
+left as raw JSON, as its type depends upon the property.
+*/
+type DeviceStateValue struct {
+	Name  string          `json:"Name"`
+	Value json.RawMessage `json:"Value"`
+}
+
+type deviceStateResponse struct {
+	Value []DeviceStateValue `json:"Value"`
+	alpacaResponse
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the device's operational properties, in a single round trip, as a list of name-value pairs.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (a *ASCOMAlpacaAPIClient) GetDeviceState(deviceType string, deviceNumber uint) ([]DeviceStateValue, error) {
+	return a.GetDeviceStateContext(context.Background(), deviceType, deviceNumber)
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetDeviceStateContext(ctx context.Context, deviceType string, deviceNumber uint) ([]DeviceStateValue, error) {
+	result := &deviceStateResponse{}
+
+	if err := a.get(ctx, deviceType, deviceNumber, "devicestate", nil, result); err != nil {
+		return []DeviceStateValue{}, err
+	}
+
+	return result.Value, nil
+}
+
+/*
+decodeDeviceState()
+
+Decodes the name-value pairs returned by devicestate into the typed device state struct, where each
+field of the struct is tagged with the name of the operational property.
+*/
+func decodeDeviceState(values []DeviceStateValue, state interface{}) error {
+	properties := make(map[string]json.RawMessage, len(values))
+
+	for _, v := range values {
+		properties[v.Name] = v.Value
+	}
+
+	b, err := json.Marshal(properties)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, state)
+}
+
+/*
+isDeviceStateUnsupported()
+
+@returns true if the error indicates that the driver predates the devicestate member, i.e., it is not
+implemented or, for older Alpaca servers, the member is unknown and rejected with a HTTP 4xx status.
+*/
+func isDeviceStateUnsupported(err error) bool {
+	var httpErr *HTTPError
+
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 400 && httpErr.StatusCode < 500
+	}
+
+	return errors.Is(err, ErrNotImplemented)
+}
+
+/*
+joinDeviceStateErrors()
+
+@returns the errors from the individual property reads of a device state fallback, joined, ignoring
+any property that is not implemented by the driver.
+*/
+func joinDeviceStateErrors(errs ...error) error {
+	var failed []error
+
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, ErrNotImplemented) {
+			failed = append(failed, err)
+		}
+	}
+
+	return errors.Join(failed...)
 }
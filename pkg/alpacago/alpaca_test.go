@@ -1,8 +1,17 @@
 package alpacago
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
 )
 
 var client = NewAlpacaAPI(65535, false, "100.69.47.32", "", -1)
@@ -348,3 +357,418 @@ func TestNewAlpacaAPDSupportedActions(t *testing.T) {
 		t.Errorf("got %q, wanted %q", client.ErrorMessage, want)
 	}
 }
+
+func TestNewAlpacaAPIGetResponseContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+
+	defer server.Close()
+
+	client := NewAlpacaAPI(65535, false, "", "", 0)
+
+	client.UrlBase = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+
+	defer cancel()
+
+	_, err := client.GetBooleanResponseContext(ctx, "camera", 0, "imageready")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, wanted %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestNewAlpacaAPIPutContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("got a %s request, wanted the request to be cancelled before it was sent", r.Method)
+	}))
+
+	defer server.Close()
+
+	telescope := NewTelescope(65535, false, "", "", 0, 0, 1)
+
+	telescope.Alpaca.UrlBase = server.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	err := telescope.SetSlewToTargetAsyncContext(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, wanted %v", err, context.Canceled)
+	}
+}
+
+func TestNewAlpacaAPIPutDoesNotModifyForm(t *testing.T) {
+	server := alpacatest.NewServer(t, map[string]string{
+		"camera/0/gain": "0",
+	})
+
+	client := NewAlpacaAPIWithOptions(server.URL)
+
+	form := map[string]string{"Gain": "100"}
+
+	if err := client.Put("camera", 0, "gain", form); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(form) != 1 || form["Gain"] != "100" {
+		t.Errorf("got %v, wanted the form to be unchanged", form)
+	}
+
+	if got := server.Get("camera/0/gain"); got != "100" {
+		t.Errorf("got %q, wanted %q", got, "100")
+	}
+}
+
+func TestNewAlpacaAPIConcurrentTransactionIDs(t *testing.T) {
+	var mu sync.Mutex
+
+	seen := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.FormValue("ClientTransactionID")
+
+		mu.Lock()
+		seen[id]++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":true,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+	}))
+
+	defer server.Close()
+
+	camera := NewCamera(65535, false, "", "", 0, 0)
+
+	camera.Alpaca.UrlBase = server.URL
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := camera.IsImageReady(); err != nil {
+				t.Errorf("got %q", err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if err := camera.SetBinX(2); err != nil {
+				t.Errorf("got %q", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(seen) != 100 {
+		t.Errorf("got %d unique transaction ids, wanted %d", len(seen), 100)
+	}
+
+	if camera.Alpaca.TransactionId != 100 {
+		t.Errorf("got %d, wanted %d", camera.Alpaca.TransactionId, 100)
+	}
+}
+
+func TestNewAlpacaAPITransactionIDMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"Value":-10.5,"ClientTransactionID":42,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`)
+	}))
+
+	defer server.Close()
+
+	client := NewAlpacaAPI(65535, false, "", "", 0)
+
+	client.UrlBase = server.URL
+
+	_, err := client.GetFloat64Response("camera", 0, "ccdtemperature")
+
+	if !errors.Is(err, ErrTransactionMismatch) {
+		t.Errorf("got %v, wanted %v", err, ErrTransactionMismatch)
+	}
+
+	var mismatch *TransactionMismatchError
+
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("got %T, wanted *TransactionMismatchError", err)
+	}
+
+	if mismatch.Sent != 1 || mismatch.Received != 42 {
+		t.Errorf("got sent %d and received %d, wanted sent %d and received %d", mismatch.Sent, mismatch.Received, 1, 42)
+	}
+}
+
+func newActionTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("got %s, wanted %s", r.Method, http.MethodPut)
+		}
+
+		id := r.FormValue("ClientTransactionID")
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.FormValue("Action") == "SlewToHA":
+			fmt.Fprintf(w, `{"Value":"ok:%s","ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, r.FormValue("Parameters"), id)
+		case r.FormValue("Action") == "Unsupported":
+			fmt.Fprintf(w, `{"Value":"","ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":1036,"ErrorMessage":"Action Unsupported is not implemented"}`, id)
+		case r.FormValue("Action") == "Legacy":
+			fmt.Fprintf(w, `{"Value":"","ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":1024,"ErrorMessage":"Action Legacy is not implemented"}`, id)
+		case r.FormValue("Command") == ":GR#":
+			fmt.Fprintf(w, `{"Value":"12:34:56#","ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+		case r.FormValue("Command") == ":Gp#":
+			fmt.Fprintf(w, `{"Value":%s,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, r.FormValue("Raw"), id)
+		default:
+			fmt.Fprintf(w, `{"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+		}
+	}))
+}
+
+func TestNewAlpacaAPIAction(t *testing.T) {
+	server := newActionTestServer(t)
+
+	defer server.Close()
+
+	telescope := NewTelescopeWithOptions(server.URL, 0, NotTracking)
+
+	got, err := telescope.Action("SlewToHA", "1.5")
+
+	var want string = "ok:1.5"
+
+	if err != nil {
+		t.Errorf("got %q, wanted %q", err, want)
+	}
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestNewAlpacaAPIActionNotImplemented(t *testing.T) {
+	server := newActionTestServer(t)
+
+	defer server.Close()
+
+	camera := NewCameraWithOptions(server.URL, 0)
+
+	if _, err := camera.Action("Unsupported", ""); !errors.Is(err, ErrActionNotImplemented) {
+		t.Errorf("got %v, wanted %v", err, ErrActionNotImplemented)
+	}
+
+	// Drivers reporting the generic NotImplemented error are mapped to ActionNotImplemented:
+	if _, err := camera.Action("Legacy", ""); !errors.Is(err, ErrActionNotImplemented) {
+		t.Errorf("got %v, wanted %v", err, ErrActionNotImplemented)
+	}
+}
+
+func TestNewAlpacaAPICommands(t *testing.T) {
+	server := newActionTestServer(t)
+
+	defer server.Close()
+
+	dome := NewDomeWithOptions(server.URL, 0)
+
+	if err := dome.CommandBlind(":Q#", true); err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	got, err := dome.CommandBool(":Gp#", true)
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if !got {
+		t.Errorf("got %v, wanted %v", got, true)
+	}
+
+	value, err := dome.CommandString(":GR#", false)
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if value != "12:34:56#" {
+		t.Errorf("got %q, wanted %q", value, "12:34:56#")
+	}
+}
+
+func newDeviceStateTestServer(t *testing.T, legacy bool) *httptest.Server {
+	mux := http.NewServeMux()
+
+	respond := func(w http.ResponseWriter, r *http.Request, value string) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":%s,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, value, r.FormValue("ClientTransactionID"))
+	}
+
+	mux.HandleFunc("/api/v1/camera/0/devicestate", func(w http.ResponseWriter, r *http.Request) {
+		// Older Alpaca servers reject the unknown member outright:
+		if legacy {
+			http.Error(w, "Unknown member devicestate", http.StatusBadRequest)
+			return
+		}
+
+		respond(w, r, `[
+			{"Name":"CameraState","Value":2},
+			{"Name":"CCDTemperature","Value":-10.5},
+			{"Name":"CoolerPower","Value":42.0},
+			{"Name":"HeatSinkTemperature","Value":18.25},
+			{"Name":"ImageReady","Value":false},
+			{"Name":"IsPulseGuiding","Value":true},
+			{"Name":"PercentCompleted","Value":37},
+			{"Name":"TimeStamp","Value":"2026-10-17T21:30:00.0000000Z"}
+		]`)
+	})
+
+	mux.HandleFunc("/api/v1/camera/0/camerastate", func(w http.ResponseWriter, r *http.Request) { respond(w, r, `2`) })
+	mux.HandleFunc("/api/v1/camera/0/ccdtemperature", func(w http.ResponseWriter, r *http.Request) { respond(w, r, `-10.5`) })
+	mux.HandleFunc("/api/v1/camera/0/coolerpower", func(w http.ResponseWriter, r *http.Request) { respond(w, r, `42.0`) })
+	mux.HandleFunc("/api/v1/camera/0/imageready", func(w http.ResponseWriter, r *http.Request) { respond(w, r, `false`) })
+	mux.HandleFunc("/api/v1/camera/0/ispulseguiding", func(w http.ResponseWriter, r *http.Request) { respond(w, r, `true`) })
+	mux.HandleFunc("/api/v1/camera/0/percentcompleted", func(w http.ResponseWriter, r *http.Request) { respond(w, r, `37`) })
+
+	// The heat sink temperature is optional, and not implemented by this camera:
+	mux.HandleFunc("/api/v1/camera/0/heatsinktemperature", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":0,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":1024,"ErrorMessage":"HeatSinkTemperature is not implemented"}`, r.FormValue("ClientTransactionID"))
+	})
+
+	mux.HandleFunc("/api/v1/switch/0/devicestate", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, `[
+			{"Name":"GetSwitch0","Value":true},
+			{"Name":"GetSwitch1","Value":false},
+			{"Name":"GetSwitchValue0","Value":1.0},
+			{"Name":"GetSwitchValue1","Value":0.25},
+			{"Name":"TimeStamp","Value":"2026-10-17T21:30:00.0000000Z"}
+		]`)
+	})
+
+	mux.HandleFunc("/api/v1/camera/0/connect", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("got %s, wanted %s", r.Method, http.MethodPut)
+		}
+
+		respond(w, r, `null`)
+	})
+
+	mux.HandleFunc("/api/v1/camera/0/connecting", func(w http.ResponseWriter, r *http.Request) { respond(w, r, `true`) })
+
+	return httptest.NewServer(mux)
+}
+
+func TestNewAlpacaAPIConnect(t *testing.T) {
+	ts := newDeviceStateTestServer(t, false)
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0, WithClientID(65535))
+
+	if err := camera.Connect(); err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	got, err := camera.IsConnecting()
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if !got {
+		t.Errorf("got %v, wanted %v", got, true)
+	}
+}
+
+func TestNewAlpacaAPIDeviceState(t *testing.T) {
+	ts := newDeviceStateTestServer(t, false)
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0, WithClientID(65535))
+
+	got, err := camera.GetDeviceState()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	want := CameraDeviceState{
+		CameraState:         CameraExposing,
+		CCDTemperature:      -10.5,
+		CoolerPower:         42.0,
+		HeatSinkTemperature: 18.25,
+		ImageReady:          false,
+		IsPulseGuiding:      true,
+		PercentCompleted:    37,
+		TimeStamp:           "2026-10-17T21:30:00.0000000Z",
+	}
+
+	if *got != want {
+		t.Errorf("got %+v, wanted %+v", *got, want)
+	}
+}
+
+func TestNewAlpacaAPIDeviceStateWithFallback(t *testing.T) {
+	ts := newDeviceStateTestServer(t, true)
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0, WithClientID(65535))
+
+	if _, err := camera.GetDeviceState(); err == nil {
+		t.Fatalf("got nil, wanted an error from a server without devicestate")
+	}
+
+	got, err := camera.GetDeviceStateWithFallback()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.CameraState != CameraExposing || got.CCDTemperature != -10.5 || got.PercentCompleted != 37 || !got.IsPulseGuiding {
+		t.Errorf("got %+v, wanted the individually read properties", *got)
+	}
+
+	if got.HeatSinkTemperature != 0 {
+		t.Errorf("got %v, wanted the unimplemented property to be zero", got.HeatSinkTemperature)
+	}
+
+	if len(got.TimeStamp) == 0 {
+		t.Errorf("got an empty timestamp, wanted the time of the fallback reads")
+	}
+}
+
+func TestNewAlpacaAPISwitchDeviceState(t *testing.T) {
+	ts := newDeviceStateTestServer(t, false)
+
+	defer ts.Close()
+
+	sw := NewSwitchWithOptions(ts.URL, 0, WithClientID(65535))
+
+	got, err := sw.GetDeviceState()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(got.Switches) != 2 || !got.Switches[0] || got.Switches[1] {
+		t.Errorf("got %v, wanted map[0:true 1:false]", got.Switches)
+	}
+
+	if len(got.Values) != 2 || got.Values[0] != 1.0 || got.Values[1] != 0.25 {
+		t.Errorf("got %v, wanted map[0:1 1:0.25]", got.Values)
+	}
+}
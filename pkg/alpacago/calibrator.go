@@ -1,8 +1,10 @@
 package alpacago
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 type CalibratorState int32
@@ -70,6 +72,21 @@ type CoverCalibrator struct {
 	DeviceNumber uint
 }
 
+/*
+CoverCalibratorDeviceState
+
+The operational properties of the cover calibrator, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type CoverCalibratorDeviceState struct {
+	Brightness         float64         `json:"Brightness"`
+	CalibratorChanging bool            `json:"CalibratorChanging"`
+	CalibratorState    CalibratorState `json:"CalibratorState"`
+	CoverMoving        bool            `json:"CoverMoving"`
+	CoverState         CoverState      `json:"CoverState"`
+	TimeStamp          string          `json:"TimeStamp"`
+}
+
 func NewCoverCalibrator(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *CoverCalibrator {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -81,6 +98,17 @@ func NewCoverCalibrator(clientId uint32, secure bool, domain string, ip string,
 	return &calibrator
 }
 
+func NewCoverCalibratorWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *CoverCalibrator {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	calibrator := CoverCalibrator{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &calibrator
+}
+
 /*
 IsConnected() common method to all ASCOM Alpaca compliant devices
 
@@ -88,7 +116,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (c *CoverCalibrator) IsConnected() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("covercalibrator", c.DeviceNumber, "connected")
+	return c.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) IsConnectedContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "covercalibrator", c.DeviceNumber, "connected")
 }
 
 /*
@@ -99,16 +136,159 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (c *CoverCalibrator) SetConnected(connected bool) error {
-	c.Alpaca.TransactionId++
+	return c.SetConnectedContext(context.Background(), connected)
+}
 
+/*
+SetConnectedContext()
+
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
 	}
 
-	return c.Alpaca.Put("covercalibrator", c.DeviceNumber, "connected", form)
+	return c.Alpaca.PutContext(ctx, "covercalibrator", c.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (c *CoverCalibrator) Connect() error {
+	return c.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) ConnectContext(ctx context.Context) error {
+	return c.Alpaca.ConnectContext(ctx, "covercalibrator", c.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (c *CoverCalibrator) Disconnect() error {
+	return c.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) DisconnectContext(ctx context.Context) error {
+	return c.Alpaca.DisconnectContext(ctx, "covercalibrator", c.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (c *CoverCalibrator) IsConnecting() (bool, error) {
+	return c.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) IsConnectingContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.IsConnectingContext(ctx, "covercalibrator", c.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the cover calibrator in a single round trip, see CoverCalibratorDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (c *CoverCalibrator) GetDeviceState() (*CoverCalibratorDeviceState, error) {
+	return c.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetDeviceStateContext(ctx context.Context) (*CoverCalibratorDeviceState, error) {
+	values, err := c.Alpaca.GetDeviceStateContext(ctx, "covercalibrator", c.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := CoverCalibratorDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the cover calibrator from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (c *CoverCalibrator) GetDeviceStateWithFallback() (*CoverCalibratorDeviceState, error) {
+	return c.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetDeviceStateWithFallbackContext(ctx context.Context) (*CoverCalibratorDeviceState, error) {
+	state, err := c.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	state = &CoverCalibratorDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.Brightness, err = c.GetBrightnessContext(ctx)
+	errs = append(errs, err)
+
+	state.CalibratorChanging, err = c.Alpaca.GetBooleanResponseContext(ctx, "covercalibrator", c.DeviceNumber, "calibratorchanging")
+	errs = append(errs, err)
+
+	calibratorState, err := c.Alpaca.GetInt32ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "calibratorstate")
+	state.CalibratorState = CalibratorState(calibratorState)
+	errs = append(errs, err)
+
+	state.CoverMoving, err = c.Alpaca.GetBooleanResponseContext(ctx, "covercalibrator", c.DeviceNumber, "covermoving")
+	errs = append(errs, err)
+
+	coverState, err := c.Alpaca.GetInt32ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "coverstate")
+	state.CoverState = CoverState(coverState)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -118,7 +298,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (c *CoverCalibrator) GetDescription() (string, error) {
-	return c.Alpaca.GetDescription("covercalibrator", c.DeviceNumber)
+	return c.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetDescriptionContext(ctx context.Context) (string, error) {
+	return c.Alpaca.GetDescriptionContext(ctx, "covercalibrator", c.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (c *CoverCalibrator) GetSupportedActions() ([]string, error) {
+	return c.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return c.Alpaca.GetSupportedActionsContext(ctx, "covercalibrator", c.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (c *CoverCalibrator) Action(action string, parameters string) (string, error) {
+	return c.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return c.Alpaca.ActionContext(ctx, "covercalibrator", c.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (c *CoverCalibrator) CommandBlind(command string, raw bool) error {
+	return c.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return c.Alpaca.CommandBlindContext(ctx, "covercalibrator", c.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (c *CoverCalibrator) CommandBool(command string, raw bool) (bool, error) {
+	return c.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return c.Alpaca.CommandBoolContext(ctx, "covercalibrator", c.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (c *CoverCalibrator) CommandString(command string, raw bool) (string, error) {
+	return c.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return c.Alpaca.CommandStringContext(ctx, "covercalibrator", c.DeviceNumber, command, raw)
 }
 
 /*
@@ -128,7 +420,16 @@ GetBrightness()
 @see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/get_covercalibrator__device_number__brightness
 */
 func (c *CoverCalibrator) GetBrightness() (float64, error) {
-	return c.Alpaca.GetFloat64Response("covercalibrator", c.DeviceNumber, "brightness")
+	return c.GetBrightnessContext(context.Background())
+}
+
+/*
+GetBrightnessContext()
+
+GetBrightness() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetBrightnessContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "brightness")
 }
 
 /*
@@ -139,7 +440,16 @@ GetStatus()
 @see https://ascom-standards.org/Help/Platform/html/T_ASCOM_DeviceInterface_CalibratorStatus.htm
 */
 func (c *CoverCalibrator) GetStatus() (string, error) {
-	status, err := c.Alpaca.GetInt32Response("covercalibrator", c.DeviceNumber, "calibratorstate")
+	return c.GetStatusContext(context.Background())
+}
+
+/*
+GetStatusContext()
+
+GetStatus() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetStatusContext(ctx context.Context) (string, error) {
+	status, err := c.Alpaca.GetInt32ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "calibratorstate")
 	return CalibratorState(status).String(), err
 }
 
@@ -151,10 +461,60 @@ GetCoverStatus()
 @see https://ascom-standards.org/Help/Platform/html/T_ASCOM_DeviceInterface_CoverStatus.htm
 */
 func (c *CoverCalibrator) GetCoverStatus() (string, error) {
-	status, err := c.Alpaca.GetInt32Response("covercalibrator", c.DeviceNumber, "coverstate")
+	return c.GetCoverStatusContext(context.Background())
+}
+
+/*
+GetCoverStatusContext()
+
+GetCoverStatus() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetCoverStatusContext(ctx context.Context) (string, error) {
+	status, err := c.Alpaca.GetInt32ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "coverstate")
 	return CoverState(status).String(), err
 }
 
+/*
+GetCalibratorState()
+
+@returns the state of the calibration device as a CalibratorState, e.g., for comparison whilst waiting for the
+calibrator to become ready.
+@see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/get_covercalibrator__device_number__calibratorstate
+*/
+func (c *CoverCalibrator) GetCalibratorState() (CalibratorState, error) {
+	return c.GetCalibratorStateContext(context.Background())
+}
+
+/*
+GetCalibratorStateContext()
+
+GetCalibratorState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetCalibratorStateContext(ctx context.Context) (CalibratorState, error) {
+	state, err := c.Alpaca.GetInt32ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "calibratorstate")
+	return CalibratorState(state), err
+}
+
+/*
+GetCoverState()
+
+@returns the state of the device cover as a CoverState, e.g., for comparison whilst waiting for the cover to move.
+@see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/get_covercalibrator__device_number__coverstate
+*/
+func (c *CoverCalibrator) GetCoverState() (CoverState, error) {
+	return c.GetCoverStateContext(context.Background())
+}
+
+/*
+GetCoverStateContext()
+
+GetCoverState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetCoverStateContext(ctx context.Context) (CoverState, error) {
+	state, err := c.Alpaca.GetInt32ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "coverstate")
+	return CoverState(state), err
+}
+
 /*
 GetMaxBrightness()
 
@@ -162,7 +522,16 @@ GetMaxBrightness()
 @see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/get_covercalibrator__device_number__maxbrightness
 */
 func (c *CoverCalibrator) GetMaxBrightness() (int32, error) {
-	return c.Alpaca.GetInt32Response("covercalibrator", c.DeviceNumber, "maxbrightness")
+	return c.GetMaxBrightnessContext(context.Background())
+}
+
+/*
+GetMaxBrightnessContext()
+
+GetMaxBrightness() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) GetMaxBrightnessContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "covercalibrator", c.DeviceNumber, "maxbrightness")
 }
 
 /*
@@ -172,16 +541,21 @@ SetCalibratorOn()
 @see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/put_covercalibrator__device_number__calibratoron
 */
 func (c *CoverCalibrator) SetCalibratorOn(brightness int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetCalibratorOnContext(context.Background(), brightness)
+}
 
+/*
+SetCalibratorOnContext()
+
+SetCalibratorOn() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) SetCalibratorOnContext(ctx context.Context, brightness int32) error {
 	var form map[string]string = map[string]string{
 		// The brightness value that makes the calibrator deliver its maximum illumination.
-		"Brightness":          fmt.Sprintf("%d", brightness),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"Brightness": fmt.Sprintf("%d", brightness),
 	}
 
-	return c.Alpaca.Put("covercalibrator", c.DeviceNumber, "calibratoron", form)
+	return c.Alpaca.PutContext(ctx, "covercalibrator", c.DeviceNumber, "calibratoron", form)
 }
 
 /*
@@ -191,14 +565,18 @@ SetCalibratorOff()
 @see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/put_covercalibrator__device_number__calibratoroff
 */
 func (c *CoverCalibrator) SetCalibratorOff() error {
-	c.Alpaca.TransactionId++
+	return c.SetCalibratorOffContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
-	}
+/*
+SetCalibratorOffContext()
 
-	return c.Alpaca.Put("covercalibrator", c.DeviceNumber, "calibratoroff", form)
+SetCalibratorOff() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) SetCalibratorOffContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return c.Alpaca.PutContext(ctx, "covercalibrator", c.DeviceNumber, "calibratoroff", form)
 }
 
 /*
@@ -208,14 +586,18 @@ CloseCover()
 @see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/put_covercalibrator__device_number__closecover
 */
 func (c *CoverCalibrator) CloseCover() error {
-	c.Alpaca.TransactionId++
+	return c.CloseCoverContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
-	}
+/*
+CloseCoverContext()
+
+CloseCover() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) CloseCoverContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return c.Alpaca.Put("covercalibrator", c.DeviceNumber, "closecover", form)
+	return c.Alpaca.PutContext(ctx, "covercalibrator", c.DeviceNumber, "closecover", form)
 }
 
 /*
@@ -225,14 +607,18 @@ HaltCover()
 @see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/put_covercalibrator__device_number__haltcover
 */
 func (c *CoverCalibrator) HaltCover() error {
-	c.Alpaca.TransactionId++
+	return c.HaltCoverContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
-	}
+/*
+HaltCoverContext()
+
+HaltCover() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) HaltCoverContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return c.Alpaca.Put("covercalibrator", c.DeviceNumber, "haltcover", form)
+	return c.Alpaca.PutContext(ctx, "covercalibrator", c.DeviceNumber, "haltcover", form)
 }
 
 /*
@@ -242,12 +628,16 @@ OpenCover()
 @see https://ascom-standards.org/api/#/CoverCalibrator%20Specific%20Methods/put_covercalibrator__device_number__opencover
 */
 func (c *CoverCalibrator) OpenCover() error {
-	c.Alpaca.TransactionId++
+	return c.OpenCoverContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
-	}
+/*
+OpenCoverContext()
+
+OpenCover() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CoverCalibrator) OpenCoverContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return c.Alpaca.Put("covercalibrator", c.DeviceNumber, "opencover", form)
+	return c.Alpaca.PutContext(ctx, "covercalibrator", c.DeviceNumber, "opencover", form)
 }
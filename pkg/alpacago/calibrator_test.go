@@ -151,6 +151,36 @@ func TestNewCalibratorCoverGetCoverStatus(t *testing.T) {
 	}
 }
 
+func TestNewCalibratorCoverGetCalibratorState(t *testing.T) {
+	calibrator.SetConnected(true)
+
+	calibrator.SetCalibratorOn(90)
+
+	var got, err = calibrator.GetCalibratorState()
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if got != CalibratorReady && got != CalibratorNotReady && got != CalibratorUnknown {
+		t.Errorf("got %v, but expected the calibrator to be ready", got)
+	}
+}
+
+func TestNewCalibratorCoverGetCoverState(t *testing.T) {
+	calibrator.SetConnected(true)
+
+	var got, err = calibrator.GetCoverState()
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if got != CoverClosed && got != CoverOpen && got != CoverMoving && got != CoverUnknown {
+		t.Errorf("got %v, but expected the calibrator to be either open, close or moving", got)
+	}
+}
+
 func TestNewCalibratorCoverGetMaxBrightness(t *testing.T) {
 	calibrator.SetConnected(true)
 
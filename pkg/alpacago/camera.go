@@ -1,6 +1,7 @@
 package alpacago
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -57,6 +58,26 @@ func (s SensorType) String() string {
 type Camera struct {
 	Alpaca       *ASCOMAlpacaAPIClient
 	DeviceNumber uint
+	// The time allowed beyond the duration of an exposure for the image to be ready in Expose(), or zero for
+	// EXPOSURE_DOWNLOAD_TIMEOUT:
+	ExposureTimeout time.Duration
+}
+
+/*
+CameraDeviceState
+
+The operational properties of the camera, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type CameraDeviceState struct {
+	CameraState         OperationalState `json:"CameraState"`
+	CCDTemperature      float64          `json:"CCDTemperature"`
+	CoolerPower         float64          `json:"CoolerPower"`
+	HeatSinkTemperature float64          `json:"HeatSinkTemperature"`
+	ImageReady          bool             `json:"ImageReady"`
+	IsPulseGuiding      bool             `json:"IsPulseGuiding"`
+	PercentCompleted    int32            `json:"PercentCompleted"`
+	TimeStamp           string           `json:"TimeStamp"`
 }
 
 func NewCamera(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *Camera {
@@ -70,6 +91,17 @@ func NewCamera(clientId uint32, secure bool, domain string, ip string, port int3
 	return &camera
 }
 
+func NewCameraWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *Camera {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	camera := Camera{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &camera
+}
+
 /*
 IsConnected() common method to all ASCOM Alpaca compliant devices
 
@@ -77,7 +109,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (c *Camera) IsConnected() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "connected")
+	return c.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) IsConnectedContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "connected")
 }
 
 /*
@@ -88,16 +129,164 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (c *Camera) SetConnected(connected bool) error {
-	c.Alpaca.TransactionId++
+	return c.SetConnectedContext(context.Background(), connected)
+}
+
+/*
+SetConnectedContext()
 
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (c *Camera) Connect() error {
+	return c.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) ConnectContext(ctx context.Context) error {
+	return c.Alpaca.ConnectContext(ctx, "camera", c.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (c *Camera) Disconnect() error {
+	return c.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) DisconnectContext(ctx context.Context) error {
+	return c.Alpaca.DisconnectContext(ctx, "camera", c.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (c *Camera) IsConnecting() (bool, error) {
+	return c.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) IsConnectingContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.IsConnectingContext(ctx, "camera", c.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the camera in a single round trip, see CameraDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (c *Camera) GetDeviceState() (*CameraDeviceState, error) {
+	return c.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetDeviceStateContext(ctx context.Context) (*CameraDeviceState, error) {
+	values, err := c.Alpaca.GetDeviceStateContext(ctx, "camera", c.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := CameraDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the camera from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (c *Camera) GetDeviceStateWithFallback() (*CameraDeviceState, error) {
+	return c.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetDeviceStateWithFallbackContext(ctx context.Context) (*CameraDeviceState, error) {
+	state, err := c.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "connected", form)
+	state = &CameraDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	cameraState, err := c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "camerastate")
+	state.CameraState = OperationalState(cameraState)
+	errs = append(errs, err)
+
+	state.CCDTemperature, err = c.GetCCDTemperatureContext(ctx)
+	errs = append(errs, err)
+
+	state.CoolerPower, err = c.GetCoolerPowerLevelContext(ctx)
+	errs = append(errs, err)
+
+	state.HeatSinkTemperature, err = c.GetHeatSinkTemperatureContext(ctx)
+	errs = append(errs, err)
+
+	state.ImageReady, err = c.IsImageReadyContext(ctx)
+	errs = append(errs, err)
+
+	state.IsPulseGuiding, err = c.IsPulseGuidingContext(ctx)
+	errs = append(errs, err)
+
+	state.PercentCompleted, err = c.GetCurrentOperationPercentageCompleteContext(ctx)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -107,7 +296,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (c *Camera) GetDescription() (string, error) {
-	return c.Alpaca.GetDescription("camera", c.DeviceNumber)
+	return c.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetDescriptionContext(ctx context.Context) (string, error) {
+	return c.Alpaca.GetDescriptionContext(ctx, "camera", c.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (c *Camera) GetSupportedActions() ([]string, error) {
+	return c.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return c.Alpaca.GetSupportedActionsContext(ctx, "camera", c.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (c *Camera) Action(action string, parameters string) (string, error) {
+	return c.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return c.Alpaca.ActionContext(ctx, "camera", c.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (c *Camera) CommandBlind(command string, raw bool) error {
+	return c.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return c.Alpaca.CommandBlindContext(ctx, "camera", c.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (c *Camera) CommandBool(command string, raw bool) (bool, error) {
+	return c.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return c.Alpaca.CommandBoolContext(ctx, "camera", c.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (c *Camera) CommandString(command string, raw bool) (string, error) {
+	return c.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return c.Alpaca.CommandStringContext(ctx, "camera", c.DeviceNumber, command, raw)
 }
 
 /*
@@ -117,7 +418,16 @@ GetBayerOffsetX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__bayeroffsetx
 */
 func (c *Camera) GetBayerOffsetX() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "bayeroffsetx")
+	return c.GetBayerOffsetXContext(context.Background())
+}
+
+/*
+GetBayerOffsetXContext()
+
+GetBayerOffsetX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetBayerOffsetXContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "bayeroffsetx")
 }
 
 /*
@@ -127,7 +437,16 @@ GetBayerOffsetY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__bayeroffsety
 */
 func (c *Camera) GetBayerOffsetY() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "bayeroffsety")
+	return c.GetBayerOffsetYContext(context.Background())
+}
+
+/*
+GetBayerOffsetYContext()
+
+GetBayerOffsetY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetBayerOffsetYContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "bayeroffsety")
 }
 
 /*
@@ -137,7 +456,16 @@ GetBinX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__binx
 */
 func (c *Camera) GetBinX() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "binx")
+	return c.GetBinXContext(context.Background())
+}
+
+/*
+GetBinXContext()
+
+GetBinX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetBinXContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "binx")
 }
 
 /*
@@ -147,15 +475,20 @@ SetBinX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__binx
 */
 func (c *Camera) SetBinX(binX int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetBinXContext(context.Background(), binX)
+}
+
+/*
+SetBinXContext()
 
+SetBinX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetBinXContext(ctx context.Context, binX int32) error {
 	var form map[string]string = map[string]string{
-		"BinX":                fmt.Sprintf("%d", binX),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"BinX": fmt.Sprintf("%d", binX),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "binx", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "binx", form)
 }
 
 /*
@@ -165,7 +498,16 @@ GetBinY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__biny
 */
 func (c *Camera) GetBinY() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "biny")
+	return c.GetBinYContext(context.Background())
+}
+
+/*
+GetBinYContext()
+
+GetBinY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetBinYContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "biny")
 }
 
 /*
@@ -175,15 +517,20 @@ SetBinY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__biny
 */
 func (c *Camera) SetBinY(binY int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetBinYContext(context.Background(), binY)
+}
+
+/*
+SetBinYContext()
 
+SetBinY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetBinYContext(ctx context.Context, binY int32) error {
 	var form map[string]string = map[string]string{
-		"BinY":                fmt.Sprintf("%d", binY),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"BinY": fmt.Sprintf("%d", binY),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "biny", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "biny", form)
 }
 
 /*
@@ -194,7 +541,16 @@ The operational state is specified as an integer value from the OperationalState
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__camerastate
 */
 func (c *Camera) GetOperationalState() (string, error) {
-	state, err := c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "camerastate")
+	return c.GetOperationalStateContext(context.Background())
+}
+
+/*
+GetOperationalStateContext()
+
+GetOperationalState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetOperationalStateContext(ctx context.Context) (string, error) {
+	state, err := c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "camerastate")
 	return OperationalState(state).String(), err
 }
 
@@ -205,7 +561,16 @@ GetCCDSizeX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__cameraxsize
 */
 func (c *Camera) GetCCDSizeX() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "cameraxsize")
+	return c.GetCCDSizeXContext(context.Background())
+}
+
+/*
+GetCCDSizeXContext()
+
+GetCCDSizeX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetCCDSizeXContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "cameraxsize")
 }
 
 /*
@@ -215,7 +580,16 @@ GetCCDSizeY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__cameraysize
 */
 func (c *Camera) GetCCDSizeY() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "cameraysize")
+	return c.GetCCDSizeYContext(context.Background())
+}
+
+/*
+GetCCDSizeYContext()
+
+GetCCDSizeY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetCCDSizeYContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "cameraysize")
 }
 
 /*
@@ -225,7 +599,16 @@ CanAbortExposure()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__canabortexposure
 */
 func (c *Camera) CanAbortExposure() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "canabortexposure")
+	return c.CanAbortExposureContext(context.Background())
+}
+
+/*
+CanAbortExposureContext()
+
+CanAbortExposure() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CanAbortExposureContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "canabortexposure")
 }
 
 /*
@@ -235,7 +618,16 @@ CanFastReadout()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__canfastreadout
 */
 func (c *Camera) CanFastReadout() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "canfastreadout")
+	return c.CanFastReadoutContext(context.Background())
+}
+
+/*
+CanFastReadoutContext()
+
+CanFastReadout() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CanFastReadoutContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "canfastreadout")
 }
 
 /*
@@ -245,7 +637,16 @@ CanAsymmetricBin()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__canasymmetricbin
 */
 func (c *Camera) CanAsymmetricBin() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "canasymmetricbin")
+	return c.CanAsymmetricBinContext(context.Background())
+}
+
+/*
+CanAsymmetricBinContext()
+
+CanAsymmetricBin() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CanAsymmetricBinContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "canasymmetricbin")
 }
 
 /*
@@ -255,7 +656,16 @@ CanGetCoolerPower()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__cangetcoolerpower
 */
 func (c *Camera) CanGetCoolerPower() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "cangetcoolerpower")
+	return c.CanGetCoolerPowerContext(context.Background())
+}
+
+/*
+CanGetCoolerPowerContext()
+
+CanGetCoolerPower() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CanGetCoolerPowerContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "cangetcoolerpower")
 }
 
 /*
@@ -265,7 +675,16 @@ CanPulseGuide()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__canpulseguide
 */
 func (c *Camera) CanPulseGuide() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "canpulseguide")
+	return c.CanPulseGuideContext(context.Background())
+}
+
+/*
+CanPulseGuideContext()
+
+CanPulseGuide() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CanPulseGuideContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "canpulseguide")
 }
 
 /*
@@ -275,7 +694,16 @@ CanSetCCDTemperature()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__cansetccdtemperature
 */
 func (c *Camera) CanSetCCDTemperature() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "cansetccdtemperature")
+	return c.CanSetCCDTemperatureContext(context.Background())
+}
+
+/*
+CanSetCCDTemperatureContext()
+
+CanSetCCDTemperature() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CanSetCCDTemperatureContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "cansetccdtemperature")
 }
 
 /*
@@ -285,7 +713,16 @@ CanStopExposure()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__canstopexposure
 */
 func (c *Camera) CanStopExposure() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "canstopexposure")
+	return c.CanStopExposureContext(context.Background())
+}
+
+/*
+CanStopExposureContext()
+
+CanStopExposure() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) CanStopExposureContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "canstopexposure")
 }
 
 /*
@@ -295,7 +732,16 @@ GetCCDTemperature()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__ccdtemperature
 */
 func (c *Camera) GetCCDTemperature() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "ccdtemperature")
+	return c.GetCCDTemperatureContext(context.Background())
+}
+
+/*
+GetCCDTemperatureContext()
+
+GetCCDTemperature() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetCCDTemperatureContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "ccdtemperature")
 }
 
 /*
@@ -305,7 +751,16 @@ IsCoolerOn()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__cooleron
 */
 func (c *Camera) IsCoolerOn() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "cooleron")
+	return c.IsCoolerOnContext(context.Background())
+}
+
+/*
+IsCoolerOnContext()
+
+IsCoolerOn() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) IsCoolerOnContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "cooleron")
 }
 
 /*
@@ -315,16 +770,21 @@ TurnCoolerOn()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__cooleron
 */
 func (c *Camera) TurnCoolerOn() error {
-	c.Alpaca.TransactionId++
+	return c.TurnCoolerOnContext(context.Background())
+}
 
+/*
+TurnCoolerOnContext()
+
+TurnCoolerOn() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) TurnCoolerOnContext(ctx context.Context) error {
 	var form map[string]string = map[string]string{
 		// Set True to turn the camera cooler on:
-		"CoolerOn":            fmt.Sprintf("%t", true),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"CoolerOn": fmt.Sprintf("%t", true),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "cooleron", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "cooleron", form)
 }
 
 /*
@@ -334,16 +794,21 @@ TurnCoolerOff()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__cooleron
 */
 func (c *Camera) TurnCoolerOff() error {
-	c.Alpaca.TransactionId++
+	return c.TurnCoolerOffContext(context.Background())
+}
+
+/*
+TurnCoolerOffContext()
 
+TurnCoolerOff() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) TurnCoolerOffContext(ctx context.Context) error {
 	var form map[string]string = map[string]string{
 		// Set True to turn the camera cooler on:
-		"CoolerOn":            fmt.Sprintf("%t", false),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"CoolerOn": fmt.Sprintf("%t", false),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "cooleron", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "cooleron", form)
 }
 
 /*
@@ -353,7 +818,16 @@ GetCoolerPowerLevel()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__coolerpower
 */
 func (c *Camera) GetCoolerPowerLevel() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "coolerpower")
+	return c.GetCoolerPowerLevelContext(context.Background())
+}
+
+/*
+GetCoolerPowerLevelContext()
+
+GetCoolerPowerLevel() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetCoolerPowerLevelContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "coolerpower")
 }
 
 /*
@@ -363,7 +837,16 @@ GetGainInElectronsPerADUnit()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__electronsperadu
 */
 func (c *Camera) GetGainInElectronsPerADUnit() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "electronsperadu")
+	return c.GetGainInElectronsPerADUnitContext(context.Background())
+}
+
+/*
+GetGainInElectronsPerADUnitContext()
+
+GetGainInElectronsPerADUnit() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetGainInElectronsPerADUnitContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "electronsperadu")
 }
 
 /*
@@ -381,10 +864,43 @@ When de-serialising to an object it is essential to know the array Rank beforeha
 class can be used. This can be achieved through a regular expression or by direct parsing of the returned
 JSON string to extract the Type and Rank values before de-serialising.
 
+The image is requested with the ImageBytes binary transfer protocol, which is considerably faster for
+large sensors, falling back to the JSON image array if the server does not support ImageBytes.
+
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__imagearray
+@see https://ascom-standards.org/Developer/AlpacaImageBytes.pdf
 */
 func (c *Camera) GetExposure() ([][]uint32, uint32, error) {
-	return c.Alpaca.GetUInt32Rank2ArrayResponse("camera", c.DeviceNumber, "imagearray")
+	return c.GetExposureContext(context.Background())
+}
+
+/*
+GetExposureContext()
+
+GetExposure() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetExposureContext(ctx context.Context) ([][]uint32, uint32, error) {
+	return c.Alpaca.GetImageBytesResponseContext(ctx, "camera", c.DeviceNumber, "imagearray")
+}
+
+/*
+GetImage()
+
+@returns the image from the last exposure, of any element type and of rank 2 (monochrome or Bayered) or
+rank 3 (colour or multi-plane), with the pixels held in a single flat slice, see Image.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__imagearray
+*/
+func (c *Camera) GetImage() (*Image, error) {
+	return c.GetImageContext(context.Background())
+}
+
+/*
+GetImageContext()
+
+GetImage() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetImageContext(ctx context.Context) (*Image, error) {
+	return c.Alpaca.GetImageArrayResponseContext(ctx, "camera", c.DeviceNumber, "imagearray")
 }
 
 /*
@@ -394,7 +910,16 @@ GetExposureMax()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__exposuremax
 */
 func (c *Camera) GetExposureMax() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "exposuremax")
+	return c.GetExposureMaxContext(context.Background())
+}
+
+/*
+GetExposureMaxContext()
+
+GetExposureMax() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetExposureMaxContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "exposuremax")
 }
 
 /*
@@ -404,17 +929,35 @@ GetExposureMin()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__exposuremin
 */
 func (c *Camera) GetExposureMin() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "exposuremin")
+	return c.GetExposureMinContext(context.Background())
+}
+
+/*
+GetExposureMinContext()
+
+GetExposureMin() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetExposureMinContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "exposuremin")
 }
 
 /*
 GetExposureResolution()
 
-@returns the smallest increment in exposure time supported by StartExposure.
-@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__exposureresolution
+@returns the smallest increment in exposure time supported by StartExposure.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__exposureresolution
+*/
+func (c *Camera) GetExposureResolution() (float64, error) {
+	return c.GetExposureResolutionContext(context.Background())
+}
+
+/*
+GetExposureResolutionContext()
+
+GetExposureResolution() with a context, which controls the cancellation and deadline of the request.
 */
-func (c *Camera) GetExposureResolution() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "exposureresolution")
+func (c *Camera) GetExposureResolutionContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "exposureresolution")
 }
 
 /*
@@ -424,7 +967,16 @@ IsFastReadoutEnabled()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__fastreadout
 */
 func (c *Camera) IsFastReadoutEnabled() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "fastreadout")
+	return c.IsFastReadoutEnabledContext(context.Background())
+}
+
+/*
+IsFastReadoutEnabledContext()
+
+IsFastReadoutEnabled() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) IsFastReadoutEnabledContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "fastreadout")
 }
 
 /*
@@ -434,16 +986,21 @@ EnableFastReadout()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__fastreadout
 */
 func (c *Camera) EnableFastReadout() error {
-	c.Alpaca.TransactionId++
+	return c.EnableFastReadoutContext(context.Background())
+}
+
+/*
+EnableFastReadoutContext()
 
+EnableFastReadout() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) EnableFastReadoutContext(ctx context.Context) error {
 	var form map[string]string = map[string]string{
 		// Set True to enable fast readout mode:
-		"FastReadout":         fmt.Sprintf("%t", true),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"FastReadout": fmt.Sprintf("%t", true),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "fastreadout", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "fastreadout", form)
 }
 
 /*
@@ -453,16 +1010,21 @@ DisableFastReadout()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__fastreadout
 */
 func (c *Camera) DisableFastReadout() error {
-	c.Alpaca.TransactionId++
+	return c.DisableFastReadoutContext(context.Background())
+}
+
+/*
+DisableFastReadoutContext()
 
+DisableFastReadout() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) DisableFastReadoutContext(ctx context.Context) error {
 	var form map[string]string = map[string]string{
 		// Set False to disable fast readout mode:
-		"FastReadout":         fmt.Sprintf("%t", false),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"FastReadout": fmt.Sprintf("%t", false),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "fastreadout", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "fastreadout", form)
 }
 
 /*
@@ -472,7 +1034,16 @@ GetFullWellCapacity()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__fullwellcapacity
 */
 func (c *Camera) GetFullWellCapacity() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "fullwellcapacity")
+	return c.GetFullWellCapacityContext(context.Background())
+}
+
+/*
+GetFullWellCapacityContext()
+
+GetFullWellCapacity() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetFullWellCapacityContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "fullwellcapacity")
 }
 
 /*
@@ -482,7 +1053,16 @@ GetGain()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__gain
 */
 func (c *Camera) GetGain() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "gain")
+	return c.GetGainContext(context.Background())
+}
+
+/*
+GetGainContext()
+
+GetGain() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetGainContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "gain")
 }
 
 /*
@@ -492,16 +1072,21 @@ SetGain()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__gain
 */
 func (c *Camera) SetGain(gain int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetGainContext(context.Background(), gain)
+}
+
+/*
+SetGainContext()
 
+SetGain() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetGainContext(ctx context.Context, gain int32) error {
 	var form map[string]string = map[string]string{
 		// Set the gain (GAIN VALUE MODE) OR the index of the selected camera gain description in the Gains array (GAINS INDEX MODE).
-		"Gain":                fmt.Sprintf("%d", gain),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"Gain": fmt.Sprintf("%d", gain),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "gain", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "gain", form)
 }
 
 /*
@@ -511,7 +1096,16 @@ GetGainMax()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__gainmax
 */
 func (c *Camera) GetGainMax() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "gainmax")
+	return c.GetGainMaxContext(context.Background())
+}
+
+/*
+GetGainMaxContext()
+
+GetGainMax() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetGainMaxContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "gainmax")
 }
 
 /*
@@ -521,7 +1115,16 @@ GetGainMin()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__gainmin
 */
 func (c *Camera) GetGainMin() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "gainmin")
+	return c.GetGainMinContext(context.Background())
+}
+
+/*
+GetGainMinContext()
+
+GetGainMin() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetGainMinContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "gainmin")
 }
 
 /*
@@ -531,7 +1134,16 @@ GetGains()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__gains
 */
 func (c *Camera) GetGains() ([]string, error) {
-	return c.Alpaca.GetStringListResponse("camera", c.DeviceNumber, "gains")
+	return c.GetGainsContext(context.Background())
+}
+
+/*
+GetGainsContext()
+
+GetGains() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetGainsContext(ctx context.Context) ([]string, error) {
+	return c.Alpaca.GetStringListResponseContext(ctx, "camera", c.DeviceNumber, "gains")
 }
 
 /*
@@ -541,7 +1153,16 @@ HasShutter()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__hasshutter
 */
 func (c *Camera) HasShutter() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "hasshutter")
+	return c.HasShutterContext(context.Background())
+}
+
+/*
+HasShutterContext()
+
+HasShutter() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) HasShutterContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "hasshutter")
 }
 
 /*
@@ -551,7 +1172,16 @@ GetHeatSinkTemperature()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__heatsinktemperature
 */
 func (c *Camera) GetHeatSinkTemperature() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "heatsinktemperature")
+	return c.GetHeatSinkTemperatureContext(context.Background())
+}
+
+/*
+GetHeatSinkTemperatureContext()
+
+GetHeatSinkTemperature() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetHeatSinkTemperatureContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "heatsinktemperature")
 }
 
 /*
@@ -561,7 +1191,16 @@ IsImageReady()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__imageready
 */
 func (c *Camera) IsImageReady() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "imageready")
+	return c.IsImageReadyContext(context.Background())
+}
+
+/*
+IsImageReadyContext()
+
+IsImageReady() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) IsImageReadyContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "imageready")
 }
 
 /*
@@ -571,7 +1210,16 @@ IsPulseGuiding()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__ispulseguiding
 */
 func (c *Camera) IsPulseGuiding() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("camera", c.DeviceNumber, "ispulseguiding")
+	return c.IsPulseGuidingContext(context.Background())
+}
+
+/*
+IsPulseGuidingContext()
+
+IsPulseGuiding() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) IsPulseGuidingContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "camera", c.DeviceNumber, "ispulseguiding")
 }
 
 /*
@@ -581,7 +1229,16 @@ GetLastExposureStartTime()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__lastexposurestarttime
 */
 func (c *Camera) GetLastExposureStartTime() (*time.Time, error) {
-	starttime, err := c.Alpaca.GetStringResponse("camera", c.DeviceNumber, "lastexposurestarttime")
+	return c.GetLastExposureStartTimeContext(context.Background())
+}
+
+/*
+GetLastExposureStartTimeContext()
+
+GetLastExposureStartTime() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetLastExposureStartTimeContext(ctx context.Context) (*time.Time, error) {
+	starttime, err := c.Alpaca.GetStringResponseContext(ctx, "camera", c.DeviceNumber, "lastexposurestarttime")
 
 	if err != nil {
 		return nil, err
@@ -607,7 +1264,16 @@ GetLastExposureDuration()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__lastexposureduration
 */
 func (c *Camera) GetLastExposureDuration() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "lastexposureduration")
+	return c.GetLastExposureDurationContext(context.Background())
+}
+
+/*
+GetLastExposureDurationContext()
+
+GetLastExposureDuration() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetLastExposureDurationContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "lastexposureduration")
 }
 
 /*
@@ -617,7 +1283,16 @@ GetMaxADU()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__maxadu
 */
 func (c *Camera) GetMaxADU() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "maxadu")
+	return c.GetMaxADUContext(context.Background())
+}
+
+/*
+GetMaxADUContext()
+
+GetMaxADU() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetMaxADUContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "maxadu")
 }
 
 /*
@@ -627,7 +1302,16 @@ GetMaxBinX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__maxbinx
 */
 func (c *Camera) GetMaxBinX() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "maxbinx")
+	return c.GetMaxBinXContext(context.Background())
+}
+
+/*
+GetMaxBinXContext()
+
+GetMaxBinX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetMaxBinXContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "maxbinx")
 }
 
 /*
@@ -637,7 +1321,16 @@ GetMaxBinY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__maxbiny
 */
 func (c *Camera) GetMaxBinY() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "maxbiny")
+	return c.GetMaxBinYContext(context.Background())
+}
+
+/*
+GetMaxBinYContext()
+
+GetMaxBinY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetMaxBinYContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "maxbiny")
 }
 
 /*
@@ -647,7 +1340,16 @@ GetSubFrameWidth()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__numx
 */
 func (c *Camera) GetSubFrameWidth() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "numx")
+	return c.GetSubFrameWidthContext(context.Background())
+}
+
+/*
+GetSubFrameWidthContext()
+
+GetSubFrameWidth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetSubFrameWidthContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "numx")
 }
 
 /*
@@ -657,16 +1359,21 @@ SetSubFrameWidth()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__numx
 */
 func (c *Camera) SetSubFrameWidth(numX int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetSubFrameWidthContext(context.Background(), numX)
+}
 
+/*
+SetSubFrameWidthContext()
+
+SetSubFrameWidth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetSubFrameWidthContext(ctx context.Context, numX int32) error {
 	var form map[string]string = map[string]string{
 		// Set the subframe width in pixels.
-		"NumX":                fmt.Sprintf("%d", numX),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"NumX": fmt.Sprintf("%d", numX),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "numx", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "numx", form)
 }
 
 /*
@@ -676,7 +1383,16 @@ GetSubFrameHeight()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__numy
 */
 func (c *Camera) GetSubFrameHeight() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "numy")
+	return c.GetSubFrameHeightContext(context.Background())
+}
+
+/*
+GetSubFrameHeightContext()
+
+GetSubFrameHeight() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetSubFrameHeightContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "numy")
 }
 
 /*
@@ -686,16 +1402,121 @@ SetSubFrameHeight()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__numy
 */
 func (c *Camera) SetSubFrameHeight(numY int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetSubFrameHeightContext(context.Background(), numY)
+}
+
+/*
+SetSubFrameHeightContext()
 
+SetSubFrameHeight() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetSubFrameHeightContext(ctx context.Context, numY int32) error {
 	var form map[string]string = map[string]string{
 		// Set the subframe height in pixels.
-		"NumY":                fmt.Sprintf("%d", numY),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"NumY": fmt.Sprintf("%d", numY),
+	}
+
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "numy", form)
+}
+
+/*
+GetOffset()
+
+@returns the camera's offset (OFFSET VALUE MODE) OR the index of the selected camera offset description in the Offsets array (OFFSETS INDEX MODE).
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__offset
+*/
+func (c *Camera) GetOffset() (int32, error) {
+	return c.GetOffsetContext(context.Background())
+}
+
+/*
+GetOffsetContext()
+
+GetOffset() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetOffsetContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "offset")
+}
+
+/*
+SetOffset()
+
+@returns an error or nil, if nil it sets the offset to the specified value.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__offset
+*/
+func (c *Camera) SetOffset(offset int32) error {
+	return c.SetOffsetContext(context.Background(), offset)
+}
+
+/*
+SetOffsetContext()
+
+SetOffset() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetOffsetContext(ctx context.Context, offset int32) error {
+	var form map[string]string = map[string]string{
+		// Set the offset (OFFSET VALUE MODE) OR the index of the selected camera offset description in the Offsets array (OFFSETS INDEX MODE).
+		"Offset": fmt.Sprintf("%d", offset),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "numy", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "offset", form)
+}
+
+/*
+GetOffsetMax()
+
+@returns the maximum value of Offset.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__offsetmax
+*/
+func (c *Camera) GetOffsetMax() (int32, error) {
+	return c.GetOffsetMaxContext(context.Background())
+}
+
+/*
+GetOffsetMaxContext()
+
+GetOffsetMax() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetOffsetMaxContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "offsetmax")
+}
+
+/*
+GetOffsetMin()
+
+@returns the minimum value of Offset.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__offsetmin
+*/
+func (c *Camera) GetOffsetMin() (int32, error) {
+	return c.GetOffsetMinContext(context.Background())
+}
+
+/*
+GetOffsetMinContext()
+
+GetOffsetMin() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetOffsetMinContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "offsetmin")
+}
+
+/*
+GetOffsets()
+
+@returns the Offsets supported by the camera.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__offsets
+*/
+func (c *Camera) GetOffsets() ([]string, error) {
+	return c.GetOffsetsContext(context.Background())
+}
+
+/*
+GetOffsetsContext()
+
+GetOffsets() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetOffsetsContext(ctx context.Context) ([]string, error) {
+	return c.Alpaca.GetStringListResponseContext(ctx, "camera", c.DeviceNumber, "offsets")
 }
 
 /*
@@ -706,7 +1527,16 @@ GetCurrentOperationPercentageComplete()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__percentcompleted
 */
 func (c *Camera) GetCurrentOperationPercentageComplete() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "percentcompleted")
+	return c.GetCurrentOperationPercentageCompleteContext(context.Background())
+}
+
+/*
+GetCurrentOperationPercentageCompleteContext()
+
+GetCurrentOperationPercentageComplete() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetCurrentOperationPercentageCompleteContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "percentcompleted")
 }
 
 /*
@@ -716,7 +1546,16 @@ GetPixelSizeX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__pixelsizex
 */
 func (c *Camera) GetPixelSizeX() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "pixelsizex")
+	return c.GetPixelSizeXContext(context.Background())
+}
+
+/*
+GetPixelSizeXContext()
+
+GetPixelSizeX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetPixelSizeXContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "pixelsizex")
 }
 
 /*
@@ -726,7 +1565,16 @@ GetPixelSizeY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__pixelsizey
 */
 func (c *Camera) GetPixelSizeY() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "pixelsizey")
+	return c.GetPixelSizeYContext(context.Background())
+}
+
+/*
+GetPixelSizeYContext()
+
+GetPixelSizeY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetPixelSizeYContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "pixelsizey")
 }
 
 /*
@@ -736,7 +1584,16 @@ GetReadOutMode()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__readoutmode
 */
 func (c *Camera) GetReadOutMode() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "readoutmode")
+	return c.GetReadOutModeContext(context.Background())
+}
+
+/*
+GetReadOutModeContext()
+
+GetReadOutMode() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetReadOutModeContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "readoutmode")
 }
 
 /*
@@ -746,16 +1603,21 @@ SetReadOutMode()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__readoutmode
 */
 func (c *Camera) SetReadOutMode(readOutMode int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetReadOutModeContext(context.Background(), readOutMode)
+}
+
+/*
+SetReadOutModeContext()
 
+SetReadOutMode() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetReadOutModeContext(ctx context.Context, readOutMode int32) error {
 	var form map[string]string = map[string]string{
 		// Set the readout mode for the camera.
-		"ReadoutMode":         fmt.Sprintf("%d", readOutMode),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"ReadoutMode": fmt.Sprintf("%d", readOutMode),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "readoutmode", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "readoutmode", form)
 }
 
 /*
@@ -765,7 +1627,16 @@ GetReadOutModes()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__readoutmodes
 */
 func (c *Camera) GetReadOutModes() ([]string, error) {
-	return c.Alpaca.GetStringListResponse("camera", c.DeviceNumber, "readoutmodes")
+	return c.GetReadOutModesContext(context.Background())
+}
+
+/*
+GetReadOutModesContext()
+
+GetReadOutModes() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetReadOutModesContext(ctx context.Context) ([]string, error) {
+	return c.Alpaca.GetStringListResponseContext(ctx, "camera", c.DeviceNumber, "readoutmodes")
 }
 
 /*
@@ -775,7 +1646,16 @@ GetSensorName()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__sensorname
 */
 func (c *Camera) GetSensorName() (string, error) {
-	return c.Alpaca.GetStringResponse("camera", c.DeviceNumber, "sensorname")
+	return c.GetSensorNameContext(context.Background())
+}
+
+/*
+GetSensorNameContext()
+
+GetSensorName() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetSensorNameContext(ctx context.Context) (string, error) {
+	return c.Alpaca.GetStringResponseContext(ctx, "camera", c.DeviceNumber, "sensorname")
 }
 
 /*
@@ -785,7 +1665,16 @@ GetSensorType()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__sensortype
 */
 func (c *Camera) GetSensorType() (SensorType, error) {
-	sensor, err := c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "sensortype")
+	return c.GetSensorTypeContext(context.Background())
+}
+
+/*
+GetSensorTypeContext()
+
+GetSensorType() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetSensorTypeContext(ctx context.Context) (SensorType, error) {
+	sensor, err := c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "sensortype")
 	return SensorType(sensor), err
 }
 
@@ -796,7 +1685,16 @@ GetCCDTemperatureCoolerSetPoint()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__setccdtemperature
 */
 func (c *Camera) GetCCDTemperatureCoolerSetPoint() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "setccdtemperature")
+	return c.GetCCDTemperatureCoolerSetPointContext(context.Background())
+}
+
+/*
+GetCCDTemperatureCoolerSetPointContext()
+
+GetCCDTemperatureCoolerSetPoint() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetCCDTemperatureCoolerSetPointContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "setccdtemperature")
 }
 
 /*
@@ -806,15 +1704,20 @@ SetCCDTemperatureCoolerSetPoint()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__setccdtemperature
 */
 func (c *Camera) SetCCDTemperatureCoolerSetPoint(temperature float64) error {
-	c.Alpaca.TransactionId++
+	return c.SetCCDTemperatureCoolerSetPointContext(context.Background(), temperature)
+}
+
+/*
+SetCCDTemperatureCoolerSetPointContext()
 
+SetCCDTemperatureCoolerSetPoint() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetCCDTemperatureCoolerSetPointContext(ctx context.Context, temperature float64) error {
 	var form map[string]string = map[string]string{
-		"SetCCDTemperature":   fmt.Sprintf("%f", temperature),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"SetCCDTemperature": fmt.Sprintf("%f", temperature),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "setccdtemperature", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "setccdtemperature", form)
 }
 
 /*
@@ -824,7 +1727,16 @@ GetStartX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__startx
 */
 func (c *Camera) GetStartX() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "startx")
+	return c.GetStartXContext(context.Background())
+}
+
+/*
+GetStartXContext()
+
+GetStartX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetStartXContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "startx")
 }
 
 /*
@@ -834,15 +1746,20 @@ SetStartX()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__startx
 */
 func (c *Camera) SetStartX(startX int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetStartXContext(context.Background(), startX)
+}
+
+/*
+SetStartXContext()
 
+SetStartX() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetStartXContext(ctx context.Context, startX int32) error {
 	var form map[string]string = map[string]string{
-		"StartX":              fmt.Sprintf("%d", startX),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"StartX": fmt.Sprintf("%d", startX),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "startx", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "startx", form)
 }
 
 /*
@@ -852,7 +1769,16 @@ GetStartY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__starty
 */
 func (c *Camera) GetStartY() (int32, error) {
-	return c.Alpaca.GetInt32Response("camera", c.DeviceNumber, "starty")
+	return c.GetStartYContext(context.Background())
+}
+
+/*
+GetStartYContext()
+
+GetStartY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetStartYContext(ctx context.Context) (int32, error) {
+	return c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "starty")
 }
 
 /*
@@ -862,15 +1788,20 @@ SetStartY()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__starty
 */
 func (c *Camera) SetStartY(startY int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetStartYContext(context.Background(), startY)
+}
 
+/*
+SetStartYContext()
+
+SetStartY() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetStartYContext(ctx context.Context, startY int32) error {
 	var form map[string]string = map[string]string{
-		"StartY":              fmt.Sprintf("%d", startY),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"StartY": fmt.Sprintf("%d", startY),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "starty", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "starty", form)
 }
 
 /*
@@ -880,7 +1811,16 @@ GetSubExposureDuration()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__subexposureduration
 */
 func (c *Camera) GetSubExposureDuration() (float64, error) {
-	return c.Alpaca.GetFloat64Response("camera", c.DeviceNumber, "subexposureduration")
+	return c.GetSubExposureDurationContext(context.Background())
+}
+
+/*
+GetSubExposureDurationContext()
+
+GetSubExposureDuration() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) GetSubExposureDurationContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "camera", c.DeviceNumber, "subexposureduration")
 }
 
 /*
@@ -890,15 +1830,20 @@ SetSubExposureDuration()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__subexposureduration
 */
 func (c *Camera) SetSubExposureDuration(subExposureDuration float64) error {
-	c.Alpaca.TransactionId++
+	return c.SetSubExposureDurationContext(context.Background(), subExposureDuration)
+}
+
+/*
+SetSubExposureDurationContext()
 
+SetSubExposureDuration() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetSubExposureDurationContext(ctx context.Context, subExposureDuration float64) error {
 	var form map[string]string = map[string]string{
 		"SubExposureDuration": fmt.Sprintf("%f", subExposureDuration),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "subexposureduration", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "subexposureduration", form)
 }
 
 /*
@@ -908,14 +1853,18 @@ AbortExposure()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__abortexposure
 */
 func (c *Camera) AbortExposure() error {
-	c.Alpaca.TransactionId++
+	return c.AbortExposureContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
-	}
+/*
+AbortExposureContext()
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "abortexposure", form)
+AbortExposure() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) AbortExposureContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "abortexposure", form)
 }
 
 /*
@@ -925,16 +1874,21 @@ PulseGuide()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__pulseguide
 */
 func (c *Camera) SetPulseGuide(direction Direction, duration int32) error {
-	c.Alpaca.TransactionId++
+	return c.SetPulseGuideContext(context.Background(), direction, duration)
+}
+
+/*
+SetPulseGuideContext()
 
+SetPulseGuide() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) SetPulseGuideContext(ctx context.Context, direction Direction, duration int32) error {
 	var form map[string]string = map[string]string{
-		"Direction":           fmt.Sprintf("%d", direction),
-		"Duration":            fmt.Sprintf("%d", duration),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"Direction": fmt.Sprintf("%d", direction),
+		"Duration":  fmt.Sprintf("%d", duration),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "pulseguide", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "pulseguide", form)
 }
 
 /*
@@ -944,16 +1898,21 @@ StartExposure()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__startexposure
 */
 func (c *Camera) StartExposure(duration float64, light bool) error {
-	c.Alpaca.TransactionId++
+	return c.StartExposureContext(context.Background(), duration, light)
+}
+
+/*
+StartExposureContext()
 
+StartExposure() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) StartExposureContext(ctx context.Context, duration float64, light bool) error {
 	var form map[string]string = map[string]string{
-		"Duration":            fmt.Sprintf("%f", duration),
-		"Light":               fmt.Sprintf("%t", light),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"Duration": fmt.Sprintf("%f", duration),
+		"Light":    fmt.Sprintf("%t", light),
 	}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "startexposure", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "startexposure", form)
 }
 
 /*
@@ -963,12 +1922,16 @@ StopExposure()
 @see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/put_camera__device_number__stopexposure
 */
 func (c *Camera) StopExposure() error {
-	c.Alpaca.TransactionId++
+	return c.StopExposureContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
-	}
+/*
+StopExposureContext()
+
+StopExposure() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *Camera) StopExposureContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return c.Alpaca.Put("camera", c.DeviceNumber, "stopexposure", form)
+	return c.Alpaca.PutContext(ctx, "camera", c.DeviceNumber, "stopexposure", form)
 }
@@ -1083,6 +1083,88 @@ func TestNewCameraGetSubFrameHeight(t *testing.T) {
 	}
 }
 
+func TestNewCameraSetOffset(t *testing.T) {
+	camera.SetConnected(true)
+
+	camera.SetOffset(10)
+
+	var got, err = camera.GetOffset()
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if got < 0 && got > 100 {
+		t.Errorf("got %v, but expected the offset value to be a realistic value", got)
+	}
+
+	if camera.Alpaca.ErrorNumber != 0 {
+		t.Errorf("got %q", camera.Alpaca.ErrorMessage)
+	}
+}
+
+func TestNewCameraGetOffset(t *testing.T) {
+	camera.SetConnected(true)
+
+	var got, err = camera.GetOffset()
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if got < 0 && got > 100 {
+		t.Errorf("got %v, but expected the offset value to be a realistic value", got)
+	}
+
+	if camera.Alpaca.ErrorNumber != 0 {
+		t.Errorf("got %q", camera.Alpaca.ErrorMessage)
+	}
+}
+
+func TestNewCameraGetOffsetMinMax(t *testing.T) {
+	camera.SetConnected(true)
+
+	var max, errMax = camera.GetOffsetMax()
+
+	var min, errMin = camera.GetOffsetMin()
+
+	if errMax != nil {
+		t.Errorf("got %q", errMax)
+	}
+
+	if errMin != nil {
+		t.Errorf("got %q", errMin)
+	}
+
+	if min > max {
+		t.Errorf("got %v, but expected the minimum offset value to be less than the maximum offset value", min)
+	}
+
+	if camera.Alpaca.ErrorNumber != 0 {
+		t.Errorf("got %q", camera.Alpaca.ErrorMessage)
+	}
+}
+
+func TestNewCameraGetOffsets(t *testing.T) {
+	camera.SetConnected(true)
+
+	var got, err = camera.GetOffsets()
+
+	var want = []string{}
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+
+	if camera.Alpaca.ErrorNumber != 0 {
+		t.Errorf("got %q", camera.Alpaca.ErrorMessage)
+	}
+}
+
 func TestNewCameraGetCurrentOperationPercentageComplete(t *testing.T) {
 	camera.SetConnected(true)
 
@@ -1,12 +1,39 @@
 package alpacago
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 type ObservingConditions struct {
 	Alpaca       *ASCOMAlpacaAPIClient
 	DeviceNumber uint
 }
 
+/*
+ObservingConditionsDeviceState
+
+The operational properties of the observing conditions, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type ObservingConditionsDeviceState struct {
+	CloudCover     float64 `json:"CloudCover"`
+	DewPoint       float64 `json:"DewPoint"`
+	Humidity       float64 `json:"Humidity"`
+	Pressure       float64 `json:"Pressure"`
+	RainRate       float64 `json:"RainRate"`
+	SkyBrightness  float64 `json:"SkyBrightness"`
+	SkyQuality     float64 `json:"SkyQuality"`
+	SkyTemperature float64 `json:"SkyTemperature"`
+	StarFWHM       float64 `json:"StarFWHM"`
+	Temperature    float64 `json:"Temperature"`
+	WindDirection  float64 `json:"WindDirection"`
+	WindGust       float64 `json:"WindGust"`
+	WindSpeed      float64 `json:"WindSpeed"`
+	TimeStamp      string  `json:"TimeStamp"`
+}
+
 func NewObservingConditions(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *ObservingConditions {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -18,6 +45,17 @@ func NewObservingConditions(clientId uint32, secure bool, domain string, ip stri
 	return &conditions
 }
 
+func NewObservingConditionsWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *ObservingConditions {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	conditions := ObservingConditions{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &conditions
+}
+
 /*
 IsConnected() common method to all ASCOM Alpaca compliant devices
 
@@ -25,7 +63,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (c *ObservingConditions) IsConnected() (bool, error) {
-	return c.Alpaca.GetBooleanResponse("observingconditions", c.DeviceNumber, "connected")
+	return c.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) IsConnectedContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.GetBooleanResponseContext(ctx, "observingconditions", c.DeviceNumber, "connected")
 }
 
 /*
@@ -36,16 +83,181 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (c *ObservingConditions) SetConnected(connected bool) error {
-	c.Alpaca.TransactionId++
+	return c.SetConnectedContext(context.Background(), connected)
+}
 
+/*
+SetConnectedContext()
+
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return c.Alpaca.PutContext(ctx, "observingconditions", c.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (c *ObservingConditions) Connect() error {
+	return c.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) ConnectContext(ctx context.Context) error {
+	return c.Alpaca.ConnectContext(ctx, "observingconditions", c.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (c *ObservingConditions) Disconnect() error {
+	return c.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) DisconnectContext(ctx context.Context) error {
+	return c.Alpaca.DisconnectContext(ctx, "observingconditions", c.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (c *ObservingConditions) IsConnecting() (bool, error) {
+	return c.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) IsConnectingContext(ctx context.Context) (bool, error) {
+	return c.Alpaca.IsConnectingContext(ctx, "observingconditions", c.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the observing conditions in a single round trip, see ObservingConditionsDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (c *ObservingConditions) GetDeviceState() (*ObservingConditionsDeviceState, error) {
+	return c.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetDeviceStateContext(ctx context.Context) (*ObservingConditionsDeviceState, error) {
+	values, err := c.Alpaca.GetDeviceStateContext(ctx, "observingconditions", c.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := ObservingConditionsDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
 	}
 
-	return c.Alpaca.Put("observingconditions", c.DeviceNumber, "connected", form)
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the observing conditions from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (c *ObservingConditions) GetDeviceStateWithFallback() (*ObservingConditionsDeviceState, error) {
+	return c.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetDeviceStateWithFallbackContext(ctx context.Context) (*ObservingConditionsDeviceState, error) {
+	state, err := c.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	state = &ObservingConditionsDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.CloudCover, err = c.GetCloudCoverContext(ctx)
+	errs = append(errs, err)
+
+	state.DewPoint, err = c.GetDewPointContext(ctx)
+	errs = append(errs, err)
+
+	state.Humidity, err = c.GetHumidityContext(ctx)
+	errs = append(errs, err)
+
+	state.Pressure, err = c.GetPressureContext(ctx)
+	errs = append(errs, err)
+
+	state.RainRate, err = c.GetRainRateContext(ctx)
+	errs = append(errs, err)
+
+	state.SkyBrightness, err = c.GetSkyBrightnessContext(ctx)
+	errs = append(errs, err)
+
+	state.SkyQuality, err = c.GetSkyQualityContext(ctx)
+	errs = append(errs, err)
+
+	state.SkyTemperature, err = c.GetSkyTemperatureContext(ctx)
+	errs = append(errs, err)
+
+	state.StarFWHM, err = c.GetSeeingStarFWHMContext(ctx)
+	errs = append(errs, err)
+
+	state.Temperature, err = c.GetTemperatureContext(ctx)
+	errs = append(errs, err)
+
+	state.WindDirection, err = c.GetWindDirectionContext(ctx)
+	errs = append(errs, err)
+
+	state.WindGust, err = c.GetWindGustContext(ctx)
+	errs = append(errs, err)
+
+	state.WindSpeed, err = c.GetWindSpeedContext(ctx)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -55,7 +267,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (c *ObservingConditions) GetDescription() (string, error) {
-	return c.Alpaca.GetDescription("observingconditions", c.DeviceNumber)
+	return c.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetDescriptionContext(ctx context.Context) (string, error) {
+	return c.Alpaca.GetDescriptionContext(ctx, "observingconditions", c.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (c *ObservingConditions) GetSupportedActions() ([]string, error) {
+	return c.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return c.Alpaca.GetSupportedActionsContext(ctx, "observingconditions", c.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (c *ObservingConditions) Action(action string, parameters string) (string, error) {
+	return c.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return c.Alpaca.ActionContext(ctx, "observingconditions", c.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (c *ObservingConditions) CommandBlind(command string, raw bool) error {
+	return c.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return c.Alpaca.CommandBlindContext(ctx, "observingconditions", c.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (c *ObservingConditions) CommandBool(command string, raw bool) (bool, error) {
+	return c.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return c.Alpaca.CommandBoolContext(ctx, "observingconditions", c.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (c *ObservingConditions) CommandString(command string, raw bool) (string, error) {
+	return c.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return c.Alpaca.CommandStringContext(ctx, "observingconditions", c.DeviceNumber, command, raw)
 }
 
 /*
@@ -65,7 +389,16 @@ GetAveragePeriod()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__averageperiod
 */
 func (c *ObservingConditions) GetAveragePeriod() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "averageperiod")
+	return c.GetAveragePeriodContext(context.Background())
+}
+
+/*
+GetAveragePeriodContext()
+
+GetAveragePeriod() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetAveragePeriodContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "averageperiod")
 }
 
 /*
@@ -75,7 +408,16 @@ GetCloudCover()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__cloudcover
 */
 func (c *ObservingConditions) GetCloudCover() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "cloudcover")
+	return c.GetCloudCoverContext(context.Background())
+}
+
+/*
+GetCloudCoverContext()
+
+GetCloudCover() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetCloudCoverContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "cloudcover")
 }
 
 /*
@@ -85,7 +427,16 @@ GetDewPoint()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__dewpoint
 */
 func (c *ObservingConditions) GetDewPoint() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "dewpoint")
+	return c.GetDewPointContext(context.Background())
+}
+
+/*
+GetDewPointContext()
+
+GetDewPoint() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetDewPointContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "dewpoint")
 }
 
 /*
@@ -95,7 +446,16 @@ GetHumidity()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__humidity
 */
 func (c *ObservingConditions) GetHumidity() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "humidity")
+	return c.GetHumidityContext(context.Background())
+}
+
+/*
+GetHumidityContext()
+
+GetHumidity() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetHumidityContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "humidity")
 }
 
 /*
@@ -105,7 +465,16 @@ GetPressure()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__pressure
 */
 func (c *ObservingConditions) GetPressure() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "pressure")
+	return c.GetPressureContext(context.Background())
+}
+
+/*
+GetPressureContext()
+
+GetPressure() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetPressureContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "pressure")
 }
 
 /*
@@ -115,7 +484,16 @@ GetRainRate()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__rainrate
 */
 func (c *ObservingConditions) GetRainRate() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "rainrate")
+	return c.GetRainRateContext(context.Background())
+}
+
+/*
+GetRainRateContext()
+
+GetRainRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetRainRateContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "rainrate")
 }
 
 /*
@@ -125,7 +503,16 @@ GetSkyBrightness()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__skybrightness
 */
 func (c *ObservingConditions) GetSkyBrightness() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "skybrightness")
+	return c.GetSkyBrightnessContext(context.Background())
+}
+
+/*
+GetSkyBrightnessContext()
+
+GetSkyBrightness() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetSkyBrightnessContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "skybrightness")
 }
 
 /*
@@ -135,7 +522,16 @@ GetSkyQuality()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__skyquality
 */
 func (c *ObservingConditions) GetSkyQuality() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "skyquality")
+	return c.GetSkyQualityContext(context.Background())
+}
+
+/*
+GetSkyQualityContext()
+
+GetSkyQuality() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetSkyQualityContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "skyquality")
 }
 
 /*
@@ -145,7 +541,16 @@ GetSkyTemperature()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__skytemperature
 */
 func (c *ObservingConditions) GetSkyTemperature() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "skytemperature")
+	return c.GetSkyTemperatureContext(context.Background())
+}
+
+/*
+GetSkyTemperatureContext()
+
+GetSkyTemperature() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetSkyTemperatureContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "skytemperature")
 }
 
 /*
@@ -155,7 +560,16 @@ GetSeeingStarFWHM()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__starfwhm
 */
 func (c *ObservingConditions) GetSeeingStarFWHM() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "starfwhm")
+	return c.GetSeeingStarFWHMContext(context.Background())
+}
+
+/*
+GetSeeingStarFWHMContext()
+
+GetSeeingStarFWHM() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetSeeingStarFWHMContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "starfwhm")
 }
 
 /*
@@ -165,7 +579,16 @@ GetTemperature()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__temperature
 */
 func (c *ObservingConditions) GetTemperature() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "temperature")
+	return c.GetTemperatureContext(context.Background())
+}
+
+/*
+GetTemperatureContext()
+
+GetTemperature() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetTemperatureContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "temperature")
 }
 
 /*
@@ -177,7 +600,16 @@ where East=90.0, South=180.0, West=270.0 and North=360.0.
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__winddirection
 */
 func (c *ObservingConditions) GetWindDirection() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "winddirection")
+	return c.GetWindDirectionContext(context.Background())
+}
+
+/*
+GetWindDirectionContext()
+
+GetWindDirection() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetWindDirectionContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "winddirection")
 }
 
 /*
@@ -187,7 +619,16 @@ GetWindGust()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__windgust
 */
 func (c *ObservingConditions) GetWindGust() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "windgust")
+	return c.GetWindGustContext(context.Background())
+}
+
+/*
+GetWindGustContext()
+
+GetWindGust() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetWindGustContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "windgust")
 }
 
 /*
@@ -197,7 +638,16 @@ GetWindSpeed()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__windspeed
 */
 func (c *ObservingConditions) GetWindSpeed() (float64, error) {
-	return c.Alpaca.GetFloat64Response("observingconditions", c.DeviceNumber, "windspeed")
+	return c.GetWindSpeedContext(context.Background())
+}
+
+/*
+GetWindSpeedContext()
+
+GetWindSpeed() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetWindSpeedContext(ctx context.Context) (float64, error) {
+	return c.Alpaca.GetFloat64ResponseContext(ctx, "observingconditions", c.DeviceNumber, "windspeed")
 }
 
 /*
@@ -207,14 +657,18 @@ SetRefresh()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/put_observingconditions__device_number__refresh
 */
 func (c *ObservingConditions) SetRefresh() error {
-	c.Alpaca.TransactionId++
+	return c.SetRefreshContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", c.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", c.Alpaca.TransactionId),
-	}
+/*
+SetRefreshContext()
+
+SetRefresh() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) SetRefreshContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return c.Alpaca.Put("observingconditions", c.DeviceNumber, "refresh", form)
+	return c.Alpaca.PutContext(ctx, "observingconditions", c.DeviceNumber, "refresh", form)
 }
 
 /*
@@ -224,26 +678,21 @@ GetSensorDescription()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__sensordescription
 */
 func (c *ObservingConditions) GetSensorDescription(sensorName string) (string, error) {
-	url := c.Alpaca.getEndpoint("observingconditions", c.DeviceNumber, "sensordescription")
+	return c.GetSensorDescriptionContext(context.Background(), sensorName)
+}
 
-	querystring := fmt.Sprintf("sensorName=%v&%s", sensorName, c.Alpaca.getQueryString())
+/*
+GetSensorDescriptionContext()
 
-	// Setup the resty client:
-	resp, err := c.Alpaca.Client.R().SetResult(&stringResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+GetSensorDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetSensorDescriptionContext(ctx context.Context, sensorName string) (string, error) {
+	result := &stringResponse{}
 
-	if err != nil {
+	if err := c.Alpaca.get(ctx, "observingconditions", c.DeviceNumber, "sensordescription", map[string]string{"sensorName": sensorName}, result); err != nil {
 		return "", err
 	}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		c.Alpaca.ErrorNumber = resp.StatusCode()
-		c.Alpaca.ErrorMessage = resp.String()
-	}
-
-	// Return the result:
-	result := (resp.Result().(*stringResponse))
-
 	return result.Value, nil
 }
 
@@ -254,25 +703,20 @@ GetTimeSinceLastUpdate()
 @see https://ascom-standards.org/api/#/ObservingConditions%20Specific%20Methods/get_observingconditions__device_number__timesincelastupdate
 */
 func (c *ObservingConditions) GetTimeSinceLastUpdate(sensorName string) (float64, error) {
-	url := c.Alpaca.getEndpoint("observingconditions", c.DeviceNumber, "timesincelastupdate")
-
-	querystring := fmt.Sprintf("sensorName=%v&%s", sensorName, c.Alpaca.getQueryString())
+	return c.GetTimeSinceLastUpdateContext(context.Background(), sensorName)
+}
 
-	// Setup the resty client:
-	resp, err := c.Alpaca.Client.R().SetResult(&float64Response{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+/*
+GetTimeSinceLastUpdateContext()
 
-	if err != nil {
-		return 0., err
-	}
+GetTimeSinceLastUpdate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *ObservingConditions) GetTimeSinceLastUpdateContext(ctx context.Context, sensorName string) (float64, error) {
+	result := &float64Response{}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		c.Alpaca.ErrorNumber = resp.StatusCode()
-		c.Alpaca.ErrorMessage = resp.String()
+	if err := c.Alpaca.get(ctx, "observingconditions", c.DeviceNumber, "timesincelastupdate", map[string]string{"sensorName": sensorName}, result); err != nil {
+		return 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*float64Response))
-
 	return result.Value, nil
 }
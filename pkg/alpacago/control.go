@@ -0,0 +1,270 @@
+package alpacago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type ControlMode int
+
+const (
+	// The camera does not support the control:
+	ControlUnsupported ControlMode = iota
+	// The control is the index of a named setting in a list, e.g., the Gains array:
+	ControlIndexMode
+	// The control is a numeric value in a range, e.g., from GainMin to GainMax:
+	ControlValueMode
+)
+
+func (m ControlMode) String() string {
+	name := []string{"Unsupported", "Index", "Value"}
+
+	if m < ControlUnsupported || m > ControlValueMode {
+		return fmt.Sprintf("Unknown ControlMode value: %d", m)
+	}
+
+	return name[m]
+}
+
+/*
+CameraControl
+
+The gain or offset of a camera, which ASCOM drivers implement in either of two modes. In index mode, the driver
+provides a list of named settings, and the control is the index of the selected name. In value mode, the driver
+provides a numeric range, and the control is a value within it. CameraControl detects the mode of the driver,
+and sets the control by name or by value in either mode.
+*/
+type CameraControl struct {
+	camera *Camera
+	// The member of the control, "gain" or "offset":
+	member string
+	Mode   ControlMode
+	// The names of the settings in index mode:
+	Names []string
+	// The range of the values in value mode:
+	Min int32
+	Max int32
+}
+
+/*
+GetGainControl()
+
+@returns the gain control of the camera, in the mode of the driver.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__gains
+*/
+func (c *Camera) GetGainControl() (*CameraControl, error) {
+	return c.GetGainControlContext(context.Background())
+}
+
+/*
+GetGainControlContext()
+
+GetGainControl() with a context, which controls the cancellation and deadline of the requests.
+*/
+func (c *Camera) GetGainControlContext(ctx context.Context) (*CameraControl, error) {
+	return c.getControlContext(ctx, "gain")
+}
+
+/*
+GetOffsetControl()
+
+@returns the offset control of the camera, in the mode of the driver.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__offsets
+*/
+func (c *Camera) GetOffsetControl() (*CameraControl, error) {
+	return c.GetOffsetControlContext(context.Background())
+}
+
+/*
+GetOffsetControlContext()
+
+GetOffsetControl() with a context, which controls the cancellation and deadline of the requests.
+*/
+func (c *Camera) GetOffsetControlContext(ctx context.Context) (*CameraControl, error) {
+	return c.getControlContext(ctx, "offset")
+}
+
+/*
+getControlContext()
+
+Detects the mode of the control, as the ASCOM specification requires: a driver in index mode implements the list
+of names and not the range, and a driver in value mode implements the range and not the list of names. A driver
+that implements neither does not support the control.
+*/
+func (c *Camera) getControlContext(ctx context.Context, member string) (*CameraControl, error) {
+	control := &CameraControl{
+		camera: c,
+		member: member,
+	}
+
+	names, err := c.Alpaca.GetStringListResponseContext(ctx, "camera", c.DeviceNumber, member+"s")
+
+	if err == nil && len(names) > 0 {
+		control.Mode = ControlIndexMode
+		control.Names = names
+		return control, nil
+	}
+
+	if err != nil && !errors.Is(err, ErrNotImplemented) {
+		return nil, err
+	}
+
+	min, err := c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, member+"min")
+
+	if errors.Is(err, ErrNotImplemented) {
+		return control, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	max, err := c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, member+"max")
+
+	if err != nil {
+		return nil, err
+	}
+
+	control.Mode = ControlValueMode
+	control.Min = min
+	control.Max = max
+
+	return control, nil
+}
+
+/*
+Get()
+
+@returns the value of the control, which is the index of the selected name in index mode, and the name of the
+value, which is the decimal value in value mode.
+*/
+func (c *CameraControl) Get() (int32, string, error) {
+	return c.GetContext(context.Background())
+}
+
+/*
+GetContext()
+
+Get() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CameraControl) GetContext(ctx context.Context) (int32, string, error) {
+	if c.Mode == ControlUnsupported {
+		return 0, "", fmt.Errorf("%w: camera does not support %s", ErrNotImplemented, c.member)
+	}
+
+	value, err := c.camera.Alpaca.GetInt32ResponseContext(ctx, "camera", c.camera.DeviceNumber, c.member)
+
+	if err != nil {
+		return 0, "", err
+	}
+
+	return value, c.Name(value), nil
+}
+
+/*
+Name()
+
+@returns the name of the value of the control, or an empty string if the value is not an index of the names in
+index mode.
+*/
+func (c *CameraControl) Name(value int32) string {
+	if c.Mode == ControlIndexMode {
+		if value < 0 || int(value) >= len(c.Names) {
+			return ""
+		}
+
+		return c.Names[value]
+	}
+
+	return strconv.Itoa(int(value))
+}
+
+/*
+SetByName()
+
+Sets the control to the named setting in index mode, or to the decimal value of the name in value mode.
+
+@param name string (the name of the setting, compared case insensitively)
+@returns ErrInvalidValue if there is no such setting.
+*/
+func (c *CameraControl) SetByName(name string) error {
+	return c.SetByNameContext(context.Background(), name)
+}
+
+/*
+SetByNameContext()
+
+SetByName() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CameraControl) SetByNameContext(ctx context.Context, name string) error {
+	switch c.Mode {
+	case ControlIndexMode:
+		for i, n := range c.Names {
+			if strings.EqualFold(strings.TrimSpace(n), strings.TrimSpace(name)) {
+				return c.set(ctx, int32(i))
+			}
+		}
+
+		return fmt.Errorf("%w: %s %q is not one of %q", ErrInvalidValue, c.member, name, c.Names)
+	case ControlValueMode:
+		value, err := strconv.ParseInt(strings.TrimSpace(name), 10, 32)
+
+		if err != nil {
+			return fmt.Errorf("%w: %s %q is not a number", ErrInvalidValue, c.member, name)
+		}
+
+		return c.SetByValueContext(ctx, int32(value))
+	default:
+		return fmt.Errorf("%w: camera does not support %s", ErrNotImplemented, c.member)
+	}
+}
+
+/*
+SetByValue()
+
+Sets the control to the value in value mode, or to the setting whose name is the decimal value in index mode,
+e.g., "100" in a list of ["0", "100", "200"].
+
+@param value int32 (the value of the setting)
+@returns ErrInvalidValue if the value is out of range, or there is no such setting.
+*/
+func (c *CameraControl) SetByValue(value int32) error {
+	return c.SetByValueContext(context.Background(), value)
+}
+
+/*
+SetByValueContext()
+
+SetByValue() with a context, which controls the cancellation and deadline of the request.
+*/
+func (c *CameraControl) SetByValueContext(ctx context.Context, value int32) error {
+	switch c.Mode {
+	case ControlIndexMode:
+		for i, n := range c.Names {
+			if v, err := strconv.ParseInt(strings.TrimSpace(n), 10, 32); err == nil && int32(v) == value {
+				return c.set(ctx, int32(i))
+			}
+		}
+
+		return fmt.Errorf("%w: %s %d is not one of %q", ErrInvalidValue, c.member, value, c.Names)
+	case ControlValueMode:
+		if value < c.Min || value > c.Max {
+			return fmt.Errorf("%w: %s %d is outside of %d to %d", ErrInvalidValue, c.member, value, c.Min, c.Max)
+		}
+
+		return c.set(ctx, value)
+	default:
+		return fmt.Errorf("%w: camera does not support %s", ErrNotImplemented, c.member)
+	}
+}
+
+func (c *CameraControl) set(ctx context.Context, value int32) error {
+	if c.member == "gain" {
+		return c.camera.SetGainContext(ctx, value)
+	}
+
+	return c.camera.SetOffsetContext(ctx, value)
+}
@@ -0,0 +1,113 @@
+package alpacago
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+)
+
+func TestCameraGainControlIndexMode(t *testing.T) {
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/gain":  "0",
+		"camera/0/gains": `["Low","High","0","100"]`,
+	})
+
+	control, err := NewCameraWithOptions(ts.URL, 0).GetGainControl()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if control.Mode != ControlIndexMode || len(control.Names) != 4 {
+		t.Fatalf("got %v mode with names %v, wanted %v mode", control.Mode, control.Names, ControlIndexMode)
+	}
+
+	if err := control.SetByName("high"); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	value, name, err := control.Get()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if value != 1 || name != "High" {
+		t.Errorf("got %d (%q), wanted 1 (%q)", value, name, "High")
+	}
+
+	if err := control.SetByValue(100); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if ts.Get("camera/0/gain") != "3" {
+		t.Errorf("got an index of %s, wanted %d", ts.Get("camera/0/gain"), 3)
+	}
+
+	if err := control.SetByName("Medium"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("got %v, wanted %v", err, ErrInvalidValue)
+	}
+
+	if err := control.SetByValue(50); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("got %v, wanted %v", err, ErrInvalidValue)
+	}
+}
+
+func TestCameraOffsetControlValueMode(t *testing.T) {
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/offset":    "10",
+		"camera/0/offsetmin": "0",
+		"camera/0/offsetmax": "255",
+	})
+
+	control, err := NewCameraWithOptions(ts.URL, 0).GetOffsetControl()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if control.Mode != ControlValueMode || control.Min != 0 || control.Max != 255 {
+		t.Fatalf("got %v mode from %d to %d, wanted %v mode from 0 to 255", control.Mode, control.Min, control.Max, ControlValueMode)
+	}
+
+	if err := control.SetByName("50"); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	value, name, err := control.Get()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if value != 50 || name != "50" {
+		t.Errorf("got %d (%q), wanted 50 (%q)", value, name, "50")
+	}
+
+	if err := control.SetByValue(256); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("got %v, wanted %v", err, ErrInvalidValue)
+	}
+
+	if err := control.SetByName("High"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("got %v, wanted %v", err, ErrInvalidValue)
+	}
+}
+
+func TestCameraControlUnsupported(t *testing.T) {
+	ts := alpacatest.NewServer(t, nil)
+
+	control, err := NewCameraWithOptions(ts.URL, 0).GetOffsetControl()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if control.Mode != ControlUnsupported {
+		t.Errorf("got %v, wanted %v", control.Mode, ControlUnsupported)
+	}
+
+	if err := control.SetByValue(1); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("got %v, wanted %v", err, ErrNotImplemented)
+	}
+}
@@ -0,0 +1,342 @@
+package alpacago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrCoolerSaturated is returned by Cooler.CoolTo() when the cooler runs at full power without reaching the setpoint.
+var ErrCoolerSaturated = errors.New("cooler is saturated")
+
+// ErrCoolerStalled is returned by Cooler.CoolTo() when the temperature stops changing away from the target.
+var ErrCoolerStalled = errors.New("cooler is stalled")
+
+// ErrCoolerNotSupported is returned by Cooler.CoolTo() and Cooler.WarmUp() when the camera cannot set the sensor temperature.
+var ErrCoolerNotSupported = errors.New("camera cannot set the sensor temperature")
+
+/*
+CoolerOptions
+
+The options of a Cooler. A zero value for any option selects its default.
+*/
+type CoolerOptions struct {
+	// The rate at which the setpoint is ramped, in °C per minute (default 5):
+	Rate float64
+	// The greatest difference between the sensor temperature and the target for the temperature to be stable, in °C (default 0.5):
+	Tolerance float64
+	// The duration for which the temperature must remain within the tolerance to be stable (default 1 minute):
+	StableDuration time.Duration
+	// The cooler power, in percent, at or above which the cooler is saturated (default 95):
+	SaturationPower float64
+	// The duration for which the cooler must be saturated, away from the setpoint, or the temperature must be stalled,
+	// away from the target, to fail (default 2 minutes):
+	SaturationDuration time.Duration
+	// The interval between polls of the camera (default 5 seconds):
+	PollInterval time.Duration
+}
+
+func (o CoolerOptions) withDefaults() CoolerOptions {
+	if o.Rate == 0 {
+		o.Rate = 5
+	}
+
+	if o.Tolerance == 0 {
+		o.Tolerance = 0.5
+	}
+
+	if o.StableDuration == 0 {
+		o.StableDuration = time.Minute
+	}
+
+	if o.SaturationPower == 0 {
+		o.SaturationPower = 95
+	}
+
+	if o.SaturationDuration == 0 {
+		o.SaturationDuration = 2 * time.Minute
+	}
+
+	if o.PollInterval == 0 {
+		o.PollInterval = 5 * time.Second
+	}
+
+	return o
+}
+
+/*
+CoolerStatus
+
+The state of the cooler on a poll of the camera. Power is NaN if the camera cannot report the cooler power. Target
+is the final temperature of the cool-down or warm-up, and SetPoint is the current setpoint of the ramp toward it.
+*/
+type CoolerStatus struct {
+	Temperature float64
+	SetPoint    float64
+	Target      float64
+	Power       float64
+	Stable      bool
+	Saturated   bool
+	Elapsed     time.Duration
+}
+
+type CoolerProgressFunc func(status CoolerStatus)
+
+/*
+Cooler
+
+A controller for the cooler of a camera, which ramps the setpoint toward a target at a controlled rate, detects
+when the temperature is stable at the target, and detects when the cooler cannot reach it.
+*/
+type Cooler struct {
+	camera *Camera
+	opts   CoolerOptions
+}
+
+/*
+NewCooler()
+
+@param camera *Camera (the camera, which must be able to set its sensor temperature)
+@param opts CoolerOptions (the options of the controller)
+@returns a controller for the cooler of the camera.
+*/
+func NewCooler(camera *Camera, opts CoolerOptions) *Cooler {
+	return &Cooler{
+		camera: camera,
+		opts:   opts.withDefaults(),
+	}
+}
+
+/*
+rampSetPoint()
+
+@returns the setpoint of a ramp from the start temperature toward the target at the rate, in °C per minute,
+after the elapsed time, which is the target once the ramp is complete.
+*/
+func rampSetPoint(start float64, target float64, rate float64, elapsed time.Duration) float64 {
+	step := rate * elapsed.Minutes()
+
+	if math.Abs(target-start) <= step {
+		return target
+	}
+
+	if target < start {
+		return start - step
+	}
+
+	return start + step
+}
+
+/*
+GetStatus()
+
+@returns the temperature, setpoint and power of the cooler. The target of the status is the current setpoint.
+*/
+func (c *Cooler) GetStatus() (CoolerStatus, error) {
+	return c.GetStatusContext(context.Background())
+}
+
+/*
+GetStatusContext()
+
+GetStatus() with a context, which controls the cancellation and deadline of the requests.
+*/
+func (c *Cooler) GetStatusContext(ctx context.Context) (CoolerStatus, error) {
+	status := CoolerStatus{
+		Power: math.NaN(),
+	}
+
+	var err error
+
+	if status.Temperature, err = c.camera.GetCCDTemperatureContext(ctx); err != nil {
+		return status, err
+	}
+
+	if status.SetPoint, err = c.camera.GetCCDTemperatureCoolerSetPointContext(ctx); err != nil {
+		return status, err
+	}
+
+	status.Target = status.SetPoint
+
+	if canGetPower, err := c.camera.CanGetCoolerPowerContext(ctx); err != nil {
+		return status, err
+	} else if canGetPower {
+		if status.Power, err = c.camera.GetCoolerPowerLevelContext(ctx); err != nil {
+			return status, err
+		}
+	}
+
+	return status, nil
+}
+
+/*
+CoolTo()
+
+Turns the cooler on, ramps the setpoint from the current sensor temperature to the target at the rate of the
+options, and waits until the temperature is stable within the tolerance of the target.
+
+@param target float64 (the target sensor temperature, in °C)
+@param progress CoolerProgressFunc (called on every poll of the camera, may be nil)
+@returns nil once the temperature is stable, ErrCoolerSaturated if the cooler power remains at or above the
+saturation power, with the temperature outside of the tolerance of the setpoint, for the saturation duration, or
+ErrCoolerStalled if, once the ramp is complete, the temperature changes by no more than the tolerance, outside of
+the tolerance of the target, for the saturation duration.
+*/
+func (c *Cooler) CoolTo(target float64, progress CoolerProgressFunc) error {
+	return c.CoolToContext(context.Background(), target, progress)
+}
+
+/*
+CoolToContext()
+
+CoolTo() with a context, which controls the cancellation and deadline of the cool-down. If the context is
+cancelled, the cooler is left on at the setpoint reached by the ramp.
+*/
+func (c *Cooler) CoolToContext(ctx context.Context, target float64, progress CoolerProgressFunc) error {
+	return c.ramp(ctx, target, true, progress)
+}
+
+/*
+WarmUp()
+
+Ramps the setpoint from the current sensor temperature to the target at the rate of the options, waits until
+the temperature is within the tolerance of the target, and turns the cooler off. Warming the sensor gradually,
+before disconnecting the camera, avoids thermal shock to the sensor and condensation on its window. If the
+temperature stalls short of the target, e.g., as the target is above the ambient temperature, the cooler is
+turned off once the temperature has stalled for the saturation duration.
+
+@param target float64 (the target sensor temperature, in °C, e.g., the heat sink temperature of the camera)
+@param progress CoolerProgressFunc (called on every poll of the camera, may be nil)
+@returns nil once the cooler is off.
+*/
+func (c *Cooler) WarmUp(target float64, progress CoolerProgressFunc) error {
+	return c.WarmUpContext(context.Background(), target, progress)
+}
+
+/*
+WarmUpContext()
+
+WarmUp() with a context, which controls the cancellation and deadline of the warm-up. If the context is cancelled,
+the cooler is left on at the setpoint reached by the ramp.
+*/
+func (c *Cooler) WarmUpContext(ctx context.Context, target float64, progress CoolerProgressFunc) error {
+	if err := c.ramp(ctx, target, false, progress); err != nil && !errors.Is(err, ErrCoolerStalled) {
+		return err
+	}
+
+	return c.camera.TurnCoolerOffContext(ctx)
+}
+
+/*
+ramp()
+
+Ramps the setpoint to the target, then polls until the temperature has remained within the tolerance of the
+target for the stable duration, or, if stable is false, until the temperature is first within the tolerance.
+*/
+func (c *Cooler) ramp(ctx context.Context, target float64, stable bool, progress CoolerProgressFunc) error {
+	if canSet, err := c.camera.CanSetCCDTemperatureContext(ctx); err != nil {
+		return err
+	} else if !canSet {
+		return ErrCoolerNotSupported
+	}
+
+	if err := c.camera.TurnCoolerOnContext(ctx); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	temperature, err := c.camera.GetCCDTemperatureContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	// The times at which the temperature entered the tolerance, and the cooler became saturated:
+	var stableSince, saturatedSince time.Time
+
+	// The temperature from which a stall is measured once the ramp is complete, and the time it was recorded:
+	stalledAt := math.NaN()
+
+	var stalledSince time.Time
+
+	setPoint := math.NaN()
+
+	for {
+		elapsed := time.Since(start)
+
+		// The setpoint is only sent when it changes by at least a tenth of a degree, or reaches the target:
+		if next := rampSetPoint(temperature, target, c.opts.Rate, elapsed); math.IsNaN(setPoint) || math.Abs(next-setPoint) >= 0.1 || (next == target && setPoint != target) {
+			if err := c.camera.SetCCDTemperatureCoolerSetPointContext(ctx, next); err != nil {
+				return err
+			}
+
+			setPoint = next
+		}
+
+		status, err := c.GetStatusContext(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		status.SetPoint = setPoint
+		status.Target = target
+		status.Elapsed = now.Sub(start)
+
+		within := setPoint == target && math.Abs(status.Temperature-target) <= c.opts.Tolerance
+
+		if !within {
+			stableSince = time.Time{}
+		} else if stableSince.IsZero() {
+			stableSince = now
+		}
+
+		status.Stable = within && now.Sub(stableSince) >= c.opts.StableDuration
+
+		saturated := status.Power >= c.opts.SaturationPower && math.Abs(status.Temperature-setPoint) > c.opts.Tolerance
+
+		if !saturated {
+			saturatedSince = time.Time{}
+		} else if saturatedSince.IsZero() {
+			saturatedSince = now
+		}
+
+		status.Saturated = saturated && now.Sub(saturatedSince) >= c.opts.SaturationDuration
+
+		// The temperature is stalled when it has not moved by more than the tolerance since it was last recorded:
+		if setPoint != target || within || math.IsNaN(stalledAt) || math.Abs(status.Temperature-stalledAt) > c.opts.Tolerance {
+			stalledAt, stalledSince = status.Temperature, time.Time{}
+		} else if stalledSince.IsZero() {
+			stalledSince = now
+		}
+
+		stalled := !stalledSince.IsZero() && now.Sub(stalledSince) >= c.opts.SaturationDuration
+
+		if progress != nil {
+			progress(status)
+		}
+
+		if status.Saturated {
+			return fmt.Errorf("%w: %.1f°C at %.0f%% power, with a setpoint of %.1f°C", ErrCoolerSaturated, status.Temperature, status.Power, setPoint)
+		}
+
+		if stalled {
+			return fmt.Errorf("%w: %.1f°C for %s, with a target of %.1f°C", ErrCoolerStalled, status.Temperature, c.opts.SaturationDuration, target)
+		}
+
+		if status.Stable || (!stable && within) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.opts.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package alpacago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+)
+
+type coolerTestCamera struct {
+	mu          sync.Mutex
+	temperature float64
+	setPoint    float64
+	// The lowest temperature the cooler can reach:
+	floor float64
+	// The highest temperature the sensor can reach, e.g., the ambient temperature, if not zero:
+	ceiling   float64
+	coolerOn  bool
+	setPoints []float64
+}
+
+func newCoolerTestServer(t *testing.T, camera *coolerTestCamera) *alpacatest.Server {
+	s := alpacatest.NewServer(t, map[string]string{
+		"camera/0/cansetccdtemperature": `true`,
+		"camera/0/cangetcoolerpower":    `true`,
+	})
+
+	s.HandleFunc("camera/0/cooleron", func(member string, r *http.Request) alpacatest.Response {
+		camera.mu.Lock()
+		defer camera.mu.Unlock()
+
+		if r.Method == http.MethodPut {
+			camera.coolerOn = r.FormValue("CoolerOn") == "true"
+		}
+
+		return alpacatest.Value(fmt.Sprint(camera.coolerOn))
+	})
+
+	// Each read of the temperature moves it a degree toward the setpoint, between the floor and ceiling of the cooler:
+	s.HandleFunc("camera/0/ccdtemperature", func(member string, r *http.Request) alpacatest.Response {
+		camera.mu.Lock()
+		defer camera.mu.Unlock()
+
+		goal := math.Max(camera.setPoint, camera.floor)
+
+		if camera.ceiling != 0 {
+			goal = math.Min(goal, camera.ceiling)
+		}
+
+		if camera.temperature > goal {
+			camera.temperature = math.Max(camera.temperature-1, goal)
+		} else {
+			camera.temperature = math.Min(camera.temperature+1, goal)
+		}
+
+		return alpacatest.Value(fmt.Sprint(camera.temperature))
+	})
+
+	s.HandleFunc("camera/0/setccdtemperature", func(member string, r *http.Request) alpacatest.Response {
+		camera.mu.Lock()
+		defer camera.mu.Unlock()
+
+		if r.Method == http.MethodPut {
+			v, err := strconv.ParseFloat(r.FormValue("SetCCDTemperature"), 64)
+
+			if err != nil {
+				t.Errorf("got %q", err)
+			}
+
+			camera.setPoint = v
+			camera.setPoints = append(camera.setPoints, v)
+		}
+
+		return alpacatest.Value(fmt.Sprint(camera.setPoint))
+	})
+
+	s.HandleFunc("camera/0/coolerpower", func(member string, r *http.Request) alpacatest.Response {
+		camera.mu.Lock()
+		defer camera.mu.Unlock()
+
+		if camera.temperature > camera.setPoint+0.5 {
+			return alpacatest.Value(`100`)
+		}
+
+		return alpacatest.Value(`40`)
+	})
+
+	return s
+}
+
+func TestRampSetPoint(t *testing.T) {
+	tests := []struct {
+		name                string
+		start, target, rate float64
+		elapsed             time.Duration
+		want                float64
+	}{
+		{"Start", 20, -10, 5, 0, 20},
+		{"Cooling", 20, -10, 5, 2 * time.Minute, 10},
+		{"Warming", -10, 20, 5, 90 * time.Second, -2.5},
+		{"Complete", 20, -10, 5, 10 * time.Minute, -10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rampSetPoint(tt.start, tt.target, tt.rate, tt.elapsed); got != tt.want {
+				t.Errorf("got %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoolerCoolTo(t *testing.T) {
+	camera := &coolerTestCamera{temperature: 20, setPoint: 20, floor: -40}
+
+	ts := newCoolerTestServer(t, camera)
+
+	cooler := NewCooler(NewCameraWithOptions(ts.URL, 0), CoolerOptions{
+		// A ramp of a degree per millisecond:
+		Rate:           60000,
+		StableDuration: 20 * time.Millisecond,
+		PollInterval:   time.Millisecond,
+	})
+
+	var last CoolerStatus
+
+	err := cooler.CoolTo(-10, func(status CoolerStatus) {
+		last = status
+	})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if !last.Stable || last.Target != -10 || math.Abs(last.Temperature+10) > 0.5 {
+		t.Errorf("got %+v, wanted a stable temperature of -10", last)
+	}
+
+	camera.mu.Lock()
+	defer camera.mu.Unlock()
+
+	if !camera.coolerOn {
+		t.Errorf("got the cooler off, wanted it on")
+	}
+
+	// The setpoint must be ramped down, never jumping straight to the target:
+	if len(camera.setPoints) < 2 || camera.setPoints[0] == -10 || camera.setPoints[len(camera.setPoints)-1] != -10 {
+		t.Errorf("got setpoints %v, wanted a ramp to -10", camera.setPoints)
+	}
+
+	for i := 1; i < len(camera.setPoints); i++ {
+		if camera.setPoints[i] > camera.setPoints[i-1] {
+			t.Errorf("got setpoints %v, wanted them to decrease", camera.setPoints)
+			break
+		}
+	}
+}
+
+func TestCoolerSaturated(t *testing.T) {
+	camera := &coolerTestCamera{temperature: 20, setPoint: 20, floor: 0}
+
+	ts := newCoolerTestServer(t, camera)
+
+	cooler := NewCooler(NewCameraWithOptions(ts.URL, 0), CoolerOptions{
+		Rate:               60000,
+		PollInterval:       time.Millisecond,
+		SaturationDuration: 20 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	defer cancel()
+
+	if err := cooler.CoolToContext(ctx, -20, nil); !errors.Is(err, ErrCoolerSaturated) {
+		t.Errorf("got %v, wanted %v", err, ErrCoolerSaturated)
+	}
+}
+
+func TestCoolerWarmUp(t *testing.T) {
+	camera := &coolerTestCamera{temperature: -10, setPoint: -10, floor: -40, coolerOn: true}
+
+	ts := newCoolerTestServer(t, camera)
+
+	cooler := NewCooler(NewCameraWithOptions(ts.URL, 0), CoolerOptions{
+		Rate:         60000,
+		PollInterval: time.Millisecond,
+	})
+
+	if err := cooler.WarmUp(15, nil); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	camera.mu.Lock()
+	defer camera.mu.Unlock()
+
+	if camera.coolerOn {
+		t.Errorf("got the cooler on, wanted it off")
+	}
+
+	if math.Abs(camera.temperature-15) > 0.5 {
+		t.Errorf("got %v, wanted %v", camera.temperature, 15)
+	}
+}
+
+func TestCoolerStalled(t *testing.T) {
+	camera := &coolerTestCamera{temperature: 20, setPoint: 20, floor: 0}
+
+	ts := newCoolerTestServer(t, camera)
+
+	cooler := NewCooler(NewCameraWithOptions(ts.URL, 0), CoolerOptions{
+		Rate:         60000,
+		PollInterval: time.Millisecond,
+		// The cooler never saturates, so only the stall can end the cool-down:
+		SaturationPower:    101,
+		SaturationDuration: 20 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	defer cancel()
+
+	if err := cooler.CoolToContext(ctx, -20, nil); !errors.Is(err, ErrCoolerStalled) {
+		t.Errorf("got %v, wanted %v", err, ErrCoolerStalled)
+	}
+}
+
+func TestCoolerWarmUpStalled(t *testing.T) {
+	camera := &coolerTestCamera{temperature: -10, setPoint: -10, floor: -40, ceiling: 5, coolerOn: true}
+
+	ts := newCoolerTestServer(t, camera)
+
+	cooler := NewCooler(NewCameraWithOptions(ts.URL, 0), CoolerOptions{
+		Rate:               60000,
+		PollInterval:       time.Millisecond,
+		SaturationDuration: 20 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	defer cancel()
+
+	if err := cooler.WarmUpContext(ctx, 15, nil); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	camera.mu.Lock()
+	defer camera.mu.Unlock()
+
+	if camera.coolerOn {
+		t.Errorf("got the cooler on, wanted it off")
+	}
+
+	if camera.temperature != 5 {
+		t.Errorf("got %v, wanted %v", camera.temperature, 5)
+	}
+}
@@ -1,8 +1,10 @@
 package alpacago
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 type Dome struct {
@@ -33,6 +35,22 @@ func (s ShutterStatus) String() string {
 	}
 }
 
+/*
+DomeDeviceState
+
+The operational properties of the dome, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type DomeDeviceState struct {
+	Altitude      float64       `json:"Altitude"`
+	AtHome        bool          `json:"AtHome"`
+	AtPark        bool          `json:"AtPark"`
+	Azimuth       float64       `json:"Azimuth"`
+	ShutterStatus ShutterStatus `json:"ShutterStatus"`
+	Slewing       bool          `json:"Slewing"`
+	TimeStamp     string        `json:"TimeStamp"`
+}
+
 func NewDome(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *Dome {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -44,6 +62,17 @@ func NewDome(clientId uint32, secure bool, domain string, ip string, port int32,
 	return &dome
 }
 
+func NewDomeWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *Dome {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	dome := Dome{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &dome
+}
+
 /*
 IsConnected() common method to all ASCOM Alpaca compliant devices
 
@@ -51,7 +80,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (d *Dome) IsConnected() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "connected")
+	return d.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) IsConnectedContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "connected")
 }
 
 /*
@@ -62,16 +100,161 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (d *Dome) SetConnected(connected bool) error {
-	d.Alpaca.TransactionId++
+	return d.SetConnectedContext(context.Background(), connected)
+}
 
+/*
+SetConnectedContext()
+
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (d *Dome) Connect() error {
+	return d.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) ConnectContext(ctx context.Context) error {
+	return d.Alpaca.ConnectContext(ctx, "dome", d.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (d *Dome) Disconnect() error {
+	return d.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) DisconnectContext(ctx context.Context) error {
+	return d.Alpaca.DisconnectContext(ctx, "dome", d.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (d *Dome) IsConnecting() (bool, error) {
+	return d.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) IsConnectingContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.IsConnectingContext(ctx, "dome", d.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the dome in a single round trip, see DomeDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (d *Dome) GetDeviceState() (*DomeDeviceState, error) {
+	return d.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) GetDeviceStateContext(ctx context.Context) (*DomeDeviceState, error) {
+	values, err := d.Alpaca.GetDeviceStateContext(ctx, "dome", d.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := DomeDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
 	}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "connected", form)
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the dome from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (d *Dome) GetDeviceStateWithFallback() (*DomeDeviceState, error) {
+	return d.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) GetDeviceStateWithFallbackContext(ctx context.Context) (*DomeDeviceState, error) {
+	state, err := d.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	state = &DomeDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.Altitude, err = d.GetAltitudeContext(ctx)
+	errs = append(errs, err)
+
+	state.AtHome, err = d.IsAtHomeContext(ctx)
+	errs = append(errs, err)
+
+	state.AtPark, err = d.IsAtParkContext(ctx)
+	errs = append(errs, err)
+
+	state.Azimuth, err = d.GetAzimuthContext(ctx)
+	errs = append(errs, err)
+
+	shutterStatus, err := d.Alpaca.GetInt32ResponseContext(ctx, "dome", d.DeviceNumber, "shutterstatus")
+	state.ShutterStatus = ShutterStatus(shutterStatus)
+	errs = append(errs, err)
+
+	state.Slewing, err = d.IsSlewingContext(ctx)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -81,7 +264,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (d *Dome) GetDescription() (string, error) {
-	return d.Alpaca.GetDescription("dome", d.DeviceNumber)
+	return d.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) GetDescriptionContext(ctx context.Context) (string, error) {
+	return d.Alpaca.GetDescriptionContext(ctx, "dome", d.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (d *Dome) GetSupportedActions() ([]string, error) {
+	return d.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return d.Alpaca.GetSupportedActionsContext(ctx, "dome", d.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (d *Dome) Action(action string, parameters string) (string, error) {
+	return d.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return d.Alpaca.ActionContext(ctx, "dome", d.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (d *Dome) CommandBlind(command string, raw bool) error {
+	return d.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return d.Alpaca.CommandBlindContext(ctx, "dome", d.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (d *Dome) CommandBool(command string, raw bool) (bool, error) {
+	return d.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return d.Alpaca.CommandBoolContext(ctx, "dome", d.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (d *Dome) CommandString(command string, raw bool) (string, error) {
+	return d.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return d.Alpaca.CommandStringContext(ctx, "dome", d.DeviceNumber, command, raw)
 }
 
 /*
@@ -91,7 +386,16 @@ GetAltitude()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__altitude
 */
 func (d *Dome) GetAltitude() (float64, error) {
-	return d.Alpaca.GetFloat64Response("dome", d.DeviceNumber, "altitude")
+	return d.GetAltitudeContext(context.Background())
+}
+
+/*
+GetAltitudeContext()
+
+GetAltitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) GetAltitudeContext(ctx context.Context) (float64, error) {
+	return d.Alpaca.GetFloat64ResponseContext(ctx, "dome", d.DeviceNumber, "altitude")
 }
 
 /*
@@ -104,7 +408,16 @@ hardware is capable of detecting that; or at the end of a slew operation if the
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__athome
 */
 func (d *Dome) IsAtHome() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "athome")
+	return d.IsAtHomeContext(context.Background())
+}
+
+/*
+IsAtHomeContext()
+
+IsAtHome() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) IsAtHomeContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "athome")
 }
 
 /*
@@ -114,7 +427,16 @@ IsAtPark()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__atpark
 */
 func (d *Dome) IsAtPark() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "atpark")
+	return d.IsAtParkContext(context.Background())
+}
+
+/*
+IsAtParkContext()
+
+IsAtPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) IsAtParkContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "atpark")
 }
 
 /*
@@ -124,7 +446,16 @@ GetAzimuth()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__azimuth
 */
 func (d *Dome) GetAzimuth() (float64, error) {
-	return d.Alpaca.GetFloat64Response("dome", d.DeviceNumber, "azimuth")
+	return d.GetAzimuthContext(context.Background())
+}
+
+/*
+GetAzimuthContext()
+
+GetAzimuth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) GetAzimuthContext(ctx context.Context) (float64, error) {
+	return d.Alpaca.GetFloat64ResponseContext(ctx, "dome", d.DeviceNumber, "azimuth")
 }
 
 /*
@@ -134,7 +465,16 @@ CanFindHome()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__canfindhome
 */
 func (d *Dome) CanFindHome() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "canfindhome")
+	return d.CanFindHomeContext(context.Background())
+}
+
+/*
+CanFindHomeContext()
+
+CanFindHome() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanFindHomeContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "canfindhome")
 }
 
 /*
@@ -144,7 +484,16 @@ CanPark()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__canpark
 */
 func (d *Dome) CanPark() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "canpark")
+	return d.CanParkContext(context.Background())
+}
+
+/*
+CanParkContext()
+
+CanPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanParkContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "canpark")
 }
 
 /*
@@ -154,7 +503,16 @@ CanSetAltitude()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__cansetaltitude
 */
 func (d *Dome) CanSetAltitude() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "cansetaltitude")
+	return d.CanSetAltitudeContext(context.Background())
+}
+
+/*
+CanSetAltitudeContext()
+
+CanSetAltitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanSetAltitudeContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "cansetaltitude")
 }
 
 /*
@@ -164,7 +522,16 @@ CanSetAzimuth()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__cansetazimuth
 */
 func (d *Dome) CanSetAzimuth() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "cansetazimuth")
+	return d.CanSetAzimuthContext(context.Background())
+}
+
+/*
+CanSetAzimuthContext()
+
+CanSetAzimuth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanSetAzimuthContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "cansetazimuth")
 }
 
 /*
@@ -174,7 +541,16 @@ CanSetPark()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__cansetpark
 */
 func (d *Dome) CanSetPark() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "cansetpark")
+	return d.CanSetParkContext(context.Background())
+}
+
+/*
+CanSetParkContext()
+
+CanSetPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanSetParkContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "cansetpark")
 }
 
 /*
@@ -184,7 +560,16 @@ CanSetShutter()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__cansetshutter
 */
 func (d *Dome) CanSetShutter() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "cansetshutter")
+	return d.CanSetShutterContext(context.Background())
+}
+
+/*
+CanSetShutterContext()
+
+CanSetShutter() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanSetShutterContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "cansetshutter")
 }
 
 /*
@@ -194,7 +579,16 @@ CanSlave()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__canslave
 */
 func (d *Dome) CanSlave() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "canslave")
+	return d.CanSlaveContext(context.Background())
+}
+
+/*
+CanSlaveContext()
+
+CanSlave() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanSlaveContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "canslave")
 }
 
 /*
@@ -204,7 +598,16 @@ CanSyncAzimuth()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__cansyncazimuth
 */
 func (d *Dome) CanSyncAzimuth() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "cansyncazimuth")
+	return d.CanSyncAzimuthContext(context.Background())
+}
+
+/*
+CanSyncAzimuthContext()
+
+CanSyncAzimuth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CanSyncAzimuthContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "cansyncazimuth")
 }
 
 /*
@@ -214,7 +617,16 @@ GetShutterStatus()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__shutterstatus
 */
 func (d *Dome) GetShutterStatus() (string, error) {
-	status, err := d.Alpaca.GetInt32Response("dome", d.DeviceNumber, "shutterstatus")
+	return d.GetShutterStatusContext(context.Background())
+}
+
+/*
+GetShutterStatusContext()
+
+GetShutterStatus() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) GetShutterStatusContext(ctx context.Context) (string, error) {
+	status, err := d.Alpaca.GetInt32ResponseContext(ctx, "dome", d.DeviceNumber, "shutterstatus")
 	return ShutterStatus(status).String(), err
 }
 
@@ -225,7 +637,16 @@ IsSlaved()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__slaved
 */
 func (d *Dome) IsSlaved() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "slaved")
+	return d.IsSlavedContext(context.Background())
+}
+
+/*
+IsSlavedContext()
+
+IsSlaved() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) IsSlavedContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "slaved")
 }
 
 /*
@@ -235,16 +656,21 @@ SetSlaved()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__slaved
 */
 func (d *Dome) SetSlaved(slaved bool) error {
-	d.Alpaca.TransactionId++
+	return d.SetSlavedContext(context.Background(), slaved)
+}
+
+/*
+SetSlavedContext()
 
+SetSlaved() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) SetSlavedContext(ctx context.Context, slaved bool) error {
 	var form map[string]string = map[string]string{
 		// Set True if telescope is slaved to dome, otherwise False
-		"Slaved":              fmt.Sprintf("%t", slaved),
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
+		"Slaved": fmt.Sprintf("%t", slaved),
 	}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "slaved", form)
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "slaved", form)
 }
 
 /*
@@ -254,7 +680,16 @@ IsSlewing()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/get_dome__device_number__slewing
 */
 func (d *Dome) IsSlewing() (bool, error) {
-	return d.Alpaca.GetBooleanResponse("dome", d.DeviceNumber, "slewing")
+	return d.IsSlewingContext(context.Background())
+}
+
+/*
+IsSlewingContext()
+
+IsSlewing() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) IsSlewingContext(ctx context.Context) (bool, error) {
+	return d.Alpaca.GetBooleanResponseContext(ctx, "dome", d.DeviceNumber, "slewing")
 }
 
 /*
@@ -264,14 +699,18 @@ AbortSlew()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__abortslew
 */
 func (d *Dome) AbortSlew() error {
-	d.Alpaca.TransactionId++
+	return d.AbortSlewContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
-	}
+/*
+AbortSlewContext()
+
+AbortSlew() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) AbortSlewContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "abortslew", form)
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "abortslew", form)
 }
 
 /*
@@ -281,14 +720,18 @@ CloseShutter()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__closeshutter
 */
 func (d *Dome) CloseShutter() error {
-	d.Alpaca.TransactionId++
+	return d.CloseShutterContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
-	}
+/*
+CloseShutterContext()
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "closeshutter", form)
+CloseShutter() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) CloseShutterContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "closeshutter", form)
 }
 
 /*
@@ -299,14 +742,18 @@ FindHome()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__findhome
 */
 func (d *Dome) FindHome() error {
-	d.Alpaca.TransactionId++
+	return d.FindHomeContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
-	}
+/*
+FindHomeContext()
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "findhome", form)
+FindHome() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) FindHomeContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "findhome", form)
 }
 
 /*
@@ -316,14 +763,18 @@ OpenShutter()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__openshutter
 */
 func (d *Dome) OpenShutter() error {
-	d.Alpaca.TransactionId++
+	return d.OpenShutterContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
-	}
+/*
+OpenShutterContext()
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "openshutter", form)
+OpenShutter() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) OpenShutterContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "openshutter", form)
 }
 
 /*
@@ -334,14 +785,18 @@ Park()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__park
 */
 func (d *Dome) Park() error {
-	d.Alpaca.TransactionId++
+	return d.ParkContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
-	}
+/*
+ParkContext()
+
+Park() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) ParkContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "park", form)
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "park", form)
 }
 
 /*
@@ -351,14 +806,18 @@ SetAsPark()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__setpark
 */
 func (d *Dome) SetAsPark() error {
-	d.Alpaca.TransactionId++
+	return d.SetAsParkContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
-	}
+/*
+SetAsParkContext()
+
+SetAsPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) SetAsParkContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "setpark", form)
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "setpark", form)
 }
 
 /*
@@ -368,16 +827,21 @@ SlewToAltitude()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__slewtoaltitude
 */
 func (d *Dome) SlewToAltitude(altitude float64) error {
-	d.Alpaca.TransactionId++
+	return d.SlewToAltitudeContext(context.Background(), altitude)
+}
+
+/*
+SlewToAltitudeContext()
 
+SlewToAltitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) SlewToAltitudeContext(ctx context.Context, altitude float64) error {
 	var form map[string]string = map[string]string{
 		// Target dome altitude (degrees, horizon zero and increasing positive to 90 zenith)
-		"Altitude":            fmt.Sprintf("%f", altitude),
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
+		"Altitude": fmt.Sprintf("%f", altitude),
 	}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "slewtoaltitude", form)
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "slewtoaltitude", form)
 }
 
 /*
@@ -387,16 +851,21 @@ SlewToAzimuth()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__slewtoazimuth
 */
 func (d *Dome) SlewToAzimuth(azimuth float64) error {
-	d.Alpaca.TransactionId++
+	return d.SlewToAzimuthContext(context.Background(), azimuth)
+}
+
+/*
+SlewToAzimuthContext()
 
+SlewToAzimuth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) SlewToAzimuthContext(ctx context.Context, azimuth float64) error {
 	var form map[string]string = map[string]string{
 		// Target dome azimuth (degrees, North zero and increasing clockwise. i.e., 90 East, 180 South, 270 West)
-		"Azimuth":             fmt.Sprintf("%f", azimuth),
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
+		"Azimuth": fmt.Sprintf("%f", azimuth),
 	}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "slewtoazimuth", form)
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "slewtoazimuth", form)
 }
 
 /*
@@ -406,14 +875,19 @@ SyncToAzimuth()
 @see https://ascom-standards.org/api/#/Dome%20Specific%20Methods/put_dome__device_number__synctoazimuth
 */
 func (d *Dome) SyncToAzimuth(azimuth float64) error {
-	d.Alpaca.TransactionId++
+	return d.SyncToAzimuthContext(context.Background(), azimuth)
+}
+
+/*
+SyncToAzimuthContext()
 
+SyncToAzimuth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (d *Dome) SyncToAzimuthContext(ctx context.Context, azimuth float64) error {
 	var form map[string]string = map[string]string{
 		// Target dome azimuth (degrees, North zero and increasing clockwise. i.e., 90 East, 180 South, 270 West)
-		"Azimuth":             fmt.Sprintf("%f", azimuth),
-		"ClientID":            fmt.Sprintf("%d", d.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", d.Alpaca.TransactionId),
+		"Azimuth": fmt.Sprintf("%f", azimuth),
 	}
 
-	return d.Alpaca.Put("dome", d.DeviceNumber, "synctoazimuth", form)
+	return d.Alpaca.PutContext(ctx, "dome", d.DeviceNumber, "synctoazimuth", form)
 }
@@ -0,0 +1,157 @@
+package alpacago
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+AlpacaError
+
+Every ASCOM Alpaca response carries an ErrorNumber and ErrorMessage, where a non-zero
+ErrorNumber indicates that the driver could not complete the request. AlpacaError
+wraps these values so that callers can compare them against the standard ASCOM
+error codes, e.g., errors.Is(err, alpacago.ErrNotConnected).
+
+@see https://ascom-standards.org/Developer/ASCOM%20Alpaca%20API%20Reference.pdf
+*/
+type AlpacaError struct {
+	ErrorNumber  int32
+	ErrorMessage string
+}
+
+const (
+	// The first (lowest) error number reserved for driver specific errors.
+	DriverErrorMin int32 = 0x500
+	// The last (highest) error number reserved for driver specific errors.
+	DriverErrorMax int32 = 0xFFF
+)
+
+var (
+	// The requested property or method is not implemented by the driver.
+	ErrNotImplemented = &AlpacaError{ErrorNumber: 0x400, ErrorMessage: "not implemented"}
+	// The supplied value is not valid, e.g., it is out of range.
+	ErrInvalidValue = &AlpacaError{ErrorNumber: 0x401, ErrorMessage: "invalid value"}
+	// The requested value has not yet been set, e.g., the target coordinates of a telescope.
+	ErrValueNotSet = &AlpacaError{ErrorNumber: 0x402, ErrorMessage: "value not set"}
+	// The device is not connected.
+	ErrNotConnected = &AlpacaError{ErrorNumber: 0x407, ErrorMessage: "not connected"}
+	// The operation is not valid whilst the device is parked.
+	ErrInvalidWhileParked = &AlpacaError{ErrorNumber: 0x408, ErrorMessage: "invalid while parked"}
+	// The operation is not valid whilst the device is slaved, e.g., a dome slaved to a telescope.
+	ErrInvalidWhileSlaved = &AlpacaError{ErrorNumber: 0x409, ErrorMessage: "invalid while slaved"}
+	// The operation is not valid in the current state of the device.
+	ErrInvalidOperation = &AlpacaError{ErrorNumber: 0x40B, ErrorMessage: "invalid operation"}
+	// The requested action is not implemented by the driver.
+	ErrActionNotImplemented = &AlpacaError{ErrorNumber: 0x40C, ErrorMessage: "action not implemented"}
+	// An error that does not map to any of the other reserved error numbers.
+	ErrUnspecified = &AlpacaError{ErrorNumber: 0x4FF, ErrorMessage: "unspecified error"}
+	// Any driver specific error, i.e., an ErrorNumber in the range 0x500 to 0xFFF.
+	ErrDriverError = &AlpacaError{ErrorNumber: DriverErrorMin, ErrorMessage: "driver error"}
+)
+
+/*
+Error()
+
+@returns the error number (in hexadecimal, as is the ASCOM convention) and the error message.
+*/
+func (e *AlpacaError) Error() string {
+	return fmt.Sprintf("ascom alpaca error 0x%X: %s", e.ErrorNumber, e.ErrorMessage)
+}
+
+/*
+Is()
+
+@returns true if the target is an AlpacaError with the same ErrorNumber. The ErrDriverError
+sentinel matches any error number within the driver specific range (0x500 to 0xFFF).
+*/
+func (e *AlpacaError) Is(target error) bool {
+	t, ok := target.(*AlpacaError)
+
+	if !ok {
+		return false
+	}
+
+	if t == ErrDriverError {
+		return e.IsDriverError()
+	}
+
+	return e.ErrorNumber == t.ErrorNumber
+}
+
+/*
+IsDriverError()
+
+@returns true if the error number is within the range reserved for driver specific errors.
+*/
+func (e *AlpacaError) IsDriverError() bool {
+	return e.ErrorNumber >= DriverErrorMin && e.ErrorNumber <= DriverErrorMax
+}
+
+/*
+newAlpacaError()
+
+@returns an AlpacaError for a non-zero error number, otherwise nil.
+*/
+func newAlpacaError(errorNumber int32, errorMessage string) error {
+	if errorNumber == 0 {
+		return nil
+	}
+
+	return &AlpacaError{
+		ErrorNumber:  errorNumber,
+		ErrorMessage: errorMessage,
+	}
+}
+
+// ErrTransactionMismatch is matched by every TransactionMismatchError.
+var ErrTransactionMismatch = errors.New("ascom alpaca client transaction id mismatch")
+
+/*
+TransactionMismatchError
+
+Returned when the ClientTransactionID echoed in a response differs from the one that was
+sent with the request, i.e., the response does not belong to the request.
+*/
+type TransactionMismatchError struct {
+	Sent     uint32
+	Received uint32
+}
+
+/*
+Error()
+
+@returns the sent and received client transaction ids.
+*/
+func (e *TransactionMismatchError) Error() string {
+	return fmt.Sprintf("%s: sent %d, received %d", ErrTransactionMismatch.Error(), e.Sent, e.Received)
+}
+
+/*
+Is()
+
+@returns true if the target is ErrTransactionMismatch.
+*/
+func (e *TransactionMismatchError) Is(target error) bool {
+	return target == ErrTransactionMismatch
+}
+
+/*
+HTTPError
+
+Returned when the Alpaca server responds with a HTTP error status, e.g., 400 Bad Request for
+an invalid device number or an unknown member on an older server, or 500 Internal Server Error.
+*/
+type HTTPError struct {
+	StatusCode int
+	Message    string
+}
+
+/*
+Error()
+
+@returns the HTTP status code and the body of the response.
+*/
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
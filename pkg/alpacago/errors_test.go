@@ -0,0 +1,129 @@
+package alpacago
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAlpacaErrorIs(t *testing.T) {
+	var err error = &AlpacaError{ErrorNumber: 0x407, ErrorMessage: "camera is not connected"}
+
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("got %v, wanted errors.Is(err, ErrNotConnected) to be true", err)
+	}
+
+	if errors.Is(err, ErrNotImplemented) {
+		t.Errorf("got %v, wanted errors.Is(err, ErrNotImplemented) to be false", err)
+	}
+}
+
+func TestAlpacaErrorIsWrapped(t *testing.T) {
+	var err error = fmt.Errorf("wrapped: %w", &AlpacaError{ErrorNumber: 0x408, ErrorMessage: "telescope is parked"})
+
+	if !errors.Is(err, ErrInvalidWhileParked) {
+		t.Errorf("got %v, wanted errors.Is(err, ErrInvalidWhileParked) to be true", err)
+	}
+}
+
+func TestAlpacaErrorIsDriverError(t *testing.T) {
+	var err error = &AlpacaError{ErrorNumber: 0x5A1, ErrorMessage: "filter wheel jammed"}
+
+	if !errors.Is(err, ErrDriverError) {
+		t.Errorf("got %v, wanted errors.Is(err, ErrDriverError) to be true", err)
+	}
+
+	err = &AlpacaError{ErrorNumber: 0x1000, ErrorMessage: "out of range"}
+
+	if errors.Is(err, ErrDriverError) {
+		t.Errorf("got %v, wanted errors.Is(err, ErrDriverError) to be false", err)
+	}
+}
+
+func TestAlpacaErrorMessage(t *testing.T) {
+	var got string = (&AlpacaError{ErrorNumber: 0x400, ErrorMessage: "Property not implemented"}).Error()
+
+	var want string = "ascom alpaca error 0x400: Property not implemented"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestNewAlpacaErrorZero(t *testing.T) {
+	if err := newAlpacaError(0, ""); err != nil {
+		t.Errorf("got %v, wanted nil", err)
+	}
+}
+
+func TestGetResponseReturnsAlpacaError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":0,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":1031,"ErrorMessage":"Camera is not connected"}`, r.FormValue("ClientTransactionID"))
+	}))
+
+	defer server.Close()
+
+	client := NewAlpacaAPI(65535, false, "", "", 0)
+
+	client.UrlBase = server.URL
+
+	got, err := client.GetFloat64Response("camera", 0, "ccdtemperature")
+
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("got %v, wanted %v", err, ErrNotConnected)
+	}
+
+	if got != 0 {
+		t.Errorf("got %f, wanted %f", got, 0.0)
+	}
+}
+
+func TestPutReturnsAlpacaError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":1025,"ErrorMessage":"BinX must be between 1 and 4"}`, r.FormValue("ClientTransactionID"))
+	}))
+
+	defer server.Close()
+
+	camera := NewCamera(65535, false, "", "", 0, 0)
+
+	camera.Alpaca.UrlBase = server.URL
+
+	err := camera.SetBinX(16)
+
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("got %v, wanted %v", err, ErrInvalidValue)
+	}
+}
+
+func TestGetResponseReturnsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "device number 7 does not exist", http.StatusBadRequest)
+	}))
+
+	defer server.Close()
+
+	client := NewAlpacaAPI(65535, false, "", "", 0)
+
+	client.UrlBase = server.URL
+
+	_, err := client.GetBooleanResponse("camera", 7, "connected")
+
+	var httpErr *HTTPError
+
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("got %v, wanted a *HTTPError", err)
+	}
+
+	if httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("got %d, wanted %d", httpErr.StatusCode, http.StatusBadRequest)
+	}
+
+	if client.ErrorNumber != http.StatusBadRequest {
+		t.Errorf("got %d, wanted %d", client.ErrorNumber, http.StatusBadRequest)
+	}
+}
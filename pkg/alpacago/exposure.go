@@ -0,0 +1,229 @@
+package alpacago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The minimum and maximum interval between polls of the camera state during Expose():
+const (
+	EXPOSURE_POLL_MIN_INTERVAL = 100 * time.Millisecond
+	EXPOSURE_POLL_MAX_INTERVAL = 1 * time.Second
+)
+
+// The timeout of the abort or stop request sent when the context of Expose() is cancelled:
+const EXPOSURE_ABORT_TIMEOUT = 10 * time.Second
+
+// The default time allowed beyond the duration of an exposure for the image to be ready in Expose():
+const EXPOSURE_DOWNLOAD_TIMEOUT = 2 * time.Minute
+
+// ErrExposureTimeout is returned by Expose() when the image is not ready within the duration of the exposure
+// and Camera.ExposureTimeout, e.g., as the camera has returned to idle after a failed download.
+var ErrExposureTimeout = errors.New("exposure timed out")
+
+// ErrCameraError is returned by Expose() when the camera enters the CameraError state.
+var ErrCameraError = errors.New("camera is in the error state")
+
+/*
+ExposureProgress
+
+The progress of an exposure in Expose(), reported to the progress callback on every poll of the camera. The
+PercentCompleted is zero if the driver does not report it.
+*/
+type ExposureProgress struct {
+	State            OperationalState
+	PercentCompleted int32
+	Elapsed          time.Duration
+}
+
+type ExposureProgressFunc func(progress ExposureProgress)
+
+/*
+Exposure
+
+The image and metadata of an exposure taken by Expose(). The StartTime is nil if the driver does not report
+it, and the Duration is the actual duration of the exposure in seconds, as reported by the driver, or the
+requested duration if the driver does not report it.
+*/
+type Exposure struct {
+	Image     *Image
+	Light     bool
+	StartTime *time.Time
+	Duration  float64
+}
+
+/*
+exposurePollInterval()
+
+@returns the interval between polls of the camera state, a tenth of the exposure duration, bounded by
+EXPOSURE_POLL_MIN_INTERVAL and EXPOSURE_POLL_MAX_INTERVAL.
+*/
+func exposurePollInterval(duration float64) time.Duration {
+	interval := time.Duration(duration * float64(time.Second) / 10)
+
+	switch {
+	case interval < EXPOSURE_POLL_MIN_INTERVAL:
+		return EXPOSURE_POLL_MIN_INTERVAL
+	case interval > EXPOSURE_POLL_MAX_INTERVAL:
+		return EXPOSURE_POLL_MAX_INTERVAL
+	default:
+		return interval
+	}
+}
+
+/*
+Expose()
+
+Starts an exposure, polls the camera until the image is ready and downloads it.
+
+@param duration float64 (the duration of the exposure in seconds)
+@param light bool (true for a light frame, false for a dark frame)
+@param progress ExposureProgressFunc (called on every poll of the camera, may be nil)
+@returns the downloaded image, with the start time and duration of the exposure, ErrCameraError if the
+camera enters the CameraError state, or ErrExposureTimeout, after aborting or stopping the exposure, if the
+image is not ready within the duration of the exposure and Camera.ExposureTimeout.
+*/
+func (c *Camera) Expose(duration float64, light bool, progress ExposureProgressFunc) (*Exposure, error) {
+	return c.ExposeContext(context.Background(), duration, light, progress)
+}
+
+/*
+ExposeContext()
+
+Expose() with a context, which controls the cancellation and deadline of the exposure. If the context is
+cancelled during the exposure, the exposure is aborted if the camera can abort exposures, otherwise it is
+stopped if the camera can stop exposures.
+*/
+func (c *Camera) ExposeContext(ctx context.Context, duration float64, light bool, progress ExposureProgressFunc) (*Exposure, error) {
+	if err := c.StartExposureContext(ctx, duration, light); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	interval := exposurePollInterval(duration)
+
+	timeout := c.ExposureTimeout
+
+	if timeout == 0 {
+		timeout = EXPOSURE_DOWNLOAD_TIMEOUT
+	}
+
+	deadline := start.Add(time.Duration(duration*float64(time.Second)) + timeout)
+
+	for {
+		state, err := c.Alpaca.GetInt32ResponseContext(ctx, "camera", c.DeviceNumber, "camerastate")
+
+		if err != nil {
+			return nil, c.cancelExposure(ctx, err)
+		}
+
+		if OperationalState(state) == CameraError {
+			return nil, fmt.Errorf("%w: exposure of %gs failed after %s", ErrCameraError, duration, time.Since(start).Round(time.Millisecond))
+		}
+
+		if progress != nil {
+			// The percentage completed is optional, and invalid whilst the camera is idle:
+			percent, _ := c.GetCurrentOperationPercentageCompleteContext(ctx)
+
+			progress(ExposureProgress{
+				State:            OperationalState(state),
+				PercentCompleted: percent,
+				Elapsed:          time.Since(start),
+			})
+		}
+
+		ready, err := c.IsImageReadyContext(ctx)
+
+		if err != nil {
+			return nil, c.cancelExposure(ctx, err)
+		}
+
+		if ready {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return nil, c.abortExposure(fmt.Errorf("%w: image not ready %s after the start of a %gs exposure", ErrExposureTimeout, time.Since(start).Round(time.Millisecond), duration))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, c.cancelExposure(ctx, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+
+	image, err := c.GetImageContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	exposure := Exposure{
+		Image: image,
+		Light: light,
+	}
+
+	exposure.StartTime, err = c.GetLastExposureStartTimeContext(ctx)
+
+	if err != nil && !errors.Is(err, ErrNotImplemented) {
+		return nil, err
+	}
+
+	exposure.Duration, err = c.GetLastExposureDurationContext(ctx)
+
+	if err != nil && !errors.Is(err, ErrNotImplemented) {
+		return nil, err
+	}
+
+	// The requested duration is kept if the driver does not report the actual duration, so that the EXPTIME of
+	// the image and the calibration frames matched to it are not those of a zero second exposure:
+	if err != nil || exposure.Duration <= 0 {
+		exposure.Duration = duration
+	}
+
+	return &exposure, nil
+}
+
+/*
+cancelExposure()
+
+Aborts, or failing that stops, the exposure in progress if the context of Expose() has been cancelled, see
+abortExposure().
+
+@returns the cause, joined with any error from aborting or stopping the exposure.
+*/
+func (c *Camera) cancelExposure(ctx context.Context, cause error) error {
+	if ctx.Err() == nil {
+		return cause
+	}
+
+	return c.abortExposure(cause)
+}
+
+/*
+abortExposure()
+
+Aborts, or failing that stops, the exposure in progress, using a fresh context so that the request is sent
+regardless of the context of Expose().
+
+@returns the cause, joined with any error from aborting or stopping the exposure.
+*/
+func (c *Camera) abortExposure(cause error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), EXPOSURE_ABORT_TIMEOUT)
+
+	defer cancel()
+
+	if canAbort, err := c.CanAbortExposureContext(ctx); err == nil && canAbort {
+		return errors.Join(cause, c.AbortExposureContext(ctx))
+	}
+
+	if canStop, err := c.CanStopExposureContext(ctx); err == nil && canStop {
+		return errors.Join(cause, c.StopExposureContext(ctx))
+	}
+
+	return cause
+}
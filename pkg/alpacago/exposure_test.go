@@ -0,0 +1,204 @@
+package alpacago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+)
+
+type exposureTestCamera struct {
+	mu       sync.Mutex
+	polls    int
+	ready    int
+	state    OperationalState
+	aborted  bool
+	canAbort bool
+	// Whether lastexposureduration is not implemented by the driver:
+	noDuration bool
+}
+
+func newExposureTestServer(t *testing.T, camera *exposureTestCamera) *alpacatest.Server {
+	s := alpacatest.NewServer(t, map[string]string{
+		"camera/0/percentcompleted":      `50`,
+		"camera/0/lastexposurestarttime": `"2026-10-17T21:30:00.123"`,
+		"camera/0/canstopexposure":       `false`,
+	})
+
+	s.HandleFunc("camera/0/startexposure", func(member string, r *http.Request) alpacatest.Response {
+		if r.FormValue("Duration") != "0.500000" || r.FormValue("Light") != "true" {
+			t.Errorf("got Duration=%s Light=%s, wanted Duration=0.500000 Light=true", r.FormValue("Duration"), r.FormValue("Light"))
+		}
+
+		return alpacatest.Value(`null`)
+	})
+
+	s.HandleFunc("camera/0/camerastate", func(member string, r *http.Request) alpacatest.Response {
+		camera.mu.Lock()
+		defer camera.mu.Unlock()
+
+		camera.polls++
+
+		return alpacatest.Value(fmt.Sprint(int32(camera.state)))
+	})
+
+	s.HandleFunc("camera/0/imageready", func(member string, r *http.Request) alpacatest.Response {
+		camera.mu.Lock()
+		defer camera.mu.Unlock()
+
+		return alpacatest.Value(fmt.Sprint(camera.ready > 0 && camera.polls >= camera.ready))
+	})
+
+	s.HandleFunc("camera/0/imagearray", func(member string, r *http.Request) alpacatest.Response {
+		return alpacatest.ImageArray(`[[1,2],[3,4]]`)
+	})
+
+	s.HandleFunc("camera/0/lastexposureduration", func(member string, r *http.Request) alpacatest.Response {
+		if camera.noDuration {
+			return alpacatest.NotImplemented
+		}
+
+		return alpacatest.Value(`0.5`)
+	})
+
+	s.HandleFunc("camera/0/canabortexposure", func(member string, r *http.Request) alpacatest.Response {
+		return alpacatest.Value(fmt.Sprint(camera.canAbort))
+	})
+
+	s.HandleFunc("camera/0/abortexposure", func(member string, r *http.Request) alpacatest.Response {
+		camera.mu.Lock()
+		camera.aborted = true
+		camera.mu.Unlock()
+
+		return alpacatest.Value(`null`)
+	})
+
+	return s
+}
+
+func TestCameraExpose(t *testing.T) {
+	camera := &exposureTestCamera{
+		ready: 3,
+		state: CameraExposing,
+	}
+
+	ts := newExposureTestServer(t, camera)
+
+	var updates []ExposureProgress
+
+	got, err := NewCameraWithOptions(ts.URL, 0).Expose(0.5, true, func(progress ExposureProgress) {
+		updates = append(updates, progress)
+	})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.Image.Width != 2 || got.Image.Height != 2 || got.Image.At(1, 0, 0) != 3 {
+		t.Errorf("got %+v, wanted the downloaded image", got.Image)
+	}
+
+	if got.StartTime == nil || !got.StartTime.Equal(time.Date(2026, 10, 17, 21, 30, 0, 123000000, time.UTC)) {
+		t.Errorf("got %v, wanted 2026-10-17T21:30:00.123Z", got.StartTime)
+	}
+
+	if got.Duration != 0.5 || !got.Light {
+		t.Errorf("got %+v, wanted a 0.5s light exposure", got)
+	}
+
+	if len(updates) != 3 || updates[0].State != CameraExposing || updates[0].PercentCompleted != 50 {
+		t.Errorf("got %+v, wanted three progress updates whilst exposing", updates)
+	}
+}
+
+func TestCameraExposeDurationNotImplemented(t *testing.T) {
+	camera := &exposureTestCamera{
+		ready:      1,
+		state:      CameraExposing,
+		noDuration: true,
+	}
+
+	ts := newExposureTestServer(t, camera)
+
+	got, err := NewCameraWithOptions(ts.URL, 0).Expose(0.5, true, nil)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.Duration != 0.5 {
+		t.Errorf("got %v, wanted the requested duration of %v", got.Duration, 0.5)
+	}
+}
+
+func TestCameraExposeTimeout(t *testing.T) {
+	// The camera returns to idle without the image ever becoming ready:
+	camera := &exposureTestCamera{
+		state:    CameraIdle,
+		canAbort: true,
+	}
+
+	ts := newExposureTestServer(t, camera)
+
+	c := NewCameraWithOptions(ts.URL, 0)
+
+	c.ExposureTimeout = 50 * time.Millisecond
+
+	_, err := c.Expose(0.5, true, nil)
+
+	if !errors.Is(err, ErrExposureTimeout) {
+		t.Fatalf("got %v, wanted %v", err, ErrExposureTimeout)
+	}
+
+	camera.mu.Lock()
+	defer camera.mu.Unlock()
+
+	if !camera.aborted {
+		t.Errorf("got the exposure not aborted, wanted it aborted")
+	}
+}
+
+func TestCameraExposeCameraError(t *testing.T) {
+	camera := &exposureTestCamera{
+		state: CameraError,
+	}
+
+	ts := newExposureTestServer(t, camera)
+
+	_, err := NewCameraWithOptions(ts.URL, 0).Expose(0.5, true, nil)
+
+	if !errors.Is(err, ErrCameraError) {
+		t.Errorf("got %v, wanted %v", err, ErrCameraError)
+	}
+}
+
+func TestCameraExposeContextCancelled(t *testing.T) {
+	camera := &exposureTestCamera{
+		state:    CameraExposing,
+		canAbort: true,
+	}
+
+	ts := newExposureTestServer(t, camera)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+
+	defer cancel()
+
+	_, err := NewCameraWithOptions(ts.URL, 0).ExposeContext(ctx, 0.5, true, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, wanted %v", err, context.DeadlineExceeded)
+	}
+
+	camera.mu.Lock()
+	defer camera.mu.Unlock()
+
+	if !camera.aborted {
+		t.Errorf("got no abort, wanted the exposure to be aborted on cancellation")
+	}
+}
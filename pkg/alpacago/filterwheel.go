@@ -1,12 +1,27 @@
 package alpacago
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 type FilterWheel struct {
 	Alpaca       *ASCOMAlpacaAPIClient
 	DeviceNumber uint
 }
 
+/*
+FilterWheelDeviceState
+
+The operational properties of the filter wheel, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type FilterWheelDeviceState struct {
+	Position  int32  `json:"Position"`
+	TimeStamp string `json:"TimeStamp"`
+}
+
 func NewFilterWheel(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *FilterWheel {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -18,6 +33,17 @@ func NewFilterWheel(clientId uint32, secure bool, domain string, ip string, port
 	return &filterwheel
 }
 
+func NewFilterWheelWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *FilterWheel {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	filterwheel := FilterWheel{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &filterwheel
+}
+
 /*
 GetDescription() common method to all ASCOM Alpaca compliant devices
 
@@ -25,7 +51,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (f *FilterWheel) GetDescription() (string, error) {
-	return f.Alpaca.GetDescription("filterwheel", f.DeviceNumber)
+	return f.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) GetDescriptionContext(ctx context.Context) (string, error) {
+	return f.Alpaca.GetDescriptionContext(ctx, "filterwheel", f.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (f *FilterWheel) GetSupportedActions() ([]string, error) {
+	return f.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return f.Alpaca.GetSupportedActionsContext(ctx, "filterwheel", f.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (f *FilterWheel) Action(action string, parameters string) (string, error) {
+	return f.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return f.Alpaca.ActionContext(ctx, "filterwheel", f.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (f *FilterWheel) CommandBlind(command string, raw bool) error {
+	return f.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return f.Alpaca.CommandBlindContext(ctx, "filterwheel", f.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (f *FilterWheel) CommandBool(command string, raw bool) (bool, error) {
+	return f.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return f.Alpaca.CommandBoolContext(ctx, "filterwheel", f.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (f *FilterWheel) CommandString(command string, raw bool) (string, error) {
+	return f.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return f.Alpaca.CommandStringContext(ctx, "filterwheel", f.DeviceNumber, command, raw)
 }
 
 /*
@@ -35,7 +173,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (f *FilterWheel) IsConnected() (bool, error) {
-	return f.Alpaca.GetBooleanResponse("filterwheel", f.DeviceNumber, "connected")
+	return f.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) IsConnectedContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.GetBooleanResponseContext(ctx, "filterwheel", f.DeviceNumber, "connected")
 }
 
 /*
@@ -46,16 +193,145 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (f *FilterWheel) SetConnected(connected bool) error {
-	f.Alpaca.TransactionId++
+	return f.SetConnectedContext(context.Background(), connected)
+}
 
+/*
+SetConnectedContext()
+
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", f.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", f.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return f.Alpaca.PutContext(ctx, "filterwheel", f.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (f *FilterWheel) Connect() error {
+	return f.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) ConnectContext(ctx context.Context) error {
+	return f.Alpaca.ConnectContext(ctx, "filterwheel", f.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (f *FilterWheel) Disconnect() error {
+	return f.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) DisconnectContext(ctx context.Context) error {
+	return f.Alpaca.DisconnectContext(ctx, "filterwheel", f.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (f *FilterWheel) IsConnecting() (bool, error) {
+	return f.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) IsConnectingContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.IsConnectingContext(ctx, "filterwheel", f.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the filter wheel in a single round trip, see FilterWheelDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (f *FilterWheel) GetDeviceState() (*FilterWheelDeviceState, error) {
+	return f.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) GetDeviceStateContext(ctx context.Context) (*FilterWheelDeviceState, error) {
+	values, err := f.Alpaca.GetDeviceStateContext(ctx, "filterwheel", f.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := FilterWheelDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the filter wheel from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (f *FilterWheel) GetDeviceStateWithFallback() (*FilterWheelDeviceState, error) {
+	return f.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) GetDeviceStateWithFallbackContext(ctx context.Context) (*FilterWheelDeviceState, error) {
+	state, err := f.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
 	}
 
-	return f.Alpaca.Put("filterwheel", f.DeviceNumber, "connected", form)
+	state = &FilterWheelDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.Position, err = f.GetPositionContext(ctx)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -65,7 +341,16 @@ GetFocusOffsets()
 @see https://ascom-standards.org/api/#/FilterWheel%20Specific%20Methods/get_filterwheel__device_number__focusoffsets
 */
 func (f *FilterWheel) GetFocusOffsets() ([]uint32, error) {
-	return f.Alpaca.GetUInt32ListResponse("filterwheel", f.DeviceNumber, "focusoffsets")
+	return f.GetFocusOffsetsContext(context.Background())
+}
+
+/*
+GetFocusOffsetsContext()
+
+GetFocusOffsets() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) GetFocusOffsetsContext(ctx context.Context) ([]uint32, error) {
+	return f.Alpaca.GetUInt32ListResponseContext(ctx, "filterwheel", f.DeviceNumber, "focusoffsets")
 }
 
 /*
@@ -75,7 +360,16 @@ GetNames()
 @see https://ascom-standards.org/api/#/FilterWheel%20Specific%20Methods/get_filterwheel__device_number__names
 */
 func (f *FilterWheel) GetNames() ([]string, error) {
-	return f.Alpaca.GetStringListResponse("filterwheel", f.DeviceNumber, "names")
+	return f.GetNamesContext(context.Background())
+}
+
+/*
+GetNamesContext()
+
+GetNames() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) GetNamesContext(ctx context.Context) ([]string, error) {
+	return f.Alpaca.GetStringListResponseContext(ctx, "filterwheel", f.DeviceNumber, "names")
 }
 
 /*
@@ -85,7 +379,16 @@ GetPosition()
 @see https://ascom-standards.org/api/#/FilterWheel%20Specific%20Methods/get_filterwheel__device_number__position
 */
 func (f *FilterWheel) GetPosition() (int32, error) {
-	return f.Alpaca.GetInt32Response("filterwheel", f.DeviceNumber, "position")
+	return f.GetPositionContext(context.Background())
+}
+
+/*
+GetPositionContext()
+
+GetPosition() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) GetPositionContext(ctx context.Context) (int32, error) {
+	return f.Alpaca.GetInt32ResponseContext(ctx, "filterwheel", f.DeviceNumber, "position")
 }
 
 /*
@@ -95,13 +398,18 @@ SetPosition()
 @see https://ascom-standards.org/api/#/FilterWheel%20Specific%20Methods/put_filterwheel__device_number__position
 */
 func (f *FilterWheel) SetPosition(position int32) error {
-	f.Alpaca.TransactionId++
+	return f.SetPositionContext(context.Background(), position)
+}
 
+/*
+SetPositionContext()
+
+SetPosition() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *FilterWheel) SetPositionContext(ctx context.Context, position int32) error {
 	var form map[string]string = map[string]string{
-		"Position":            fmt.Sprintf("%d", position),
-		"ClientID":            fmt.Sprintf("%d", f.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", f.Alpaca.TransactionId),
+		"Position": fmt.Sprintf("%d", position),
 	}
 
-	return f.Alpaca.Put("filterwheel", f.DeviceNumber, "position", form)
+	return f.Alpaca.PutContext(ctx, "filterwheel", f.DeviceNumber, "position", form)
 }
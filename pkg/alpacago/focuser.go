@@ -1,12 +1,29 @@
 package alpacago
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 type Focuser struct {
 	Alpaca       *ASCOMAlpacaAPIClient
 	DeviceNumber uint
 }
 
+/*
+FocuserDeviceState
+
+The operational properties of the focuser, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type FocuserDeviceState struct {
+	IsMoving    bool    `json:"IsMoving"`
+	Position    int32   `json:"Position"`
+	Temperature float64 `json:"Temperature"`
+	TimeStamp   string  `json:"TimeStamp"`
+}
+
 func NewFocuser(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *Focuser {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -18,6 +35,17 @@ func NewFocuser(clientId uint32, secure bool, domain string, ip string, port int
 	return &focuser
 }
 
+func NewFocuserWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *Focuser {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	focuser := Focuser{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &focuser
+}
+
 /*
 GetDescription() common method to all ASCOM Alpaca compliant devices
 
@@ -25,7 +53,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (f *Focuser) GetDescription() (string, error) {
-	return f.Alpaca.GetDescription("focuser", f.DeviceNumber)
+	return f.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetDescriptionContext(ctx context.Context) (string, error) {
+	return f.Alpaca.GetDescriptionContext(ctx, "focuser", f.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (f *Focuser) GetSupportedActions() ([]string, error) {
+	return f.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return f.Alpaca.GetSupportedActionsContext(ctx, "focuser", f.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (f *Focuser) Action(action string, parameters string) (string, error) {
+	return f.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return f.Alpaca.ActionContext(ctx, "focuser", f.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (f *Focuser) CommandBlind(command string, raw bool) error {
+	return f.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return f.Alpaca.CommandBlindContext(ctx, "focuser", f.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (f *Focuser) CommandBool(command string, raw bool) (bool, error) {
+	return f.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return f.Alpaca.CommandBoolContext(ctx, "focuser", f.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (f *Focuser) CommandString(command string, raw bool) (string, error) {
+	return f.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return f.Alpaca.CommandStringContext(ctx, "focuser", f.DeviceNumber, command, raw)
 }
 
 /*
@@ -35,7 +175,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (f *Focuser) IsConnected() (bool, error) {
-	return f.Alpaca.GetBooleanResponse("focuser", f.DeviceNumber, "connected")
+	return f.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) IsConnectedContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.GetBooleanResponseContext(ctx, "focuser", f.DeviceNumber, "connected")
 }
 
 /*
@@ -46,16 +195,151 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (f *Focuser) SetConnected(connected bool) error {
-	f.Alpaca.TransactionId++
+	return f.SetConnectedContext(context.Background(), connected)
+}
+
+/*
+SetConnectedContext()
 
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", f.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", f.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return f.Alpaca.PutContext(ctx, "focuser", f.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (f *Focuser) Connect() error {
+	return f.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) ConnectContext(ctx context.Context) error {
+	return f.Alpaca.ConnectContext(ctx, "focuser", f.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (f *Focuser) Disconnect() error {
+	return f.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) DisconnectContext(ctx context.Context) error {
+	return f.Alpaca.DisconnectContext(ctx, "focuser", f.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (f *Focuser) IsConnecting() (bool, error) {
+	return f.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) IsConnectingContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.IsConnectingContext(ctx, "focuser", f.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the focuser in a single round trip, see FocuserDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (f *Focuser) GetDeviceState() (*FocuserDeviceState, error) {
+	return f.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetDeviceStateContext(ctx context.Context) (*FocuserDeviceState, error) {
+	values, err := f.Alpaca.GetDeviceStateContext(ctx, "focuser", f.DeviceNumber)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return f.Alpaca.Put("focuser", f.DeviceNumber, "connected", form)
+	state := FocuserDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the focuser from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (f *Focuser) GetDeviceStateWithFallback() (*FocuserDeviceState, error) {
+	return f.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetDeviceStateWithFallbackContext(ctx context.Context) (*FocuserDeviceState, error) {
+	state, err := f.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	state = &FocuserDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.IsMoving, err = f.IsMovingContext(ctx)
+	errs = append(errs, err)
+
+	state.Position, err = f.GetPositionContext(ctx)
+	errs = append(errs, err)
+
+	state.Temperature, err = f.GetTemperatureContext(ctx)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -65,7 +349,16 @@ IsAbsolute()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__absolute
 */
 func (f *Focuser) IsAbsolute() (bool, error) {
-	return f.Alpaca.GetBooleanResponse("focuser", f.DeviceNumber, "absolute")
+	return f.IsAbsoluteContext(context.Background())
+}
+
+/*
+IsAbsoluteContext()
+
+IsAbsolute() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) IsAbsoluteContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.GetBooleanResponseContext(ctx, "focuser", f.DeviceNumber, "absolute")
 }
 
 /*
@@ -75,7 +368,16 @@ IsMoving()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__ismoving
 */
 func (f *Focuser) IsMoving() (bool, error) {
-	return f.Alpaca.GetBooleanResponse("focuser", f.DeviceNumber, "ismoving")
+	return f.IsMovingContext(context.Background())
+}
+
+/*
+IsMovingContext()
+
+IsMoving() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) IsMovingContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.GetBooleanResponseContext(ctx, "focuser", f.DeviceNumber, "ismoving")
 }
 
 /*
@@ -85,7 +387,16 @@ GetMaxIncrement()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__maxincrement
 */
 func (f *Focuser) GetMaxIncrement() (int32, error) {
-	return f.Alpaca.GetInt32Response("focuser", f.DeviceNumber, "maxincrement")
+	return f.GetMaxIncrementContext(context.Background())
+}
+
+/*
+GetMaxIncrementContext()
+
+GetMaxIncrement() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetMaxIncrementContext(ctx context.Context) (int32, error) {
+	return f.Alpaca.GetInt32ResponseContext(ctx, "focuser", f.DeviceNumber, "maxincrement")
 }
 
 /*
@@ -95,7 +406,16 @@ GetMaxStep()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__maxstep
 */
 func (f *Focuser) GetMaxStep() (int32, error) {
-	return f.Alpaca.GetInt32Response("focuser", f.DeviceNumber, "maxstep")
+	return f.GetMaxStepContext(context.Background())
+}
+
+/*
+GetMaxStepContext()
+
+GetMaxStep() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetMaxStepContext(ctx context.Context) (int32, error) {
+	return f.Alpaca.GetInt32ResponseContext(ctx, "focuser", f.DeviceNumber, "maxstep")
 }
 
 /*
@@ -105,7 +425,16 @@ GetPosition()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__position
 */
 func (f *Focuser) GetPosition() (int32, error) {
-	return f.Alpaca.GetInt32Response("focuser", f.DeviceNumber, "position")
+	return f.GetPositionContext(context.Background())
+}
+
+/*
+GetPositionContext()
+
+GetPosition() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetPositionContext(ctx context.Context) (int32, error) {
+	return f.Alpaca.GetInt32ResponseContext(ctx, "focuser", f.DeviceNumber, "position")
 }
 
 /*
@@ -115,7 +444,16 @@ GetStepSize()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__stepsize
 */
 func (f *Focuser) GetStepSize() (float64, error) {
-	return f.Alpaca.GetFloat64Response("focuser", f.DeviceNumber, "stepsize")
+	return f.GetStepSizeContext(context.Background())
+}
+
+/*
+GetStepSizeContext()
+
+GetStepSize() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetStepSizeContext(ctx context.Context) (float64, error) {
+	return f.Alpaca.GetFloat64ResponseContext(ctx, "focuser", f.DeviceNumber, "stepsize")
 }
 
 /*
@@ -125,7 +463,16 @@ GetTemperatureCompensation()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__tempcomp
 */
 func (f *Focuser) GetTemperatureCompensation() (bool, error) {
-	return f.Alpaca.GetBooleanResponse("focuser", f.DeviceNumber, "tempcomp")
+	return f.GetTemperatureCompensationContext(context.Background())
+}
+
+/*
+GetTemperatureCompensationContext()
+
+GetTemperatureCompensation() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetTemperatureCompensationContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.GetBooleanResponseContext(ctx, "focuser", f.DeviceNumber, "tempcomp")
 }
 
 /*
@@ -136,16 +483,21 @@ SetTemperatureCompensation()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/put_focuser__device_number__tempcomp
 */
 func (f *Focuser) SetTemperatureCompensation(tempComp bool) error {
-	f.Alpaca.TransactionId++
+	return f.SetTemperatureCompensationContext(context.Background(), tempComp)
+}
+
+/*
+SetTemperatureCompensationContext()
 
+SetTemperatureCompensation() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) SetTemperatureCompensationContext(ctx context.Context, tempComp bool) error {
 	var form map[string]string = map[string]string{
 		// Set true to enable the focuser's temperature compensation mode, otherwise false for normal operation.
-		"TempComp":            fmt.Sprintf("%t", tempComp),
-		"ClientID":            fmt.Sprintf("%d", f.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", f.Alpaca.TransactionId),
+		"TempComp": fmt.Sprintf("%t", tempComp),
 	}
 
-	return f.Alpaca.Put("focuser", f.DeviceNumber, "tempcomp", form)
+	return f.Alpaca.PutContext(ctx, "focuser", f.DeviceNumber, "tempcomp", form)
 }
 
 /*
@@ -155,7 +507,16 @@ IsTemperatureCompensationAvailable()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__tempcompavailable
 */
 func (f *Focuser) IsTemperatureCompensationAvailable() (bool, error) {
-	return f.Alpaca.GetBooleanResponse("focuser", f.DeviceNumber, "tempcompavailable")
+	return f.IsTemperatureCompensationAvailableContext(context.Background())
+}
+
+/*
+IsTemperatureCompensationAvailableContext()
+
+IsTemperatureCompensationAvailable() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) IsTemperatureCompensationAvailableContext(ctx context.Context) (bool, error) {
+	return f.Alpaca.GetBooleanResponseContext(ctx, "focuser", f.DeviceNumber, "tempcompavailable")
 }
 
 /*
@@ -165,7 +526,16 @@ GetTemperature()
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/get_focuser__device_number__temperature
 */
 func (f *Focuser) GetTemperature() (float64, error) {
-	return f.Alpaca.GetFloat64Response("focuser", f.DeviceNumber, "temperature")
+	return f.GetTemperatureContext(context.Background())
+}
+
+/*
+GetTemperatureContext()
+
+GetTemperature() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) GetTemperatureContext(ctx context.Context) (float64, error) {
+	return f.Alpaca.GetFloat64ResponseContext(ctx, "focuser", f.DeviceNumber, "temperature")
 }
 
 /*
@@ -175,14 +545,18 @@ SetHalt
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/put_focuser__device_number__halt
 */
 func (f *Focuser) SetHalt() error {
-	f.Alpaca.TransactionId++
+	return f.SetHaltContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", f.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", f.Alpaca.TransactionId),
-	}
+/*
+SetHaltContext()
 
-	return f.Alpaca.Put("focuser", f.DeviceNumber, "halt", form)
+SetHalt() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) SetHaltContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return f.Alpaca.PutContext(ctx, "focuser", f.DeviceNumber, "halt", form)
 }
 
 /*
@@ -194,14 +568,19 @@ position depending on the value of the Absolute property.
 @see https://ascom-standards.org/api/#/Focuser%20Specific%20Methods/put_focuser__device_number__move
 */
 func (f *Focuser) SetMove(position int32) error {
-	f.Alpaca.TransactionId++
+	return f.SetMoveContext(context.Background(), position)
+}
 
+/*
+SetMoveContext()
+
+SetMove() with a context, which controls the cancellation and deadline of the request.
+*/
+func (f *Focuser) SetMoveContext(ctx context.Context, position int32) error {
 	var form map[string]string = map[string]string{
 		// Step distance or absolute position, depending on the value of the Absolute property
-		"Position":            fmt.Sprintf("%d", position),
-		"ClientID":            fmt.Sprintf("%d", f.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", f.Alpaca.TransactionId),
+		"Position": fmt.Sprintf("%d", position),
 	}
 
-	return f.Alpaca.Put("focuser", f.DeviceNumber, "move", form)
+	return f.Alpaca.PutContext(ctx, "focuser", f.DeviceNumber, "move", form)
 }
@@ -0,0 +1,265 @@
+package alpacago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidFrame is returned by SetFrame() when the frame is not possible on the camera, before any setting is changed.
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// ErrFrameMismatch is returned by SetFrame() when the settings read back from the camera differ from those applied.
+var ErrFrameMismatch = errors.New("camera did not apply the frame")
+
+/*
+Frame
+
+The region of the sensor read out by an exposure, and the binning of the readout. X, Y, Width and Height are in
+unbinned sensor pixels, whereas the StartX, StartY, NumX and NumY of the camera are in binned pixels.
+*/
+type Frame struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+	BinX   int32
+	BinY   int32
+}
+
+func (f Frame) String() string {
+	return fmt.Sprintf("%dx%d at (%d, %d), binned %dx%d", f.Width, f.Height, f.X, f.Y, f.BinX, f.BinY)
+}
+
+/*
+NewFullFrame()
+
+@returns the frame of the whole sensor, of ccdSizeX by ccdSizeY unbinned pixels, at the given binning.
+*/
+func NewFullFrame(ccdSizeX int32, ccdSizeY int32, binX int32, binY int32) Frame {
+	return Frame{
+		Width:  ccdSizeX,
+		Height: ccdSizeY,
+		BinX:   binX,
+		BinY:   binY,
+	}
+}
+
+/*
+Binned()
+
+@returns the StartX, StartY, NumX and NumY of the frame in binned pixels. A frame that is not aligned to the
+binning is reduced to the whole binned pixels within it, i.e., its start is rounded up and its end rounded down.
+*/
+func (f Frame) Binned() (int32, int32, int32, int32) {
+	startX, startY := (f.X+f.BinX-1)/f.BinX, (f.Y+f.BinY-1)/f.BinY
+
+	return startX, startY, (f.X+f.Width)/f.BinX - startX, (f.Y+f.Height)/f.BinY - startY
+}
+
+/*
+FrameLimits
+
+The geometry and binning limits of a camera, against which frames are validated.
+*/
+type FrameLimits struct {
+	CCDSizeX         int32
+	CCDSizeY         int32
+	MaxBinX          int32
+	MaxBinY          int32
+	CanAsymmetricBin bool
+}
+
+/*
+Validate()
+
+@returns nil if the frame is possible within the limits, otherwise an error wrapping ErrInvalidFrame.
+*/
+func (f Frame) Validate(limits FrameLimits) error {
+	if f.BinX < 1 || f.BinX > limits.MaxBinX || f.BinY < 1 || f.BinY > limits.MaxBinY {
+		return fmt.Errorf("%w: binning %dx%d is outside of 1x1 to %dx%d", ErrInvalidFrame, f.BinX, f.BinY, limits.MaxBinX, limits.MaxBinY)
+	}
+
+	if f.BinX != f.BinY && !limits.CanAsymmetricBin {
+		return fmt.Errorf("%w: binning %dx%d is asymmetric, which the camera does not support", ErrInvalidFrame, f.BinX, f.BinY)
+	}
+
+	if f.X < 0 || f.Y < 0 || f.Width <= 0 || f.Height <= 0 || f.X+f.Width > limits.CCDSizeX || f.Y+f.Height > limits.CCDSizeY {
+		return fmt.Errorf("%w: %dx%d at (%d, %d) is outside of the %dx%d sensor", ErrInvalidFrame, f.Width, f.Height, f.X, f.Y, limits.CCDSizeX, limits.CCDSizeY)
+	}
+
+	if _, _, numX, numY := f.Binned(); numX < 1 || numY < 1 {
+		return fmt.Errorf("%w: %dx%d at (%d, %d) holds no whole binned pixel of %dx%d", ErrInvalidFrame, f.Width, f.Height, f.X, f.Y, f.BinX, f.BinY)
+	}
+
+	return nil
+}
+
+/*
+GetFrameLimits()
+
+@returns the sensor size, maximum binning and asymmetric binning support of the camera.
+*/
+func (c *Camera) GetFrameLimits() (FrameLimits, error) {
+	return c.GetFrameLimitsContext(context.Background())
+}
+
+/*
+GetFrameLimitsContext()
+
+GetFrameLimits() with a context, which controls the cancellation and deadline of the requests.
+*/
+func (c *Camera) GetFrameLimitsContext(ctx context.Context) (FrameLimits, error) {
+	limits := FrameLimits{}
+
+	var err error
+
+	if limits.CCDSizeX, err = c.GetCCDSizeXContext(ctx); err != nil {
+		return limits, err
+	}
+
+	if limits.CCDSizeY, err = c.GetCCDSizeYContext(ctx); err != nil {
+		return limits, err
+	}
+
+	if limits.MaxBinX, err = c.GetMaxBinXContext(ctx); err != nil {
+		return limits, err
+	}
+
+	if limits.MaxBinY, err = c.GetMaxBinYContext(ctx); err != nil {
+		return limits, err
+	}
+
+	if limits.CanAsymmetricBin, err = c.CanAsymmetricBinContext(ctx); err != nil {
+		return limits, err
+	}
+
+	return limits, nil
+}
+
+/*
+GetFrame()
+
+@returns the current frame of the camera, in unbinned sensor pixels.
+*/
+func (c *Camera) GetFrame() (Frame, error) {
+	return c.GetFrameContext(context.Background())
+}
+
+/*
+GetFrameContext()
+
+GetFrame() with a context, which controls the cancellation and deadline of the requests.
+*/
+func (c *Camera) GetFrameContext(ctx context.Context) (Frame, error) {
+	binX, binY, startX, startY, numX, numY, err := c.getBinnedFrameContext(ctx)
+
+	if err != nil {
+		return Frame{}, err
+	}
+
+	return Frame{
+		X:      startX * binX,
+		Y:      startY * binY,
+		Width:  numX * binX,
+		Height: numY * binY,
+		BinX:   binX,
+		BinY:   binY,
+	}, nil
+}
+
+func (c *Camera) getBinnedFrameContext(ctx context.Context) (binX int32, binY int32, startX int32, startY int32, numX int32, numY int32, err error) {
+	if binX, err = c.GetBinXContext(ctx); err != nil {
+		return
+	}
+
+	if binY, err = c.GetBinYContext(ctx); err != nil {
+		return
+	}
+
+	if startX, err = c.GetStartXContext(ctx); err != nil {
+		return
+	}
+
+	if startY, err = c.GetStartYContext(ctx); err != nil {
+		return
+	}
+
+	if numX, err = c.GetSubFrameWidthContext(ctx); err != nil {
+		return
+	}
+
+	numY, err = c.GetSubFrameHeightContext(ctx)
+
+	return
+}
+
+/*
+SetFrame()
+
+Validates the frame against the limits of the camera, then sets the binning and subframe of the camera, and
+reads them back to confirm that the camera applied them.
+
+The subframe origin is first reset to the corner of the sensor, so that no intermediate combination of binning,
+origin and size lies outside of the sensor, then the binning is set, followed by the size and the origin.
+
+@param frame Frame (the frame, in unbinned sensor pixels)
+@returns ErrInvalidFrame if the frame is not possible on the camera, in which case no setting is changed, or
+ErrFrameMismatch if the settings read back differ from those applied.
+*/
+func (c *Camera) SetFrame(frame Frame) error {
+	return c.SetFrameContext(context.Background(), frame)
+}
+
+/*
+SetFrameContext()
+
+SetFrame() with a context, which controls the cancellation and deadline of the requests.
+*/
+func (c *Camera) SetFrameContext(ctx context.Context, frame Frame) error {
+	limits, err := c.GetFrameLimitsContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if err := frame.Validate(limits); err != nil {
+		return err
+	}
+
+	startX, startY, numX, numY := frame.Binned()
+
+	steps := []func() error{
+		func() error { return c.SetStartXContext(ctx, 0) },
+		func() error { return c.SetStartYContext(ctx, 0) },
+		func() error { return c.SetBinXContext(ctx, frame.BinX) },
+		func() error { return c.SetBinYContext(ctx, frame.BinY) },
+		func() error { return c.SetSubFrameWidthContext(ctx, numX) },
+		func() error { return c.SetSubFrameHeightContext(ctx, numY) },
+		func() error { return c.SetStartXContext(ctx, startX) },
+		func() error { return c.SetStartYContext(ctx, startY) },
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	binX, binY, gotStartX, gotStartY, gotNumX, gotNumY, err := c.getBinnedFrameContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if binX != frame.BinX || binY != frame.BinY || gotStartX != startX || gotStartY != startY || gotNumX != numX || gotNumY != numY {
+		return fmt.Errorf(
+			"%w: got binning %dx%d, start (%d, %d) and size %dx%d, wanted binning %dx%d, start (%d, %d) and size %dx%d",
+			ErrFrameMismatch,
+			binX, binY, gotStartX, gotStartY, gotNumX, gotNumY,
+			frame.BinX, frame.BinY, startX, startY, numX, numY,
+		)
+	}
+
+	return nil
+}
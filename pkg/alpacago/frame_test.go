@@ -0,0 +1,157 @@
+package alpacago
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+)
+
+func newFrameTestServer(t *testing.T) *alpacatest.Server {
+	return alpacatest.NewServer(t, map[string]string{
+		"camera/0/cameraxsize":      "4000",
+		"camera/0/cameraysize":      "3000",
+		"camera/0/maxbinx":          "4",
+		"camera/0/maxbiny":          "4",
+		"camera/0/canasymmetricbin": "false",
+		"camera/0/binx":             "1",
+		"camera/0/biny":             "1",
+		"camera/0/startx":           "0",
+		"camera/0/starty":           "0",
+		"camera/0/numx":             "4000",
+		"camera/0/numy":             "3000",
+	}).Strict()
+}
+
+func TestFrameValidate(t *testing.T) {
+	limits := FrameLimits{CCDSizeX: 4000, CCDSizeY: 3000, MaxBinX: 4, MaxBinY: 4}
+
+	tests := []struct {
+		name  string
+		frame Frame
+		valid bool
+	}{
+		{"FullFrame", NewFullFrame(4000, 3000, 1, 1), true},
+		{"Subframe", Frame{X: 100, Y: 200, Width: 1000, Height: 800, BinX: 2, BinY: 2}, true},
+		{"Binning", NewFullFrame(4000, 3000, 5, 5), false},
+		{"Asymmetric", NewFullFrame(4000, 3000, 1, 2), false},
+		{"Outside", Frame{X: 3500, Y: 0, Width: 1000, Height: 100, BinX: 1, BinY: 1}, false},
+		{"Negative", Frame{X: -1, Y: 0, Width: 100, Height: 100, BinX: 1, BinY: 1}, false},
+		{"Empty", Frame{Width: 0, Height: 100, BinX: 1, BinY: 1}, false},
+		{"Small", Frame{Width: 3, Height: 3, BinX: 4, BinY: 4}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.frame.Validate(limits)
+
+			if tt.valid && err != nil {
+				t.Errorf("got %q, wanted nil", err)
+			}
+
+			if !tt.valid && !errors.Is(err, ErrInvalidFrame) {
+				t.Errorf("got %v, wanted %v", err, ErrInvalidFrame)
+			}
+		})
+	}
+}
+
+func TestFrameBinned(t *testing.T) {
+	tests := []struct {
+		name                       string
+		frame                      Frame
+		startX, startY, numX, numY int32
+	}{
+		{"Aligned", Frame{X: 100, Y: 200, Width: 1000, Height: 800, BinX: 2, BinY: 2}, 50, 100, 500, 400},
+		// The start is rounded up, so that the binned frame lies within the frame:
+		{"Unaligned", Frame{X: 101, Y: 200, Width: 1001, Height: 800, BinX: 2, BinY: 2}, 51, 100, 500, 400},
+		{"Trimmed", Frame{X: 1, Y: 2, Width: 10, Height: 9, BinX: 3, BinY: 3}, 1, 1, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startX, startY, numX, numY := tt.frame.Binned()
+
+			if startX != tt.startX || startY != tt.startY || numX != tt.numX || numY != tt.numY {
+				t.Errorf("got (%d, %d) %dx%d, wanted (%d, %d) %dx%d", startX, startY, numX, numY, tt.startX, tt.startY, tt.numX, tt.numY)
+			}
+
+			// The binned frame lies within the frame:
+			if startX*tt.frame.BinX < tt.frame.X || (startX+numX)*tt.frame.BinX > tt.frame.X+tt.frame.Width {
+				t.Errorf("got columns %d to %d, wanted within %d to %d", startX*tt.frame.BinX, (startX+numX)*tt.frame.BinX, tt.frame.X, tt.frame.X+tt.frame.Width)
+			}
+		})
+	}
+}
+
+func TestCameraSetFrame(t *testing.T) {
+	ts := newFrameTestServer(t)
+
+	c := NewCameraWithOptions(ts.URL, 0)
+
+	frame := Frame{X: 100, Y: 200, Width: 1000, Height: 800, BinX: 2, BinY: 2}
+
+	if err := c.SetFrame(frame); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	want := []string{
+		"camera/0/startx?StartX=0",
+		"camera/0/starty?StartY=0",
+		"camera/0/binx?BinX=2",
+		"camera/0/biny?BinY=2",
+		"camera/0/numx?NumX=500",
+		"camera/0/numy?NumY=400",
+		"camera/0/startx?StartX=50",
+		"camera/0/starty?StartY=100",
+	}
+
+	if got := ts.Puts(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+
+	got, err := c.GetFrame()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got != frame {
+		t.Errorf("got %v, wanted %v", got, frame)
+	}
+}
+
+func TestCameraSetFrameInvalid(t *testing.T) {
+	ts := newFrameTestServer(t)
+
+	err := NewCameraWithOptions(ts.URL, 0).SetFrame(NewFullFrame(4000, 3000, 2, 1))
+
+	if !errors.Is(err, ErrInvalidFrame) {
+		t.Errorf("got %v, wanted %v", err, ErrInvalidFrame)
+	}
+
+	if got := ts.Puts(); len(got) != 0 {
+		t.Errorf("got %v, wanted no settings to be changed", got)
+	}
+}
+
+func TestCameraSetFrameMismatch(t *testing.T) {
+	ts := newFrameTestServer(t)
+
+	// The camera ignores the width of the frame, to simulate a driver that silently clamps a value:
+	ts.HandleFunc("camera/0/numx", func(member string, r *http.Request) alpacatest.Response {
+		if r.Method == http.MethodPut {
+			return alpacatest.Value("null")
+		}
+
+		return alpacatest.Value("4000")
+	})
+
+	err := NewCameraWithOptions(ts.URL, 0).SetFrame(Frame{Width: 1000, Height: 1000, BinX: 1, BinY: 1})
+
+	if !errors.Is(err, ErrFrameMismatch) {
+		t.Errorf("got %v, wanted %v", err, ErrFrameMismatch)
+	}
+}
@@ -0,0 +1,354 @@
+package alpacago
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+/*
+Image
+
+An image downloaded from a camera, with the pixels held in a single flat slice. Pixels are stored plane by
+plane, each plane row by row, so that the pixel at (x, y) of plane p is at Pix[(p*Height+y)*Width+x]. A rank 2
+(monochrome or Bayered) image has a single plane, a rank 3 (colour or multi-plane) image has Planes planes.
+
+ElementType is the element type of the image as reported by the camera, regardless of how it was transmitted.
+The pixels are held as float64, which holds every value of the 8, 16 and 32 bit integer and the Single and Double
+element types exactly.
+*/
+type Image struct {
+	ElementType ImageArrayElementType
+	Rank        int
+	Width       int
+	Height      int
+	Planes      int
+	Pix         []float64
+}
+
+/*
+NewImage()
+
+@returns a new zeroed image of the given dimensions, of rank 2 if it has a single plane, otherwise of rank 3.
+*/
+func NewImage(elementType ImageArrayElementType, width int, height int, planes int) *Image {
+	rank := 3
+
+	if planes == 1 {
+		rank = 2
+	}
+
+	return &Image{
+		ElementType: elementType,
+		Rank:        rank,
+		Width:       width,
+		Height:      height,
+		Planes:      planes,
+		Pix:         make([]float64, width*height*planes),
+	}
+}
+
+/*
+PixOffset()
+
+@returns the index into Pix of the pixel at (x, y) of the given plane.
+*/
+func (i *Image) PixOffset(x int, y int, plane int) int {
+	return (plane*i.Height+y)*i.Width + x
+}
+
+/*
+At()
+
+@returns the value of the pixel at (x, y) of the given plane.
+*/
+func (i *Image) At(x int, y int, plane int) float64 {
+	return i.Pix[i.PixOffset(x, y, plane)]
+}
+
+/*
+Set()
+
+Sets the value of the pixel at (x, y) of the given plane.
+*/
+func (i *Image) Set(x int, y int, plane int, value float64) {
+	i.Pix[i.PixOffset(x, y, plane)] = value
+}
+
+/*
+Plane()
+
+@returns the pixels of the given plane, as a sub-slice of Pix, row by row.
+*/
+func (i *Image) Plane(plane int) []float64 {
+	n := i.Width * i.Height
+
+	return i.Pix[plane*n : (plane+1)*n : (plane+1)*n]
+}
+
+/*
+UInt32Array()
+
+@returns the first plane of the image as an array indexed [x][y], as returned by GetExposure(), with
+negative values clamped to zero and fractional values rounded.
+*/
+func (i *Image) UInt32Array() [][]uint32 {
+	array := newUInt32Array(i.Width, i.Height)
+
+	for x := range array {
+		for y := range array[x] {
+			array[x][y] = clampUInt32(i.At(x, y, 0))
+		}
+	}
+
+	return array
+}
+
+type imageArrayResponse struct {
+	Type  ImageArrayElementType `json:"Type"`
+	Rank  int                   `json:"Rank"`
+	Value json.RawMessage       `json:"Value"`
+	alpacaResponse
+}
+
+/*
+decodeImageArray()
+
+@returns the image from the Value of a JSON imagearray response, which is indexed [x][y] for rank 2 images
+and [x][y][plane] for rank 3 images.
+*/
+func decodeImageArray(result *imageArrayResponse) (*Image, error) {
+	switch result.Rank {
+	case 2:
+		var value [][]float64
+
+		if err := json.Unmarshal(result.Value, &value); err != nil {
+			return nil, err
+		}
+
+		w, h := len(value), 0
+
+		if w > 0 {
+			h = len(value[0])
+		}
+
+		image := NewImage(result.Type, w, h, 1)
+
+		for x := range value {
+			if len(value[x]) != h {
+				return nil, fmt.Errorf("imagearray: column %d has %d pixels, expected %d", x, len(value[x]), h)
+			}
+
+			for y, v := range value[x] {
+				image.Pix[y*w+x] = v
+			}
+		}
+
+		return image, nil
+	case 3:
+		var value [][][]float64
+
+		if err := json.Unmarshal(result.Value, &value); err != nil {
+			return nil, err
+		}
+
+		w, h, planes := len(value), 0, 0
+
+		if w > 0 && len(value[0]) > 0 {
+			h, planes = len(value[0]), len(value[0][0])
+		}
+
+		image := NewImage(result.Type, w, h, planes)
+
+		for x := range value {
+			if len(value[x]) != h {
+				return nil, fmt.Errorf("imagearray: column %d has %d pixels, expected %d", x, len(value[x]), h)
+			}
+
+			for y := range value[x] {
+				if len(value[x][y]) != planes {
+					return nil, fmt.Errorf("imagearray: pixel (%d, %d) has %d planes, expected %d", x, y, len(value[x][y]), planes)
+				}
+
+				for p, v := range value[x][y] {
+					image.Pix[image.PixOffset(x, y, p)] = v
+				}
+			}
+		}
+
+		return image, nil
+	default:
+		return nil, fmt.Errorf("imagearray: unsupported rank %d", result.Rank)
+	}
+}
+
+/*
+imageBytesElementReader()
+
+@returns a function which reads a single little-endian element of the given transmission element type, or
+nil if the type is unknown.
+*/
+func imageBytesElementReader(t ImageArrayElementType) func(b []byte) float64 {
+	switch t {
+	case ImageElementByte:
+		return func(b []byte) float64 {
+			return float64(b[0])
+		}
+	case ImageElementInt16:
+		return func(b []byte) float64 {
+			return float64(int16(binary.LittleEndian.Uint16(b)))
+		}
+	case ImageElementUInt16:
+		return func(b []byte) float64 {
+			return float64(binary.LittleEndian.Uint16(b))
+		}
+	case ImageElementInt32:
+		return func(b []byte) float64 {
+			return float64(int32(binary.LittleEndian.Uint32(b)))
+		}
+	case ImageElementSingle:
+		return func(b []byte) float64 {
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		}
+	case ImageElementInt64:
+		return func(b []byte) float64 {
+			return float64(int64(binary.LittleEndian.Uint64(b)))
+		}
+	case ImageElementUInt64:
+		return func(b []byte) float64 {
+			return float64(binary.LittleEndian.Uint64(b))
+		}
+	case ImageElementDouble:
+		return func(b []byte) float64 {
+			return math.Float64frombits(binary.LittleEndian.Uint64(b))
+		}
+	default:
+		return nil
+	}
+}
+
+/*
+decodeImageBytes()
+
+@returns the image from the data of an ImageBytes response, which is ordered [x][y] for rank 2 images and
+[x][y][plane] for rank 3 images, with the last index varying fastest.
+*/
+func decodeImageBytes(metadata *ImageBytesMetadata, data []byte) (*Image, error) {
+	read := imageBytesElementReader(metadata.TransmissionElementType)
+
+	if read == nil {
+		return nil, fmt.Errorf("imagebytes: unsupported transmission element type %s", metadata.TransmissionElementType)
+	}
+
+	w, h, planes := int(metadata.Dimension1), int(metadata.Dimension2), 1
+
+	switch metadata.Rank {
+	case 2:
+	case 3:
+		planes = int(metadata.Dimension3)
+	default:
+		return nil, fmt.Errorf("imagebytes: unsupported rank %d", metadata.Rank)
+	}
+
+	size := metadata.TransmissionElementType.size()
+
+	if w < 0 || h < 0 || planes < 0 || len(data) < w*h*planes*size {
+		return nil, fmt.Errorf("imagebytes: %d bytes of data is too short for a %dx%dx%d %s image", len(data), w, h, planes, metadata.TransmissionElementType)
+	}
+
+	image := NewImage(metadata.ImageElementType, w, h, planes)
+
+	i := 0
+
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			for p := 0; p < planes; p++ {
+				image.Pix[image.PixOffset(x, y, p)] = read(data[i:])
+				i += size
+			}
+		}
+	}
+
+	return image, nil
+}
+
+// newUInt32Array returns a zeroed array indexed [x][y], backed by a single slice:
+func newUInt32Array(w int, h int) [][]uint32 {
+	pixels := make([]uint32, w*h)
+
+	array := make([][]uint32, w)
+
+	for x := range array {
+		array[x] = pixels[x*h : (x+1)*h : (x+1)*h]
+	}
+
+	return array
+}
+
+/*
+GetImageArrayResponse()
+
+Global public method to work with calls returning an image array of any element type and of rank 2 or 3. The
+image is requested with the Alpaca ImageBytes binary transfer protocol, and if the server does not support
+ImageBytes, the JSON image array in the response is decoded instead.
+
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__imagearray
+@see https://ascom-standards.org/Developer/AlpacaImageBytes.pdf
+*/
+func (a *ASCOMAlpacaAPIClient) GetImageArrayResponse(deviceType string, deviceNumber uint, method string) (*Image, error) {
+	return a.GetImageArrayResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
+
+/*
+GetImageArrayResponseContext()
+
+GetImageArrayResponse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetImageArrayResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) (*Image, error) {
+	transactionId := a.nextTransactionId()
+
+	result := &imageArrayResponse{}
+
+	// Prefer ImageBytes, but accept JSON from servers which do not support it:
+	resp, err := a.Client.R().SetContext(ctx).SetResult(result).SetQueryString(a.getTransactionQueryString(transactionId)).SetHeader("Accept", IMAGEBYTES_MEDIA_TYPE+", application/json").Get(a.getEndpoint(deviceType, deviceNumber, method))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		a.setError(resp.StatusCode(), resp.String())
+		return nil, &HTTPError{StatusCode: resp.StatusCode(), Message: resp.String()}
+	}
+
+	if !strings.HasPrefix(resp.Header().Get("Content-Type"), IMAGEBYTES_MEDIA_TYPE) {
+		if err := verifyResponse(transactionId, result.response()); err != nil {
+			return nil, err
+		}
+
+		return decodeImageArray(result)
+	}
+
+	body := resp.Body()
+
+	metadata, err := parseImageBytesMetadata(body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// On error, the data following the metadata is the UTF-8 encoded error message:
+	if err := verifyResponse(transactionId, &alpacaResponse{
+		ClientTransactionID: metadata.ClientTransactionID,
+		ServerTransactionID: metadata.ServerTransactionID,
+		ErrorNumber:         metadata.ErrorNumber,
+		ErrorMessage:        string(body[metadata.DataStart:]),
+	}); err != nil {
+		return nil, err
+	}
+
+	return decodeImageBytes(metadata, body[metadata.DataStart:])
+}
@@ -0,0 +1,199 @@
+package alpacago
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestImagePixOffset(t *testing.T) {
+	image := NewImage(ImageElementInt32, 4, 3, 3)
+
+	image.Set(1, 2, 2, 42)
+
+	var got int = image.PixOffset(1, 2, 2)
+	var want int = (2*3+2)*4 + 1
+
+	if got != want {
+		t.Errorf("got %d, wanted %d", got, want)
+	}
+
+	if image.Plane(2)[2*4+1] != 42 {
+		t.Errorf("got %v, wanted the pixel to be in plane 2", image.Plane(2))
+	}
+
+	if image.Rank != 3 {
+		t.Errorf("got %d, wanted %d", image.Rank, 3)
+	}
+}
+
+func TestGetImageArrayResponseImageBytesRank3(t *testing.T) {
+	ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+		w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+		// A 2x1 image of three planes, ordered [x][y][plane]:
+		w.Write(encodeImageBytes(t, ImageBytesMetadata{
+			ClientTransactionID:     id,
+			ImageElementType:        ImageElementInt32,
+			TransmissionElementType: ImageElementUInt16,
+			Rank:                    3,
+			Dimension1:              2,
+			Dimension2:              1,
+			Dimension3:              3,
+		}, []uint16{1, 2, 3, 4, 5, 6}))
+	})
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0)
+
+	got, err := camera.GetImage()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.Rank != 3 || got.Width != 2 || got.Height != 1 || got.Planes != 3 || got.ElementType != ImageElementInt32 {
+		t.Fatalf("got %+v, wanted a 2x1 rank 3 Int32 image of three planes", got)
+	}
+
+	want := []float64{1, 4, 2, 5, 3, 6}
+
+	if fmt.Sprint(got.Pix) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got.Pix, want)
+	}
+}
+
+func TestGetImageArrayResponseImageBytesElementTypes(t *testing.T) {
+	tests := []struct {
+		transmission ImageArrayElementType
+		data         interface{}
+	}{
+		{ImageElementSingle, []float32{-1.5, 7, 255, 42}},
+		{ImageElementInt64, []int64{-3, 7, 255, 42}},
+		{ImageElementUInt64, []uint64{3, 7, 255, 42}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transmission.String(), func(t *testing.T) {
+			ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+				w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+				w.Write(encodeImageBytes(t, ImageBytesMetadata{
+					ClientTransactionID:     id,
+					ImageElementType:        tt.transmission,
+					TransmissionElementType: tt.transmission,
+					Rank:                    2,
+					Dimension1:              2,
+					Dimension2:              2,
+				}, tt.data))
+			})
+
+			defer ts.Close()
+
+			got, err := NewCameraWithOptions(ts.URL, 0).GetImage()
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			// The pixel at (1, 0) is the third element of the [x][y] ordered data:
+			if got.At(1, 0, 0) != 255 || got.At(1, 1, 0) != 42 || got.At(0, 1, 0) != 7 {
+				t.Errorf("got %v, wanted the pixels transposed into rows", got.Pix)
+			}
+		})
+	}
+}
+
+func TestGetImageArrayResponseExactPixels(t *testing.T) {
+	tests := []struct {
+		name         string
+		transmission ImageArrayElementType
+		data         interface{}
+		want         float64
+	}{
+		{"Int32", ImageElementInt32, []int32{16777217, 1, 2, 3}, 16777217},
+		{"Double", ImageElementDouble, []float64{16777217.25, 1, 2, 3}, 16777217.25},
+		{"JSON", ImageElementUnknown, nil, 16777217},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+				if tt.data == nil {
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprintf(w, `{"Type":2,"Rank":2,"Value":[[16777217,1],[2,3]],"ClientTransactionID":%d,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+					return
+				}
+
+				w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+				w.Write(encodeImageBytes(t, ImageBytesMetadata{
+					ClientTransactionID:     id,
+					ImageElementType:        tt.transmission,
+					TransmissionElementType: tt.transmission,
+					Rank:                    2,
+					Dimension1:              2,
+					Dimension2:              2,
+				}, tt.data))
+			})
+
+			defer ts.Close()
+
+			got, err := NewCameraWithOptions(ts.URL, 0).GetImage()
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if got.At(0, 0, 0) != tt.want {
+				t.Errorf("got %v, wanted %v", got.At(0, 0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func TestGetImageArrayResponseJSONRank3Double(t *testing.T) {
+	ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Type":3,"Rank":3,"Value":[[[0.5,1.5,2.5]],[[3.5,4.5,5.5]]],"ClientTransactionID":%d,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+	})
+
+	defer ts.Close()
+
+	got, err := NewCameraWithOptions(ts.URL, 0).GetImage()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.ElementType != ImageElementDouble || got.Rank != 3 || got.Planes != 3 {
+		t.Fatalf("got %+v, wanted a rank 3 Double image of three planes", got)
+	}
+
+	if got.At(1, 0, 2) != 5.5 || got.At(0, 0, 1) != 1.5 {
+		t.Errorf("got %v, wanted [0.5 3.5 1.5 4.5 2.5 5.5]", got.Pix)
+	}
+}
+
+func TestGetImageArrayResponseJSONRank2(t *testing.T) {
+	ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Type":1,"Rank":2,"Value":[[1,2,3],[4,5,6]],"ClientTransactionID":%d,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+	})
+
+	defer ts.Close()
+
+	got, err := NewCameraWithOptions(ts.URL, 0).GetImage()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.Width != 2 || got.Height != 3 || got.ElementType != ImageElementInt16 {
+		t.Fatalf("got %+v, wanted a 2x3 Int16 image", got)
+	}
+
+	want := []float64{1, 4, 2, 5, 3, 6}
+
+	if fmt.Sprint(got.Pix) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got.Pix, want)
+	}
+}
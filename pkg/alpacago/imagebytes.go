@@ -0,0 +1,154 @@
+package alpacago
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// The media type of the Alpaca ImageBytes binary image transfer protocol:
+const IMAGEBYTES_MEDIA_TYPE = "application/imagebytes"
+
+// The length, in bytes, of the version 1 ImageBytes metadata header:
+const IMAGEBYTES_METADATA_LENGTH = 44
+
+type ImageArrayElementType int32
+
+const (
+	ImageElementUnknown ImageArrayElementType = iota
+	ImageElementInt16
+	ImageElementInt32
+	ImageElementDouble
+	ImageElementSingle
+	ImageElementUInt64
+	ImageElementByte
+	ImageElementInt64
+	ImageElementUInt16
+)
+
+func (t ImageArrayElementType) String() string {
+	name := []string{"Unknown", "Int16", "Int32", "Double", "Single", "UInt64", "Byte", "Int64", "UInt16"}
+
+	i := uint8(t)
+
+	switch {
+	case i <= uint8(ImageElementUInt16):
+		return name[i]
+	default:
+		return strconv.Itoa(int(i))
+	}
+}
+
+/*
+size()
+
+@returns the size, in bytes, of a single element of the type, or 0 if the type is unknown.
+*/
+func (t ImageArrayElementType) size() int {
+	switch t {
+	case ImageElementByte:
+		return 1
+	case ImageElementInt16, ImageElementUInt16:
+		return 2
+	case ImageElementInt32, ImageElementSingle:
+		return 4
+	case ImageElementDouble, ImageElementInt64, ImageElementUInt64:
+		return 8
+	default:
+		return 0
+	}
+}
+
+/*
+ImageBytesMetadata
+
+The little-endian metadata header which precedes the image data of an ImageBytes response.
+@see https://ascom-standards.org/Developer/AlpacaImageBytes.pdf
+*/
+type ImageBytesMetadata struct {
+	MetadataVersion         int32
+	ErrorNumber             int32
+	ClientTransactionID     uint32
+	ServerTransactionID     uint32
+	DataStart               int32
+	ImageElementType        ImageArrayElementType
+	TransmissionElementType ImageArrayElementType
+	Rank                    int32
+	Dimension1              int32
+	Dimension2              int32
+	Dimension3              int32
+}
+
+/*
+parseImageBytesMetadata()
+
+@returns the metadata header of an ImageBytes response, or an error if the header is truncated or of an
+unsupported version.
+*/
+func parseImageBytesMetadata(b []byte) (*ImageBytesMetadata, error) {
+	if len(b) < IMAGEBYTES_METADATA_LENGTH {
+		return nil, fmt.Errorf("imagebytes: response of %d bytes is shorter than the metadata header", len(b))
+	}
+
+	metadata := ImageBytesMetadata{}
+
+	if err := binary.Read(bytes.NewReader(b[:IMAGEBYTES_METADATA_LENGTH]), binary.LittleEndian, &metadata); err != nil {
+		return nil, err
+	}
+
+	if metadata.MetadataVersion != 1 {
+		return nil, fmt.Errorf("imagebytes: unsupported metadata version %d", metadata.MetadataVersion)
+	}
+
+	if metadata.DataStart < IMAGEBYTES_METADATA_LENGTH || int(metadata.DataStart) > len(b) {
+		return nil, fmt.Errorf("imagebytes: data start %d is outside of the response", metadata.DataStart)
+	}
+
+	return &metadata, nil
+}
+
+/*
+GetImageBytesResponse()
+
+Global public method to work with calls returning a rank 2 image array, requested with the Alpaca ImageBytes
+binary transfer protocol, see GetImageArrayResponse().
+
+@returns the image array indexed [x][y], and the rank of the image.
+@see https://ascom-standards.org/Developer/AlpacaImageBytes.pdf
+*/
+func (a *ASCOMAlpacaAPIClient) GetImageBytesResponse(deviceType string, deviceNumber uint, method string) ([][]uint32, uint32, error) {
+	return a.GetImageBytesResponseContext(context.Background(), deviceType, deviceNumber, method)
+}
+
+/*
+GetImageBytesResponseContext()
+
+GetImageBytesResponse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (a *ASCOMAlpacaAPIClient) GetImageBytesResponseContext(ctx context.Context, deviceType string, deviceNumber uint, method string) ([][]uint32, uint32, error) {
+	image, err := a.GetImageArrayResponseContext(ctx, deviceType, deviceNumber, method)
+
+	if err != nil {
+		return [][]uint32{}, 0, err
+	}
+
+	if image.Rank != 2 {
+		return [][]uint32{}, 0, fmt.Errorf("imagebytes: unsupported rank %d, expected 2", image.Rank)
+	}
+
+	return image.UInt32Array(), uint32(image.Rank), nil
+}
+
+func clampUInt32(v float64) uint32 {
+	switch {
+	case v <= 0 || math.IsNaN(v):
+		return 0
+	case v >= math.MaxUint32:
+		return math.MaxUint32
+	default:
+		return uint32(math.Round(v))
+	}
+}
@@ -0,0 +1,253 @@
+package alpacago
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func encodeImageBytes(t *testing.T, metadata ImageBytesMetadata, data interface{}) []byte {
+	buf := new(bytes.Buffer)
+
+	metadata.MetadataVersion = 1
+	metadata.DataStart = IMAGEBYTES_METADATA_LENGTH
+
+	if err := binary.Write(buf, binary.LittleEndian, metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, ok := data.(string); ok {
+		buf.WriteString(s)
+	} else if err := binary.Write(buf, binary.LittleEndian, data); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func newImageBytesTestServer(t *testing.T, respond func(w http.ResponseWriter, r *http.Request, id uint32)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseUint(r.FormValue("ClientTransactionID"), 10, 32)
+		respond(w, r, uint32(id))
+	}))
+}
+
+func TestGetImageBytesResponseUInt16(t *testing.T) {
+	ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+		if r.Header.Get("Accept") != IMAGEBYTES_MEDIA_TYPE+", application/json" {
+			t.Errorf("got %q, wanted the imagebytes media type to be accepted", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+		w.Write(encodeImageBytes(t, ImageBytesMetadata{
+			ClientTransactionID:     id,
+			ImageElementType:        ImageElementInt32,
+			TransmissionElementType: ImageElementUInt16,
+			Rank:                    2,
+			Dimension1:              3,
+			Dimension2:              2,
+		}, []uint16{0, 1, 10, 11, 65535, 21}))
+	})
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0, WithClientID(65535))
+
+	got, rank, err := camera.GetExposure()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if rank != 2 {
+		t.Errorf("got %d, wanted %d", rank, 2)
+	}
+
+	want := [][]uint32{{0, 1}, {10, 11}, {65535, 21}}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestGetImageBytesResponseElementTypes(t *testing.T) {
+	tests := []struct {
+		transmission ImageArrayElementType
+		data         interface{}
+	}{
+		{ImageElementByte, []uint8{0, 7, 255, 42}},
+		{ImageElementInt16, []int16{-5, 7, 255, 42}},
+		{ImageElementInt32, []int32{0, 7, 255, 42}},
+		{ImageElementDouble, []float64{-1.5, 6.6, 255, 42.2}},
+	}
+
+	want := [][]uint32{{0, 7}, {255, 42}}
+
+	for _, tt := range tests {
+		t.Run(tt.transmission.String(), func(t *testing.T) {
+			ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+				w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+				w.Write(encodeImageBytes(t, ImageBytesMetadata{
+					ClientTransactionID:     id,
+					ImageElementType:        ImageElementInt32,
+					TransmissionElementType: tt.transmission,
+					Rank:                    2,
+					Dimension1:              2,
+					Dimension2:              2,
+				}, tt.data))
+			})
+
+			defer ts.Close()
+
+			camera := NewCameraWithOptions(ts.URL, 0)
+
+			got, _, err := camera.GetExposure()
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("got %v, wanted %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGetImageBytesResponseError(t *testing.T) {
+	ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+		w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+		w.Write(encodeImageBytes(t, ImageBytesMetadata{
+			ClientTransactionID: id,
+			ErrorNumber:         0x40B,
+			Rank:                0,
+		}, "No image is available"))
+	})
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0)
+
+	_, _, err := camera.GetExposure()
+
+	if !errors.Is(err, ErrInvalidOperation) {
+		t.Fatalf("got %v, wanted %v", err, ErrInvalidOperation)
+	}
+
+	var alpacaErr *AlpacaError
+
+	if !errors.As(err, &alpacaErr) || alpacaErr.ErrorMessage != "No image is available" {
+		t.Errorf("got %v, wanted the error message from the image data", err)
+	}
+}
+
+func TestGetImageBytesResponseJSONFallback(t *testing.T) {
+	ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Type":2,"Rank":2,"Value":[[1,2],[3,4]],"ClientTransactionID":%d,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+	})
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0)
+
+	got, rank, err := camera.GetExposure()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if rank != 2 || fmt.Sprint(got) != fmt.Sprint([][]uint32{{1, 2}, {3, 4}}) {
+		t.Errorf("got %v (rank %d), wanted [[1 2] [3 4]] (rank 2)", got, rank)
+	}
+}
+
+func TestGetImageBytesResponseTruncated(t *testing.T) {
+	ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+		w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+		w.Write(encodeImageBytes(t, ImageBytesMetadata{
+			ClientTransactionID:     id,
+			TransmissionElementType: ImageElementUInt16,
+			Rank:                    2,
+			Dimension1:              100,
+			Dimension2:              100,
+		}, []uint16{1, 2, 3}))
+	})
+
+	defer ts.Close()
+
+	camera := NewCameraWithOptions(ts.URL, 0)
+
+	if _, _, err := camera.GetExposure(); err == nil {
+		t.Errorf("got nil, wanted an error for the truncated image data")
+	}
+}
+
+func TestGetImageBytesResponseExactIntegers(t *testing.T) {
+	tests := []struct {
+		transmission ImageArrayElementType
+		data         interface{}
+	}{
+		{ImageElementInt32, []int32{16777217, 1, 2147483647, 3}},
+		{ImageElementInt64, []int64{16777217, 1, 2147483647, 3}},
+		{ImageElementUInt64, []uint64{16777217, 1, 2147483647, 3}},
+	}
+
+	want := [][]uint32{{16777217, 1}, {2147483647, 3}}
+
+	for _, tt := range tests {
+		t.Run(tt.transmission.String(), func(t *testing.T) {
+			ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+				w.Header().Set("Content-Type", IMAGEBYTES_MEDIA_TYPE)
+				w.Write(encodeImageBytes(t, ImageBytesMetadata{
+					ClientTransactionID:     id,
+					ImageElementType:        tt.transmission,
+					TransmissionElementType: tt.transmission,
+					Rank:                    2,
+					Dimension1:              2,
+					Dimension2:              2,
+				}, tt.data))
+			})
+
+			defer ts.Close()
+
+			camera := NewCameraWithOptions(ts.URL, 0)
+
+			got, _, err := camera.GetExposure()
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("got %v, wanted %v", got, want)
+			}
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		ts := newImageBytesTestServer(t, func(w http.ResponseWriter, r *http.Request, id uint32) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"Type":2,"Rank":2,"Value":[[16777217,1],[2147483647,3]],"ClientTransactionID":%d,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+		})
+
+		defer ts.Close()
+
+		camera := NewCameraWithOptions(ts.URL, 0)
+
+		got, _, err := camera.GetExposure()
+
+		if err != nil {
+			t.Fatalf("got %q", err)
+		}
+
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("got %v, wanted %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package alpacago
+
+import (
+	"context"
+	"strings"
+)
+
+/*
+ServerDescription
+
+@see https://ascom-standards.org/api/#/Management%20Interface%20(JSON)/get_management_v1_description
+*/
+type ServerDescription struct {
+	ServerName          string `json:"ServerName"`
+	Manufacturer        string `json:"Manufacturer"`
+	ManufacturerVersion string `json:"ManufacturerVersion"`
+	Location            string `json:"Location"`
+}
+
+/*
+ConfiguredDevice
+
+@see https://ascom-standards.org/api/#/Management%20Interface%20(JSON)/get_management_v1_configureddevices
+*/
+type ConfiguredDevice struct {
+	DeviceName   string `json:"DeviceName"`
+	DeviceType   string `json:"DeviceType"`
+	DeviceNumber uint   `json:"DeviceNumber"`
+	UniqueID     string `json:"UniqueID"`
+}
+
+type serverDescriptionResponse struct {
+	Value ServerDescription `json:"Value"`
+	alpacaResponse
+}
+
+type configuredDevicesResponse struct {
+	Value []ConfiguredDevice `json:"Value"`
+	alpacaResponse
+}
+
+/*
+Management
+
+A client for the ASCOM Alpaca Management API, which describes the Alpaca server itself and the
+devices that it hosts.
+*/
+type Management struct {
+	Alpaca *ASCOMAlpacaAPIClient
+}
+
+func NewManagement(clientId uint32, secure bool, domain string, ip string, port int32) *Management {
+	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
+
+	management := Management{
+		Alpaca: alpaca,
+	}
+
+	return &management
+}
+
+func NewManagementWithOptions(urlBase string, opts ...ClientOption) *Management {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	management := Management{
+		Alpaca: alpaca,
+	}
+
+	return &management
+}
+
+/*
+GetAPIVersions()
+
+@returns the list of supported Alpaca API version numbers, e.g., [1].
+@see https://ascom-standards.org/api/#/Management%20Interface%20(JSON)/get_management_apiversions
+*/
+func (m *Management) GetAPIVersions() ([]uint32, error) {
+	return m.GetAPIVersionsContext(context.Background())
+}
+
+/*
+GetAPIVersionsContext()
+
+GetAPIVersions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *Management) GetAPIVersionsContext(ctx context.Context) ([]uint32, error) {
+	result := &uint32listResponse{}
+
+	if err := m.Alpaca.getURL(ctx, m.Alpaca.getManagementEndpoint("apiversions"), nil, result); err != nil {
+		return []uint32{}, err
+	}
+
+	return result.Value, nil
+}
+
+/*
+GetDescription()
+
+@returns the server name, manufacturer, manufacturer version and location of the Alpaca server.
+@see https://ascom-standards.org/api/#/Management%20Interface%20(JSON)/get_management_v1_description
+*/
+func (m *Management) GetDescription() (*ServerDescription, error) {
+	return m.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *Management) GetDescriptionContext(ctx context.Context) (*ServerDescription, error) {
+	result := &serverDescriptionResponse{}
+
+	if err := m.Alpaca.getURL(ctx, m.Alpaca.getManagementEndpoint("v1/description"), nil, result); err != nil {
+		return nil, err
+	}
+
+	return &result.Value, nil
+}
+
+/*
+GetConfiguredDevices()
+
+@returns the list of devices configured on the Alpaca server, with their name, type, number and unique ID.
+@see https://ascom-standards.org/api/#/Management%20Interface%20(JSON)/get_management_v1_configureddevices
+*/
+func (m *Management) GetConfiguredDevices() ([]ConfiguredDevice, error) {
+	return m.GetConfiguredDevicesContext(context.Background())
+}
+
+/*
+GetConfiguredDevicesContext()
+
+GetConfiguredDevices() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *Management) GetConfiguredDevicesContext(ctx context.Context) ([]ConfiguredDevice, error) {
+	result := &configuredDevicesResponse{}
+
+	if err := m.Alpaca.getURL(ctx, m.Alpaca.getManagementEndpoint("v1/configureddevices"), nil, result); err != nil {
+		return []ConfiguredDevice{}, err
+	}
+
+	return result.Value, nil
+}
+
+/*
+Devices
+
+The ready-to-use device handles for each of the devices configured on an Alpaca server, grouped by device
+type. Devices of a type unknown to this library are collected in Unknown.
+*/
+type Devices struct {
+	Cameras             []*Camera
+	CoverCalibrators    []*CoverCalibrator
+	Domes               []*Dome
+	FilterWheels        []*FilterWheel
+	Focusers            []*Focuser
+	ObservingConditions []*ObservingConditions
+	Rotators            []*Rotator
+	SafetyMonitors      []*SafetyMonitor
+	Switches            []*Switch
+	Telescopes          []*Telescope
+	Unknown             []ConfiguredDevice
+}
+
+/*
+Management()
+
+@returns a Management API client sharing the server's client.
+*/
+func (s *AlpacaServer) Management() *Management {
+	return &Management{
+		Alpaca: s.Alpaca,
+	}
+}
+
+/*
+NewDevices()
+
+@returns the device handles, sharing the server's client, for each of the given configured devices.
+*/
+func (s *AlpacaServer) NewDevices(configured []ConfiguredDevice) *Devices {
+	devices := Devices{}
+
+	for _, d := range configured {
+		switch strings.ToLower(d.DeviceType) {
+		case "camera":
+			devices.Cameras = append(devices.Cameras, s.Camera(d.DeviceNumber))
+		case "covercalibrator":
+			devices.CoverCalibrators = append(devices.CoverCalibrators, s.CoverCalibrator(d.DeviceNumber))
+		case "dome":
+			devices.Domes = append(devices.Domes, s.Dome(d.DeviceNumber))
+		case "filterwheel":
+			devices.FilterWheels = append(devices.FilterWheels, s.FilterWheel(d.DeviceNumber))
+		case "focuser":
+			devices.Focusers = append(devices.Focusers, s.Focuser(d.DeviceNumber))
+		case "observingconditions":
+			devices.ObservingConditions = append(devices.ObservingConditions, s.ObservingConditions(d.DeviceNumber))
+		case "rotator":
+			devices.Rotators = append(devices.Rotators, s.Rotator(d.DeviceNumber))
+		case "safetymonitor":
+			devices.SafetyMonitors = append(devices.SafetyMonitors, s.SafetyMonitor(d.DeviceNumber))
+		case "switch":
+			devices.Switches = append(devices.Switches, s.Switch(d.DeviceNumber))
+		case "telescope":
+			devices.Telescopes = append(devices.Telescopes, s.Telescope(d.DeviceNumber))
+		default:
+			devices.Unknown = append(devices.Unknown, d)
+		}
+	}
+
+	return &devices
+}
+
+/*
+GetDevices()
+
+@returns the device handles for every device configured on the Alpaca server, as reported by the
+Management API, so that device numbers need not be hard-coded.
+*/
+func (s *AlpacaServer) GetDevices() (*Devices, error) {
+	return s.GetDevicesContext(context.Background())
+}
+
+/*
+GetDevicesContext()
+
+GetDevices() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *AlpacaServer) GetDevicesContext(ctx context.Context) (*Devices, error) {
+	configured, err := s.Management().GetConfiguredDevicesContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.NewDevices(configured), nil
+}
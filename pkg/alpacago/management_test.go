@@ -0,0 +1,139 @@
+package alpacago
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newManagementTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+
+	respond := func(w http.ResponseWriter, r *http.Request, value string) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":%s,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, value, r.FormValue("ClientTransactionID"))
+	}
+
+	mux.HandleFunc("/management/apiversions", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, `[1]`)
+	})
+
+	mux.HandleFunc("/management/v1/description", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, `{"ServerName":"Alpaca Simulators","Manufacturer":"ASCOM Initiative","ManufacturerVersion":"0.2.0","Location":"Mauna Kea"}`)
+	})
+
+	mux.HandleFunc("/management/v1/configureddevices", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, `[
+			{"DeviceName":"Alpaca Camera Sim","DeviceType":"Camera","DeviceNumber":0,"UniqueID":"b6b7a0e3"},
+			{"DeviceName":"Guide Camera Sim","DeviceType":"Camera","DeviceNumber":1,"UniqueID":"d8a1c2f4"},
+			{"DeviceName":"Alpaca Telescope Sim","DeviceType":"Telescope","DeviceNumber":0,"UniqueID":"0a1b2c3d"},
+			{"DeviceName":"Alpaca Filter Wheel Sim","DeviceType":"FilterWheel","DeviceNumber":0,"UniqueID":"9f8e7d6c"},
+			{"DeviceName":"Alpaca Cover Calibrator Sim","DeviceType":"CoverCalibrator","DeviceNumber":0,"UniqueID":"5a4b3c2d"},
+			{"DeviceName":"Spectrograph","DeviceType":"Spectrograph","DeviceNumber":0,"UniqueID":"11223344"}
+		]`)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestManagementGetAPIVersions(t *testing.T) {
+	ts := newManagementTestServer(t)
+
+	defer ts.Close()
+
+	management := NewManagementWithOptions(ts.URL, WithClientID(65535))
+
+	got, err := management.GetAPIVersions()
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("got %v, wanted %v", got, []uint32{1})
+	}
+}
+
+func TestManagementGetDescription(t *testing.T) {
+	ts := newManagementTestServer(t)
+
+	defer ts.Close()
+
+	management := NewManagementWithOptions(ts.URL, WithClientID(65535))
+
+	got, err := management.GetDescription()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	want := ServerDescription{
+		ServerName:          "Alpaca Simulators",
+		Manufacturer:        "ASCOM Initiative",
+		ManufacturerVersion: "0.2.0",
+		Location:            "Mauna Kea",
+	}
+
+	if *got != want {
+		t.Errorf("got %+v, wanted %+v", *got, want)
+	}
+}
+
+func TestManagementGetConfiguredDevices(t *testing.T) {
+	ts := newManagementTestServer(t)
+
+	defer ts.Close()
+
+	management := NewManagementWithOptions(ts.URL, WithClientID(65535))
+
+	got, err := management.GetConfiguredDevices()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(got) != 6 {
+		t.Fatalf("got %d devices, wanted %d", len(got), 6)
+	}
+
+	want := ConfiguredDevice{DeviceName: "Guide Camera Sim", DeviceType: "Camera", DeviceNumber: 1, UniqueID: "d8a1c2f4"}
+
+	if got[1] != want {
+		t.Errorf("got %+v, wanted %+v", got[1], want)
+	}
+}
+
+func TestAlpacaServerGetDevices(t *testing.T) {
+	ts := newManagementTestServer(t)
+
+	defer ts.Close()
+
+	server := NewAlpacaServer(ts.URL, WithClientID(65535))
+
+	devices, err := server.GetDevices()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(devices.Cameras) != 2 {
+		t.Fatalf("got %d cameras, wanted %d", len(devices.Cameras), 2)
+	}
+
+	if devices.Cameras[1].DeviceNumber != 1 {
+		t.Errorf("got %d, wanted %d", devices.Cameras[1].DeviceNumber, 1)
+	}
+
+	if devices.Cameras[0].Alpaca != server.Alpaca {
+		t.Errorf("got a distinct client, wanted the camera to share the server's client")
+	}
+
+	if len(devices.Telescopes) != 1 || len(devices.FilterWheels) != 1 || len(devices.CoverCalibrators) != 1 {
+		t.Errorf("got %+v, wanted one telescope, filter wheel and cover calibrator", devices)
+	}
+
+	if len(devices.Unknown) != 1 || devices.Unknown[0].DeviceType != "Spectrograph" {
+		t.Errorf("got %+v, wanted the spectrograph to be unknown", devices.Unknown)
+	}
+}
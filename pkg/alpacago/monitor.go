@@ -1,12 +1,27 @@
 package alpacago
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 type SafetyMonitor struct {
 	Alpaca       *ASCOMAlpacaAPIClient
 	DeviceNumber uint
 }
 
+/*
+SafetyMonitorDeviceState
+
+The operational properties of the safety monitor, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type SafetyMonitorDeviceState struct {
+	IsSafe    bool   `json:"IsSafe"`
+	TimeStamp string `json:"TimeStamp"`
+}
+
 func NewSafetyMonitor(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *SafetyMonitor {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -18,6 +33,17 @@ func NewSafetyMonitor(clientId uint32, secure bool, domain string, ip string, po
 	return &monitor
 }
 
+func NewSafetyMonitorWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *SafetyMonitor {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	monitor := SafetyMonitor{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &monitor
+}
+
 /*
 GetDescription() common method to all ASCOM Alpaca compliant devices
 
@@ -25,7 +51,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (m *SafetyMonitor) GetDescription() (string, error) {
-	return m.Alpaca.GetDescription("safetymonitor", m.DeviceNumber)
+	return m.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) GetDescriptionContext(ctx context.Context) (string, error) {
+	return m.Alpaca.GetDescriptionContext(ctx, "safetymonitor", m.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (m *SafetyMonitor) GetSupportedActions() ([]string, error) {
+	return m.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return m.Alpaca.GetSupportedActionsContext(ctx, "safetymonitor", m.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (m *SafetyMonitor) Action(action string, parameters string) (string, error) {
+	return m.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return m.Alpaca.ActionContext(ctx, "safetymonitor", m.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (m *SafetyMonitor) CommandBlind(command string, raw bool) error {
+	return m.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return m.Alpaca.CommandBlindContext(ctx, "safetymonitor", m.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (m *SafetyMonitor) CommandBool(command string, raw bool) (bool, error) {
+	return m.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return m.Alpaca.CommandBoolContext(ctx, "safetymonitor", m.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (m *SafetyMonitor) CommandString(command string, raw bool) (string, error) {
+	return m.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return m.Alpaca.CommandStringContext(ctx, "safetymonitor", m.DeviceNumber, command, raw)
 }
 
 /*
@@ -35,7 +173,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (m *SafetyMonitor) IsConnected() (bool, error) {
-	return m.Alpaca.GetBooleanResponse("safetymonitor", m.DeviceNumber, "connected")
+	return m.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) IsConnectedContext(ctx context.Context) (bool, error) {
+	return m.Alpaca.GetBooleanResponseContext(ctx, "safetymonitor", m.DeviceNumber, "connected")
 }
 
 /*
@@ -46,16 +193,145 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (m *SafetyMonitor) SetConnected(connected bool) error {
-	m.Alpaca.TransactionId++
+	return m.SetConnectedContext(context.Background(), connected)
+}
 
+/*
+SetConnectedContext()
+
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", m.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", m.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
 	}
 
-	return m.Alpaca.Put("safetymonitor", m.DeviceNumber, "connected", form)
+	return m.Alpaca.PutContext(ctx, "safetymonitor", m.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (m *SafetyMonitor) Connect() error {
+	return m.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) ConnectContext(ctx context.Context) error {
+	return m.Alpaca.ConnectContext(ctx, "safetymonitor", m.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (m *SafetyMonitor) Disconnect() error {
+	return m.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) DisconnectContext(ctx context.Context) error {
+	return m.Alpaca.DisconnectContext(ctx, "safetymonitor", m.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (m *SafetyMonitor) IsConnecting() (bool, error) {
+	return m.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) IsConnectingContext(ctx context.Context) (bool, error) {
+	return m.Alpaca.IsConnectingContext(ctx, "safetymonitor", m.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the safety monitor in a single round trip, see SafetyMonitorDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (m *SafetyMonitor) GetDeviceState() (*SafetyMonitorDeviceState, error) {
+	return m.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) GetDeviceStateContext(ctx context.Context) (*SafetyMonitorDeviceState, error) {
+	values, err := m.Alpaca.GetDeviceStateContext(ctx, "safetymonitor", m.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := SafetyMonitorDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the safety monitor from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (m *SafetyMonitor) GetDeviceStateWithFallback() (*SafetyMonitorDeviceState, error) {
+	return m.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) GetDeviceStateWithFallbackContext(ctx context.Context) (*SafetyMonitorDeviceState, error) {
+	state, err := m.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	state = &SafetyMonitorDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.IsSafe, err = m.IsSafeContext(ctx)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -65,5 +341,14 @@ IsSafe()
 @see https://ascom-standards.org/api/#/SafetyMonitor%20Specific%20Methods/get_safetymonitor__device_number__issafe
 */
 func (m *SafetyMonitor) IsSafe() (bool, error) {
-	return m.Alpaca.GetBooleanResponse("safetymonitor", m.DeviceNumber, "issafe")
+	return m.IsSafeContext(context.Background())
+}
+
+/*
+IsSafeContext()
+
+IsSafe() with a context, which controls the cancellation and deadline of the request.
+*/
+func (m *SafetyMonitor) IsSafeContext(ctx context.Context) (bool, error) {
+	return m.Alpaca.GetBooleanResponseContext(ctx, "safetymonitor", m.DeviceNumber, "issafe")
 }
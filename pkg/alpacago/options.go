@@ -0,0 +1,225 @@
+package alpacago
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// The default timeout applied to every request made by the client:
+const DEFAULT_TIMEOUT = 60 * time.Second
+
+type clientOptions struct {
+	clientId  uint32
+	tlsConfig *tls.Config
+	transport http.RoundTripper
+	timeout   time.Duration
+	basePath  string
+	username  string
+	password  string
+	token     string
+	logger    log.FieldLogger
+	retries   int
+	retryWait time.Duration
+	retryMax  time.Duration
+}
+
+/*
+ClientOption
+
+Configures the ASCOM Alpaca API client created by NewAlpacaAPIWithOptions, and by each of
+the New<Device>WithOptions device constructors.
+*/
+type ClientOption func(*clientOptions)
+
+/*
+WithClientID()
+
+@param clientId uint32 (the ClientID sent with every request to identify this client)
+*/
+func WithClientID(clientId uint32) ClientOption {
+	return func(o *clientOptions) {
+		o.clientId = clientId
+	}
+}
+
+/*
+WithTLSConfig()
+
+@param config *tls.Config (e.g., with RootCAs to trust a self-signed observatory certificate, or with
+Certificates to present a client certificate for mutual TLS)
+*/
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = config
+	}
+}
+
+/*
+WithTransport()
+
+@param transport http.RoundTripper (replaces the default transport of the underlying HTTP client, any
+WithTLSConfig option is applied to the transport if it is an *http.Transport)
+*/
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+/*
+WithTimeout()
+
+@param timeout time.Duration (the per-client timeout of every request, defaults to 60 seconds). A per-call
+timeout can be applied by passing a context created with context.WithTimeout to any Context method.
+*/
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+/*
+WithBasePath()
+
+@param path string (a path prefix for servers behind a reverse proxy, e.g., "/alpaca" for the endpoints
+of the form http(s)://host:port/alpaca/api/v1/...)
+*/
+func WithBasePath(path string) ClientOption {
+	return func(o *clientOptions) {
+		o.basePath = strings.TrimSuffix(path, "/")
+
+		if len(o.basePath) > 0 && !strings.HasPrefix(o.basePath, "/") {
+			o.basePath = "/" + o.basePath
+		}
+	}
+}
+
+/*
+WithBasicAuth()
+
+@param username string
+@param password string
+Sends a HTTP Basic Authorization header with every request.
+*/
+func WithBasicAuth(username string, password string) ClientOption {
+	return func(o *clientOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+/*
+WithBearerToken()
+
+@param token string
+Sends a HTTP Bearer Authorization header with every request.
+*/
+func WithBearerToken(token string) ClientOption {
+	return func(o *clientOptions) {
+		o.token = token
+	}
+}
+
+/*
+WithLogger()
+
+@param logger logrus.FieldLogger (the logger used by the client and the underlying HTTP client, defaults
+to the logrus standard logger)
+*/
+func WithLogger(logger log.FieldLogger) ClientOption {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+/*
+WithRetryPolicy()
+
+Only GET requests are retried. A PUT which fails with a transport error may still have reached the device, and
+so is never retried, as repeating it could repeat an action, e.g., a slew, a guide pulse or an exposure.
+
+@param retries int (the number of times a GET request is retried after a transport error, e.g., a dropped connection)
+@param wait time.Duration (the initial wait between retries, which backs off exponentially)
+@param maxWait time.Duration (the maximum wait between retries)
+*/
+func WithRetryPolicy(retries int, wait time.Duration, maxWait time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.retries = retries
+		o.retryWait = wait
+		o.retryMax = maxWait
+	}
+}
+
+/*
+retryGetRequests()
+
+@returns true if the request is a GET which failed with a transport error, see WithRetryPolicy().
+*/
+func retryGetRequests(r *resty.Response, err error) bool {
+	return err != nil && r != nil && r.Request.Method == http.MethodGet
+}
+
+/*
+NewAlpacaAPIWithOptions()
+
+@param urlBase string (the scheme, host and port of the Alpaca server, e.g., https://observatory.local:11111)
+@returns a new ASCOM Alpaca API client configured by the given options.
+*/
+func NewAlpacaAPIWithOptions(urlBase string, opts ...ClientOption) *ASCOMAlpacaAPIClient {
+	o := clientOptions{
+		timeout: DEFAULT_TIMEOUT,
+		logger:  log.StandardLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// Create a new resty client:
+	resty := resty.New()
+
+	// The transport must be set before the TLS configuration, which is applied to it:
+	if o.transport != nil {
+		resty.SetTransport(o.transport)
+	}
+
+	if o.tlsConfig != nil {
+		resty.SetTLSClientConfig(o.tlsConfig)
+	}
+
+	resty.SetTimeout(o.timeout)
+
+	resty.SetLogger(o.logger)
+
+	if o.retries > 0 {
+		resty.SetRetryCount(o.retries).SetRetryWaitTime(o.retryWait).SetRetryMaxWaitTime(o.retryMax)
+
+		// A retry condition replaces the default of retrying every request after a transport error:
+		resty.AddRetryCondition(retryGetRequests)
+	}
+
+	if len(o.username) > 0 {
+		resty.SetBasicAuth(o.username, o.password)
+	}
+
+	if len(o.token) > 0 {
+		resty.SetAuthToken(o.token)
+	}
+
+	// Create a new ASCOM Alpaca API client:
+	client := ASCOMAlpacaAPIClient{
+		Client:        resty,
+		UrlBase:       strings.TrimSuffix(urlBase, "/"),
+		BasePath:      o.basePath,
+		ClientId:      o.clientId,
+		TransactionId: 0,
+		Logger:        o.logger,
+	}
+
+	return &client
+}
@@ -0,0 +1,208 @@
+package alpacago
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func echoBooleanHandler(t *testing.T, check func(r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		check(r)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":true,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, r.FormValue("ClientTransactionID"))
+	}
+}
+
+func TestNewAlpacaAPIWithOptionsDefaults(t *testing.T) {
+	client := NewAlpacaAPIWithOptions("http://0.0.0.0:8000/")
+
+	var got string = client.getEndpoint("telescope", 0, "canslew")
+	var want string = "http://0.0.0.0:8000/api/v1/telescope/0/canslew"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+
+	if client.Client.GetClient().Timeout != DEFAULT_TIMEOUT {
+		t.Errorf("got %v, wanted %v", client.Client.GetClient().Timeout, DEFAULT_TIMEOUT)
+	}
+}
+
+func TestNewAlpacaAPIWithOptionsClientID(t *testing.T) {
+	client := NewAlpacaAPIWithOptions("http://0.0.0.0:8000", WithClientID(65535))
+
+	var got uint32 = client.ClientId
+	var want uint32 = 65535
+
+	if got != want {
+		t.Errorf("got %d, wanted %d", got, want)
+	}
+}
+
+func TestNewAlpacaAPIWithOptionsBasePath(t *testing.T) {
+	client := NewAlpacaAPIWithOptions("https://observatory.local", WithBasePath("alpaca/"))
+
+	var got string = client.getEndpoint("camera", 1, "imageready")
+	var want string = "https://observatory.local/alpaca/api/v1/camera/1/imageready"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestNewAlpacaAPIWithOptionsBasicAuth(t *testing.T) {
+	server := httptest.NewServer(echoBooleanHandler(t, func(r *http.Request) {
+		username, password, ok := r.BasicAuth()
+
+		if !ok || username != "observer" || password != "s3cret" {
+			t.Errorf("got %q:%q, wanted %q:%q", username, password, "observer", "s3cret")
+		}
+	}))
+
+	defer server.Close()
+
+	camera := NewCameraWithOptions(server.URL, 0, WithBasicAuth("observer", "s3cret"))
+
+	if _, err := camera.IsConnected(); err != nil {
+		t.Errorf("got %q", err)
+	}
+}
+
+func TestNewAlpacaAPIWithOptionsBearerToken(t *testing.T) {
+	server := httptest.NewServer(echoBooleanHandler(t, func(r *http.Request) {
+		var got string = r.Header.Get("Authorization")
+		var want string = "Bearer abc123"
+
+		if got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	}))
+
+	defer server.Close()
+
+	focuser := NewFocuserWithOptions(server.URL, 0, WithBearerToken("abc123"))
+
+	if _, err := focuser.IsMoving(); err != nil {
+		t.Errorf("got %q", err)
+	}
+}
+
+func TestNewAlpacaAPIWithOptionsTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(echoBooleanHandler(t, func(r *http.Request) {}))
+
+	defer server.Close()
+
+	// Trust the self-signed certificate of the test server:
+	pool := x509.NewCertPool()
+
+	pool.AddCert(server.Certificate())
+
+	dome := NewDomeWithOptions(server.URL, 0, WithTLSConfig(&tls.Config{RootCAs: pool}))
+
+	if _, err := dome.IsSlewing(); err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	// Without trusting the certificate, the request must fail:
+	untrusted := NewDomeWithOptions(server.URL, 0)
+
+	if _, err := untrusted.IsSlewing(); err == nil {
+		t.Errorf("got nil, wanted a certificate verification error")
+	}
+}
+
+type countingTransport struct {
+	count int
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.count++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestNewAlpacaAPIWithOptionsTransport(t *testing.T) {
+	server := httptest.NewServer(echoBooleanHandler(t, func(r *http.Request) {}))
+
+	defer server.Close()
+
+	transport := &countingTransport{}
+
+	telescope := NewTelescopeWithOptions(server.URL, 0, NotTracking, WithTransport(transport))
+
+	if _, err := telescope.IsSlewing(); err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if transport.count != 1 {
+		t.Errorf("got %d, wanted %d", transport.count, 1)
+	}
+}
+
+func TestNewAlpacaAPIWithOptionsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+
+	defer server.Close()
+
+	rotator := NewRotatorWithOptions(server.URL, 0, WithTimeout(50*time.Millisecond))
+
+	_, err := rotator.IsMoving()
+
+	if err == nil {
+		t.Errorf("got nil, wanted a timeout error")
+	}
+
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("got %q, wanted a timeout error", err)
+	}
+}
+
+type failingTransport struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (f *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.counts[r.Method]++
+
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestNewAlpacaAPIWithOptionsRetryPolicy(t *testing.T) {
+	transport := &failingTransport{counts: map[string]int{}}
+
+	telescope := NewTelescopeWithOptions("http://127.0.0.1:11111", 0, NotTracking, WithTransport(transport), WithRetryPolicy(2, time.Millisecond, time.Millisecond))
+
+	if _, err := telescope.IsSlewing(); err == nil {
+		t.Errorf("got nil, wanted a transport error")
+	}
+
+	if err := telescope.SetPulseGuide(North, 100); err == nil {
+		t.Errorf("got nil, wanted a transport error")
+	}
+
+	// The GET is retried twice, but the pulse, which may have reached the mount, is not repeated:
+	if transport.counts[http.MethodGet] != 3 {
+		t.Errorf("got %d, wanted %d", transport.counts[http.MethodGet], 3)
+	}
+
+	if transport.counts[http.MethodPut] != 1 {
+		t.Errorf("got %d, wanted %d", transport.counts[http.MethodPut], 1)
+	}
+}
@@ -1,12 +1,29 @@
 package alpacago
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 type Rotator struct {
 	Alpaca       *ASCOMAlpacaAPIClient
 	DeviceNumber uint
 }
 
+/*
+RotatorDeviceState
+
+The operational properties of the rotator, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type RotatorDeviceState struct {
+	IsMoving           bool    `json:"IsMoving"`
+	MechanicalPosition float64 `json:"MechanicalPosition"`
+	Position           float64 `json:"Position"`
+	TimeStamp          string  `json:"TimeStamp"`
+}
+
 func NewRotator(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *Rotator {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -18,6 +35,17 @@ func NewRotator(clientId uint32, secure bool, domain string, ip string, port int
 	return &rotator
 }
 
+func NewRotatorWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *Rotator {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	rotator := Rotator{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &rotator
+}
+
 /*
 GetDescription() common method to all ASCOM Alpaca compliant devices
 
@@ -25,7 +53,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (r *Rotator) GetDescription() (string, error) {
-	return r.Alpaca.GetDescription("rotator", r.DeviceNumber)
+	return r.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetDescriptionContext(ctx context.Context) (string, error) {
+	return r.Alpaca.GetDescriptionContext(ctx, "rotator", r.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (r *Rotator) GetSupportedActions() ([]string, error) {
+	return r.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return r.Alpaca.GetSupportedActionsContext(ctx, "rotator", r.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (r *Rotator) Action(action string, parameters string) (string, error) {
+	return r.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return r.Alpaca.ActionContext(ctx, "rotator", r.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (r *Rotator) CommandBlind(command string, raw bool) error {
+	return r.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return r.Alpaca.CommandBlindContext(ctx, "rotator", r.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (r *Rotator) CommandBool(command string, raw bool) (bool, error) {
+	return r.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return r.Alpaca.CommandBoolContext(ctx, "rotator", r.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (r *Rotator) CommandString(command string, raw bool) (string, error) {
+	return r.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return r.Alpaca.CommandStringContext(ctx, "rotator", r.DeviceNumber, command, raw)
 }
 
 /*
@@ -35,7 +175,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (r *Rotator) IsConnected() (bool, error) {
-	return r.Alpaca.GetBooleanResponse("rotator", r.DeviceNumber, "connected")
+	return r.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) IsConnectedContext(ctx context.Context) (bool, error) {
+	return r.Alpaca.GetBooleanResponseContext(ctx, "rotator", r.DeviceNumber, "connected")
 }
 
 /*
@@ -46,16 +195,151 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (r *Rotator) SetConnected(connected bool) error {
-	r.Alpaca.TransactionId++
+	return r.SetConnectedContext(context.Background(), connected)
+}
+
+/*
+SetConnectedContext()
 
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", r.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", r.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return r.Alpaca.PutContext(ctx, "rotator", r.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (r *Rotator) Connect() error {
+	return r.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) ConnectContext(ctx context.Context) error {
+	return r.Alpaca.ConnectContext(ctx, "rotator", r.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (r *Rotator) Disconnect() error {
+	return r.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) DisconnectContext(ctx context.Context) error {
+	return r.Alpaca.DisconnectContext(ctx, "rotator", r.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (r *Rotator) IsConnecting() (bool, error) {
+	return r.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) IsConnectingContext(ctx context.Context) (bool, error) {
+	return r.Alpaca.IsConnectingContext(ctx, "rotator", r.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the rotator in a single round trip, see RotatorDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (r *Rotator) GetDeviceState() (*RotatorDeviceState, error) {
+	return r.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetDeviceStateContext(ctx context.Context) (*RotatorDeviceState, error) {
+	values, err := r.Alpaca.GetDeviceStateContext(ctx, "rotator", r.DeviceNumber)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return r.Alpaca.Put("rotator", r.DeviceNumber, "connected", form)
+	state := RotatorDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the rotator from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (r *Rotator) GetDeviceStateWithFallback() (*RotatorDeviceState, error) {
+	return r.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetDeviceStateWithFallbackContext(ctx context.Context) (*RotatorDeviceState, error) {
+	state, err := r.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	state = &RotatorDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.IsMoving, err = r.IsMovingContext(ctx)
+	errs = append(errs, err)
+
+	state.MechanicalPosition, err = r.GetMechanicalPositionContext(ctx)
+	errs = append(errs, err)
+
+	state.Position, err = r.GetPositionContext(ctx)
+	errs = append(errs, err)
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -65,7 +349,16 @@ CanReverse()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/get_rotator__device_number__canreverse
 */
 func (r *Rotator) CanReverse() (bool, error) {
-	return r.Alpaca.GetBooleanResponse("rotator", r.DeviceNumber, "canreverse")
+	return r.CanReverseContext(context.Background())
+}
+
+/*
+CanReverseContext()
+
+CanReverse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) CanReverseContext(ctx context.Context) (bool, error) {
+	return r.Alpaca.GetBooleanResponseContext(ctx, "rotator", r.DeviceNumber, "canreverse")
 }
 
 /*
@@ -75,7 +368,16 @@ IsMoving()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/get_rotator__device_number__ismoving
 */
 func (r *Rotator) IsMoving() (bool, error) {
-	return r.Alpaca.GetBooleanResponse("rotator", r.DeviceNumber, "ismoving")
+	return r.IsMovingContext(context.Background())
+}
+
+/*
+IsMovingContext()
+
+IsMoving() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) IsMovingContext(ctx context.Context) (bool, error) {
+	return r.Alpaca.GetBooleanResponseContext(ctx, "rotator", r.DeviceNumber, "ismoving")
 }
 
 /*
@@ -85,7 +387,16 @@ GetMechanicalPosition()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/get_rotator__device_number__mechanicalposition
 */
 func (r *Rotator) GetMechanicalPosition() (float64, error) {
-	return r.Alpaca.GetFloat64Response("rotator", r.DeviceNumber, "mechanicalposition")
+	return r.GetMechanicalPositionContext(context.Background())
+}
+
+/*
+GetMechanicalPositionContext()
+
+GetMechanicalPosition() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetMechanicalPositionContext(ctx context.Context) (float64, error) {
+	return r.Alpaca.GetFloat64ResponseContext(ctx, "rotator", r.DeviceNumber, "mechanicalposition")
 }
 
 /*
@@ -95,7 +406,16 @@ GetPosition()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/get_rotator__device_number__position
 */
 func (r *Rotator) GetPosition() (float64, error) {
-	return r.Alpaca.GetFloat64Response("rotator", r.DeviceNumber, "mechanicalposition")
+	return r.GetPositionContext(context.Background())
+}
+
+/*
+GetPositionContext()
+
+GetPosition() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetPositionContext(ctx context.Context) (float64, error) {
+	return r.Alpaca.GetFloat64ResponseContext(ctx, "rotator", r.DeviceNumber, "position")
 }
 
 /*
@@ -105,7 +425,16 @@ GetReverse()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/get_rotator__device_number__reverse
 */
 func (r *Rotator) GetReverse() (bool, error) {
-	return r.Alpaca.GetBooleanResponse("rotator", r.DeviceNumber, "reverse")
+	return r.GetReverseContext(context.Background())
+}
+
+/*
+GetReverseContext()
+
+GetReverse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetReverseContext(ctx context.Context) (bool, error) {
+	return r.Alpaca.GetBooleanResponseContext(ctx, "rotator", r.DeviceNumber, "reverse")
 }
 
 /*
@@ -115,15 +444,20 @@ SetReverse
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/put_rotator__device_number__reverse
 */
 func (r *Rotator) SetReverse(reverse bool) error {
-	r.Alpaca.TransactionId++
+	return r.SetReverseContext(context.Background(), reverse)
+}
 
+/*
+SetReverseContext()
+
+SetReverse() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) SetReverseContext(ctx context.Context, reverse bool) error {
 	var form map[string]string = map[string]string{
-		"Reverse":             fmt.Sprintf("%t", reverse),
-		"ClientID":            fmt.Sprintf("%d", r.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", r.Alpaca.TransactionId),
+		"Reverse": fmt.Sprintf("%t", reverse),
 	}
 
-	return r.Alpaca.Put("rotator", r.DeviceNumber, "reverse", form)
+	return r.Alpaca.PutContext(ctx, "rotator", r.DeviceNumber, "reverse", form)
 }
 
 /*
@@ -133,7 +467,16 @@ GetStepSize()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/get_rotator__device_number__stepsize
 */
 func (r *Rotator) GetStepSize() (float64, error) {
-	return r.Alpaca.GetFloat64Response("rotator", r.DeviceNumber, "stepsize")
+	return r.GetStepSizeContext(context.Background())
+}
+
+/*
+GetStepSizeContext()
+
+GetStepSize() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetStepSizeContext(ctx context.Context) (float64, error) {
+	return r.Alpaca.GetFloat64ResponseContext(ctx, "rotator", r.DeviceNumber, "stepsize")
 }
 
 /*
@@ -143,7 +486,16 @@ GetTargetPosition()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/get_rotator__device_number__stepsize
 */
 func (r *Rotator) GetTargetPosition() (float64, error) {
-	return r.Alpaca.GetFloat64Response("rotator", r.DeviceNumber, "stepsize")
+	return r.GetTargetPositionContext(context.Background())
+}
+
+/*
+GetTargetPositionContext()
+
+GetTargetPosition() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) GetTargetPositionContext(ctx context.Context) (float64, error) {
+	return r.Alpaca.GetFloat64ResponseContext(ctx, "rotator", r.DeviceNumber, "stepsize")
 }
 
 /*
@@ -153,14 +505,18 @@ SetHalt() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/put_rotator__device_number__halt
 */
 func (r *Rotator) SetHalt() error {
-	r.Alpaca.TransactionId++
+	return r.SetHaltContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", r.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", r.Alpaca.TransactionId),
-	}
+/*
+SetHaltContext()
 
-	return r.Alpaca.Put("rotator", r.DeviceNumber, "halt", form)
+SetHalt() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) SetHaltContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return r.Alpaca.PutContext(ctx, "rotator", r.DeviceNumber, "halt", form)
 }
 
 /*
@@ -171,16 +527,21 @@ SetMove()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/put_rotator__device_number__move
 */
 func (r *Rotator) SetMove(position float64) error {
-	r.Alpaca.TransactionId++
+	return r.SetMoveContext(context.Background(), position)
+}
 
+/*
+SetMoveContext()
+
+SetMove() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) SetMoveContext(ctx context.Context, position float64) error {
 	var form map[string]string = map[string]string{
 		// Relative position to move in degrees from current Position.
-		"Position":            fmt.Sprintf("%f", position),
-		"ClientID":            fmt.Sprintf("%d", r.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", r.Alpaca.TransactionId),
+		"Position": fmt.Sprintf("%f", position),
 	}
 
-	return r.Alpaca.Put("rotator", r.DeviceNumber, "move", form)
+	return r.Alpaca.PutContext(ctx, "rotator", r.DeviceNumber, "move", form)
 }
 
 /*
@@ -191,16 +552,21 @@ SetMoveAbsolute()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/put_rotator__device_number__moveabsolute
 */
 func (r *Rotator) SetMoveAbsolute(position float64) error {
-	r.Alpaca.TransactionId++
+	return r.SetMoveAbsoluteContext(context.Background(), position)
+}
+
+/*
+SetMoveAbsoluteContext()
 
+SetMoveAbsolute() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) SetMoveAbsoluteContext(ctx context.Context, position float64) error {
 	var form map[string]string = map[string]string{
 		// Absolute position in degrees.
-		"Position":            fmt.Sprintf("%f", position),
-		"ClientID":            fmt.Sprintf("%d", r.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", r.Alpaca.TransactionId),
+		"Position": fmt.Sprintf("%f", position),
 	}
 
-	return r.Alpaca.Put("rotator", r.DeviceNumber, "moveabsolute", form)
+	return r.Alpaca.PutContext(ctx, "rotator", r.DeviceNumber, "moveabsolute", form)
 }
 
 /*
@@ -211,16 +577,21 @@ SetMoveMechanical()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/put_rotator__device_number__movemechanical
 */
 func (r *Rotator) SetMoveMechanical(position float64) error {
-	r.Alpaca.TransactionId++
+	return r.SetMoveMechanicalContext(context.Background(), position)
+}
+
+/*
+SetMoveMechanicalContext()
 
+SetMoveMechanical() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) SetMoveMechanicalContext(ctx context.Context, position float64) error {
 	var form map[string]string = map[string]string{
 		// Absolute position in degrees.
-		"Position":            fmt.Sprintf("%f", position),
-		"ClientID":            fmt.Sprintf("%d", r.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", r.Alpaca.TransactionId),
+		"Position": fmt.Sprintf("%f", position),
 	}
 
-	return r.Alpaca.Put("rotator", r.DeviceNumber, "movemechanical", form)
+	return r.Alpaca.PutContext(ctx, "rotator", r.DeviceNumber, "movemechanical", form)
 }
 
 /*
@@ -231,14 +602,19 @@ SetSync()
 @see https://ascom-standards.org/api/#/Rotator%20Specific%20Methods/put_rotator__device_number__sync
 */
 func (r *Rotator) SetSync(position float64) error {
-	r.Alpaca.TransactionId++
+	return r.SetSyncContext(context.Background(), position)
+}
 
+/*
+SetSyncContext()
+
+SetSync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (r *Rotator) SetSyncContext(ctx context.Context, position float64) error {
 	var form map[string]string = map[string]string{
 		// Absolute position in degrees.
-		"Position":            fmt.Sprintf("%f", position),
-		"ClientID":            fmt.Sprintf("%d", r.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", r.Alpaca.TransactionId),
+		"Position": fmt.Sprintf("%f", position),
 	}
 
-	return r.Alpaca.Put("rotator", r.DeviceNumber, "sync", form)
+	return r.Alpaca.PutContext(ctx, "rotator", r.DeviceNumber, "sync", form)
 }
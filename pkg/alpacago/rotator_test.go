@@ -3,6 +3,8 @@ package alpacago
 import (
 	"math"
 	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
 )
 
 var rotator = NewRotator(65535, false, "100.69.47.32", "", -1, 0)
@@ -188,6 +190,35 @@ func TestNewRotatorGetPosition(t *testing.T) {
 	}
 }
 
+func TestRotatorGetPositionQueriesPosition(t *testing.T) {
+	server := alpacatest.NewServer(t, map[string]string{
+		"rotator/0/position":           "90.5",
+		"rotator/0/mechanicalposition": "45.25",
+	}).Strict()
+
+	rotator := NewRotatorWithOptions(server.URL, 0)
+
+	got, err := rotator.GetPosition()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got != 90.5 {
+		t.Errorf("got %f, wanted %f", got, 90.5)
+	}
+
+	got, err = rotator.GetMechanicalPosition()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got != 45.25 {
+		t.Errorf("got %f, wanted %f", got, 45.25)
+	}
+}
+
 func TestNewRotatorGetReverse(t *testing.T) {
 	var got, err = rotator.GetReverse()
 
@@ -0,0 +1,149 @@
+package alpacago
+
+/*
+AlpacaServer
+
+A handle to a single ASCOM Alpaca server, which vends device objects for each of the devices
+hosted by the server. Every device handle vended by the server shares the same underlying
+ASCOM Alpaca API client, and therefore the same HTTP connection pool, transaction ID sequence,
+logger and retry policy.
+*/
+type AlpacaServer struct {
+	Alpaca *ASCOMAlpacaAPIClient
+}
+
+/*
+NewAlpacaServer()
+
+@param urlBase string (the scheme, host and port of the Alpaca server, e.g., http://observatory.local:11111)
+@returns a new AlpacaServer, configured by the given options.
+*/
+func NewAlpacaServer(urlBase string, opts ...ClientOption) *AlpacaServer {
+	server := AlpacaServer{
+		Alpaca: NewAlpacaAPIWithOptions(urlBase, opts...),
+	}
+
+	return &server
+}
+
+/*
+Camera()
+
+@returns a Camera handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) Camera(deviceNumber uint) *Camera {
+	return &Camera{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+CoverCalibrator()
+
+@returns a CoverCalibrator handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) CoverCalibrator(deviceNumber uint) *CoverCalibrator {
+	return &CoverCalibrator{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+Dome()
+
+@returns a Dome handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) Dome(deviceNumber uint) *Dome {
+	return &Dome{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+FilterWheel()
+
+@returns a FilterWheel handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) FilterWheel(deviceNumber uint) *FilterWheel {
+	return &FilterWheel{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+Focuser()
+
+@returns a Focuser handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) Focuser(deviceNumber uint) *Focuser {
+	return &Focuser{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+ObservingConditions()
+
+@returns an ObservingConditions handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) ObservingConditions(deviceNumber uint) *ObservingConditions {
+	return &ObservingConditions{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+Rotator()
+
+@returns a Rotator handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) Rotator(deviceNumber uint) *Rotator {
+	return &Rotator{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+SafetyMonitor()
+
+@returns a SafetyMonitor handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) SafetyMonitor(deviceNumber uint) *SafetyMonitor {
+	return &SafetyMonitor{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+Switch()
+
+@returns a Switch handle for the given device number, sharing the server's client.
+*/
+func (s *AlpacaServer) Switch(deviceNumber uint) *Switch {
+	return &Switch{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+	}
+}
+
+/*
+Telescope()
+
+@returns a Telescope handle for the given device number, sharing the server's client. The handle's
+Tracking mode defaults to NotTracking.
+*/
+func (s *AlpacaServer) Telescope(deviceNumber uint) *Telescope {
+	return &Telescope{
+		Alpaca:       s.Alpaca,
+		DeviceNumber: deviceNumber,
+		Tracking:     NotTracking,
+	}
+}
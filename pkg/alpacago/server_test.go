@@ -0,0 +1,113 @@
+package alpacago
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewAlpacaServerBaseURL(t *testing.T) {
+	server := NewAlpacaServer("http://0.0.0.0:8000", WithClientID(65535))
+
+	var got string = server.Camera(0).Alpaca.UrlBase
+	var want string = "http://0.0.0.0:8000"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestNewAlpacaServerSharesClient(t *testing.T) {
+	server := NewAlpacaServer("http://0.0.0.0:8000", WithClientID(65535))
+
+	camera := server.Camera(0)
+
+	telescope := server.Telescope(1)
+
+	if camera.Alpaca != telescope.Alpaca {
+		t.Errorf("got distinct clients, wanted the camera and telescope to share a client")
+	}
+
+	if camera.Alpaca.Client != server.FilterWheel(0).Alpaca.Client {
+		t.Errorf("got distinct HTTP clients, wanted the camera and filter wheel to share a HTTP client")
+	}
+
+	if telescope.DeviceNumber != 1 {
+		t.Errorf("got %d, wanted %d", telescope.DeviceNumber, 1)
+	}
+}
+
+func TestNewAlpacaServerSharesTransactionIDs(t *testing.T) {
+	var mu sync.Mutex
+
+	seen := map[string]bool{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.FormValue("ClientTransactionID")
+
+		mu.Lock()
+		seen[id] = true
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":true,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, id)
+	}))
+
+	defer ts.Close()
+
+	server := NewAlpacaServer(ts.URL, WithClientID(65535))
+
+	if _, err := server.Camera(0).IsConnected(); err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if _, err := server.Focuser(0).IsConnected(); err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if _, err := server.Dome(0).IsConnected(); err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if len(seen) != 3 || !seen["1"] || !seen["2"] || !seen["3"] {
+		t.Errorf("got %v, wanted transaction ids 1, 2 and 3", seen)
+	}
+}
+
+func TestNewAlpacaServerRetryPolicy(t *testing.T) {
+	var attempts int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Drop the first connection without a response:
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Value":true,"ClientTransactionID":%s,"ServerTransactionID":1,"ErrorNumber":0,"ErrorMessage":""}`, r.FormValue("ClientTransactionID"))
+	}))
+
+	defer ts.Close()
+
+	server := NewAlpacaServer(ts.URL, WithRetryPolicy(2, 10*time.Millisecond, 50*time.Millisecond))
+
+	got, err := server.SafetyMonitor(0).IsSafe()
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if !got {
+		t.Errorf("got %v, wanted %v", got, true)
+	}
+
+	if atomic.LoadInt32(&attempts) != 2 {
+		t.Errorf("got %d attempts, wanted %d", attempts, 2)
+	}
+}
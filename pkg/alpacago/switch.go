@@ -1,12 +1,32 @@
 package alpacago
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Switch struct {
 	Alpaca       *ASCOMAlpacaAPIClient
 	DeviceNumber uint
 }
 
+/*
+SwitchDeviceState
+
+The operational properties of the switch device, as returned by devicestate in a single round trip, where
+Switches and Values hold the GetSwitch and GetSwitchValue of each switch, keyed by switch ID.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type SwitchDeviceState struct {
+	Switches  map[int32]bool
+	Values    map[int32]float64
+	TimeStamp string
+}
+
 func NewSwitch(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint) *Switch {
 	alpaca := NewAlpacaAPI(clientId, secure, domain, ip, port)
 
@@ -18,6 +38,17 @@ func NewSwitch(clientId uint32, secure bool, domain string, ip string, port int3
 	return &sw
 }
 
+func NewSwitchWithOptions(urlBase string, deviceNumber uint, opts ...ClientOption) *Switch {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	sw := Switch{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+	}
+
+	return &sw
+}
+
 /*
 GetDescription() common method to all ASCOM Alpaca compliant devices
 
@@ -25,7 +56,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (s *Switch) GetDescription() (string, error) {
-	return s.Alpaca.GetDescription("switch", s.DeviceNumber)
+	return s.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetDescriptionContext(ctx context.Context) (string, error) {
+	return s.Alpaca.GetDescriptionContext(ctx, "switch", s.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (s *Switch) GetSupportedActions() ([]string, error) {
+	return s.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return s.Alpaca.GetSupportedActionsContext(ctx, "switch", s.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (s *Switch) Action(action string, parameters string) (string, error) {
+	return s.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return s.Alpaca.ActionContext(ctx, "switch", s.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (s *Switch) CommandBlind(command string, raw bool) error {
+	return s.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return s.Alpaca.CommandBlindContext(ctx, "switch", s.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (s *Switch) CommandBool(command string, raw bool) (bool, error) {
+	return s.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return s.Alpaca.CommandBoolContext(ctx, "switch", s.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (s *Switch) CommandString(command string, raw bool) (string, error) {
+	return s.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return s.Alpaca.CommandStringContext(ctx, "switch", s.DeviceNumber, command, raw)
 }
 
 /*
@@ -35,7 +178,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (s *Switch) IsConnected() (bool, error) {
-	return s.Alpaca.GetBooleanResponse("switch", s.DeviceNumber, "connected")
+	return s.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) IsConnectedContext(ctx context.Context) (bool, error) {
+	return s.Alpaca.GetBooleanResponseContext(ctx, "switch", s.DeviceNumber, "connected")
 }
 
 /*
@@ -46,16 +198,188 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (s *Switch) SetConnected(connected bool) error {
-	s.Alpaca.TransactionId++
+	return s.SetConnectedContext(context.Background(), connected)
+}
+
+/*
+SetConnectedContext()
 
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", s.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", s.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return s.Alpaca.PutContext(ctx, "switch", s.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (s *Switch) Connect() error {
+	return s.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) ConnectContext(ctx context.Context) error {
+	return s.Alpaca.ConnectContext(ctx, "switch", s.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (s *Switch) Disconnect() error {
+	return s.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) DisconnectContext(ctx context.Context) error {
+	return s.Alpaca.DisconnectContext(ctx, "switch", s.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (s *Switch) IsConnecting() (bool, error) {
+	return s.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) IsConnectingContext(ctx context.Context) (bool, error) {
+	return s.Alpaca.IsConnectingContext(ctx, "switch", s.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the switch device in a single round trip, see SwitchDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (s *Switch) GetDeviceState() (*SwitchDeviceState, error) {
+	return s.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetDeviceStateContext(ctx context.Context) (*SwitchDeviceState, error) {
+	values, err := s.Alpaca.GetDeviceStateContext(ctx, "switch", s.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := SwitchDeviceState{
+		Switches: map[int32]bool{},
+		Values:   map[int32]float64{},
 	}
 
-	return s.Alpaca.Put("switch", s.DeviceNumber, "connected", form)
+	for _, v := range values {
+		switch {
+		case v.Name == "TimeStamp":
+			err = json.Unmarshal(v.Value, &state.TimeStamp)
+		case strings.HasPrefix(v.Name, "GetSwitchValue"):
+			id, _ := strconv.ParseInt(strings.TrimPrefix(v.Name, "GetSwitchValue"), 10, 32)
+			value := 0.0
+			err = json.Unmarshal(v.Value, &value)
+			state.Values[int32(id)] = value
+		case strings.HasPrefix(v.Name, "GetSwitch"):
+			id, _ := strconv.ParseInt(strings.TrimPrefix(v.Name, "GetSwitch"), 10, 32)
+			value := false
+			err = json.Unmarshal(v.Value, &value)
+			state.Switches[int32(id)] = value
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the switch device from devicestate or, for drivers which predate it,
+from the individual GetSwitch and GetSwitchValue reads of each switch.
+*/
+func (s *Switch) GetDeviceStateWithFallback() (*SwitchDeviceState, error) {
+	return s.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetDeviceStateWithFallbackContext(ctx context.Context) (*SwitchDeviceState, error) {
+	state, err := s.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	n, err := s.GetMaxSwitchContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state = &SwitchDeviceState{
+		Switches:  map[int32]bool{},
+		Values:    map[int32]float64{},
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	for id := int32(0); id < n; id++ {
+		on, err := s.GetSwitchContext(ctx, id)
+
+		if err == nil {
+			state.Switches[id] = on
+		}
+
+		errs = append(errs, err)
+
+		value, err := s.GetSwitchValueContext(ctx, id)
+
+		if err == nil {
+			state.Values[id] = value
+		}
+
+		errs = append(errs, err)
+	}
+
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -65,7 +389,16 @@ GetMaxSwitch()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__maxswitch
 */
 func (s *Switch) GetMaxSwitch() (int32, error) {
-	return s.Alpaca.GetInt32Response("switch", s.DeviceNumber, "maxswitch")
+	return s.GetMaxSwitchContext(context.Background())
+}
+
+/*
+GetMaxSwitchContext()
+
+GetMaxSwitch() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetMaxSwitchContext(ctx context.Context) (int32, error) {
+	return s.Alpaca.GetInt32ResponseContext(ctx, "switch", s.DeviceNumber, "maxswitch")
 }
 
 /*
@@ -75,21 +408,21 @@ CanAsync()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__canasync
 */
 func (s *Switch) CanAsync(switchID int32) (bool, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "canasync")
+	return s.CanAsyncContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+CanAsyncContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&booleanResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+CanAsync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) CanAsyncContext(ctx context.Context, switchID int32) (bool, error) {
+	result := &booleanResponse{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "canasync", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return false, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*booleanResponse))
-
 	return result.Value, nil
 }
 
@@ -100,21 +433,21 @@ CanWrite()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__canwrite
 */
 func (s *Switch) CanWrite(switchID int32) (bool, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "canwrite")
+	return s.CanWriteContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+CanWriteContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&booleanResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+CanWrite() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) CanWriteContext(ctx context.Context, switchID int32) (bool, error) {
+	result := &booleanResponse{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "canwrite", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return false, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*booleanResponse))
-
 	return result.Value, nil
 }
 
@@ -125,21 +458,21 @@ GetSwitch()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__getswitch
 */
 func (s *Switch) GetSwitch(switchID int32) (bool, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "getswitch")
+	return s.GetSwitchContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+GetSwitchContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&booleanResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+GetSwitch() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetSwitchContext(ctx context.Context, switchID int32) (bool, error) {
+	result := &booleanResponse{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "getswitch", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return false, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*booleanResponse))
-
 	return result.Value, nil
 }
 
@@ -150,21 +483,21 @@ GetSwitchDescription()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__getswitchdescription
 */
 func (s *Switch) GetSwitchDescription(switchID int32) (string, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "getswitchdescription")
+	return s.GetSwitchDescriptionContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+GetSwitchDescriptionContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&stringResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+GetSwitchDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetSwitchDescriptionContext(ctx context.Context, switchID int32) (string, error) {
+	result := &stringResponse{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "getswitchdescription", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return "", err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*stringResponse))
-
 	return result.Value, nil
 }
 
@@ -175,21 +508,21 @@ GetSwitchName()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__getswitchname
 */
 func (s *Switch) GetSwitchName(switchID int32) (string, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "getswitchname")
+	return s.GetSwitchNameContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+GetSwitchNameContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&stringResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+GetSwitchName() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetSwitchNameContext(ctx context.Context, switchID int32) (string, error) {
+	result := &stringResponse{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "getswitchname", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return "", err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*stringResponse))
-
 	return result.Value, nil
 }
 
@@ -200,21 +533,21 @@ GetSwitchValue()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__getswitchvalue
 */
 func (s *Switch) GetSwitchValue(switchID int32) (float64, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "getswitchvalue")
+	return s.GetSwitchValueContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+GetSwitchValueContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&float64Response{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+GetSwitchValue() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) GetSwitchValueContext(ctx context.Context, switchID int32) (float64, error) {
+	result := &float64Response{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "getswitchvalue", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*float64Response))
-
 	return result.Value, nil
 }
 
@@ -225,21 +558,21 @@ MinSwitchValue()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__minswitchvalue
 */
 func (s *Switch) MinSwitchValue(switchID int32) (float64, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "minswitchvalue")
+	return s.MinSwitchValueContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+MinSwitchValueContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&float64Response{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+MinSwitchValue() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) MinSwitchValueContext(ctx context.Context, switchID int32) (float64, error) {
+	result := &float64Response{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "minswitchvalue", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*float64Response))
-
 	return result.Value, nil
 }
 
@@ -250,21 +583,21 @@ MaxSwitchValue()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__maxswitchvalue
 */
 func (s *Switch) MaxSwitchValue(switchID int32) (float64, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "maxswitchvalue")
+	return s.MaxSwitchValueContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+MaxSwitchValueContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&float64Response{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+MaxSwitchValue() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) MaxSwitchValueContext(ctx context.Context, switchID int32) (float64, error) {
+	result := &float64Response{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "maxswitchvalue", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*float64Response))
-
 	return result.Value, nil
 }
 
@@ -288,16 +621,21 @@ SetSwitch()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/put_switch__device_number__setswitch
 */
 func (s *Switch) SetSwitch(state bool) error {
-	s.Alpaca.TransactionId++
+	return s.SetSwitchContext(context.Background(), state)
+}
+
+/*
+SetSwitchContext()
 
+SetSwitch() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) SetSwitchContext(ctx context.Context, state bool) error {
 	var form map[string]string = map[string]string{
 		// Relative position to move in degrees from current Position.
-		"State":               fmt.Sprintf("%t", state),
-		"ClientID":            fmt.Sprintf("%d", s.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", s.Alpaca.TransactionId),
+		"State": fmt.Sprintf("%t", state),
 	}
 
-	return s.Alpaca.Put("switch", s.DeviceNumber, "setswitch", form)
+	return s.Alpaca.PutContext(ctx, "switch", s.DeviceNumber, "setswitch", form)
 }
 
 /*
@@ -308,16 +646,21 @@ SetSwitchName()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/put_switch__device_number__setswitchname
 */
 func (s *Switch) SetSwitchName(name string) error {
-	s.Alpaca.TransactionId++
+	return s.SetSwitchNameContext(context.Background(), name)
+}
 
+/*
+SetSwitchNameContext()
+
+SetSwitchName() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) SetSwitchNameContext(ctx context.Context, name string) error {
 	var form map[string]string = map[string]string{
 		// Relative position to move in degrees from current Position.
-		"Name":                fmt.Sprintf("%s", name),
-		"ClientID":            fmt.Sprintf("%d", s.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", s.Alpaca.TransactionId),
+		"Name": fmt.Sprintf("%s", name),
 	}
 
-	return s.Alpaca.Put("switch", s.DeviceNumber, "setswitchname", form)
+	return s.Alpaca.PutContext(ctx, "switch", s.DeviceNumber, "setswitchname", form)
 }
 
 /*
@@ -328,16 +671,21 @@ SetSwitchValue()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/put_switch__device_number__setswitchvalue
 */
 func (s *Switch) SetSwitchValue(value float64) error {
-	s.Alpaca.TransactionId++
+	return s.SetSwitchValueContext(context.Background(), value)
+}
 
+/*
+SetSwitchValueContext()
+
+SetSwitchValue() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) SetSwitchValueContext(ctx context.Context, value float64) error {
 	var form map[string]string = map[string]string{
 		// Relative position to move in degrees from current Position.
-		"Value":               fmt.Sprintf("%f", value),
-		"ClientID":            fmt.Sprintf("%d", s.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", s.Alpaca.TransactionId),
+		"Value": fmt.Sprintf("%f", value),
 	}
 
-	return s.Alpaca.Put("switch", s.DeviceNumber, "setswitchvalue", form)
+	return s.Alpaca.PutContext(ctx, "switch", s.DeviceNumber, "setswitchvalue", form)
 }
 
 /*
@@ -347,21 +695,21 @@ StateChangeComplete()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__statechangecomplete
 */
 func (s *Switch) StateChangeComplete(switchID int32) (bool, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "statechangecomplete")
+	return s.StateChangeCompleteContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+StateChangeCompleteContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&booleanResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+StateChangeComplete() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) StateChangeCompleteContext(ctx context.Context, switchID int32) (bool, error) {
+	result := &booleanResponse{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "statechangecomplete", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return false, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*booleanResponse))
-
 	return result.Value, nil
 }
 
@@ -372,20 +720,20 @@ SwitchStep()
 @see https://ascom-standards.org/api/#/Switch%20Specific%20Methods/get_switch__device_number__switchstep
 */
 func (s *Switch) SwitchStep(switchID int32) (float64, error) {
-	url := s.Alpaca.getEndpoint("switch", s.DeviceNumber, "switchstep")
+	return s.SwitchStepContext(context.Background(), switchID)
+}
 
-	querystring := fmt.Sprintf("Id=%d&%s", switchID, s.Alpaca.getQueryString())
+/*
+SwitchStepContext()
 
-	// Setup the resty client:
-	resp, err := s.Alpaca.Client.R().SetResult(&float64Response{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+SwitchStep() with a context, which controls the cancellation and deadline of the request.
+*/
+func (s *Switch) SwitchStepContext(ctx context.Context, switchID int32) (float64, error) {
+	result := &float64Response{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := s.Alpaca.get(ctx, "switch", s.DeviceNumber, "switchstep", map[string]string{"Id": fmt.Sprintf("%d", switchID)}, result); err != nil {
 		return 0, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*float64Response))
-
 	return result.Value, nil
 }
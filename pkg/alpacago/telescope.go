@@ -1,6 +1,7 @@
 package alpacago
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -94,11 +95,30 @@ type Telescope struct {
 }
 
 type AxisRatesResponse struct {
-	Value               []map[string]float64 `json:"Value"`
-	ClientTransactionID int32                `json:"ClientTransactionID"`
-	ServerTransactionID int32                `json:"ServerTransactionID"`
-	ErrorNumber         int32                `json:"ErrorNumber"`
-	ErrorMessage        string               `json:"ErrorMessage"`
+	Value []map[string]float64 `json:"Value"`
+	alpacaResponse
+}
+
+/*
+TelescopeDeviceState
+
+The operational properties of the telescope, as returned by devicestate in a single round trip.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+type TelescopeDeviceState struct {
+	Altitude       float64          `json:"Altitude"`
+	AtHome         bool             `json:"AtHome"`
+	AtPark         bool             `json:"AtPark"`
+	Azimuth        float64          `json:"Azimuth"`
+	Declination    float64          `json:"Declination"`
+	IsPulseGuiding bool             `json:"IsPulseGuiding"`
+	RightAscension float64          `json:"RightAscension"`
+	SideOfPier     PierPointingMode `json:"SideOfPier"`
+	SiderealTime   float64          `json:"SiderealTime"`
+	Slewing        bool             `json:"Slewing"`
+	Tracking       bool             `json:"Tracking"`
+	UTCDate        string           `json:"UTCDate"`
+	TimeStamp      string           `json:"TimeStamp"`
 }
 
 func NewTelescope(clientId uint32, secure bool, domain string, ip string, port int32, deviceNumber uint, tm TrackingMode) *Telescope {
@@ -113,6 +133,18 @@ func NewTelescope(clientId uint32, secure bool, domain string, ip string, port i
 	return &telescope
 }
 
+func NewTelescopeWithOptions(urlBase string, deviceNumber uint, tm TrackingMode, opts ...ClientOption) *Telescope {
+	alpaca := NewAlpacaAPIWithOptions(urlBase, opts...)
+
+	telescope := Telescope{
+		Alpaca:       alpaca,
+		DeviceNumber: deviceNumber,
+		Tracking:     tm,
+	}
+
+	return &telescope
+}
+
 /*
 GetDescription() common method to all ASCOM Alpaca compliant devices
 
@@ -120,7 +152,119 @@ GetDescription() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__description
 */
 func (t *Telescope) GetDescription() (string, error) {
-	return t.Alpaca.GetDescription("telescope", t.DeviceNumber)
+	return t.GetDescriptionContext(context.Background())
+}
+
+/*
+GetDescriptionContext()
+
+GetDescription() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetDescriptionContext(ctx context.Context) (string, error) {
+	return t.Alpaca.GetDescriptionContext(ctx, "telescope", t.DeviceNumber)
+}
+
+/*
+GetSupportedActions() common method to all ASCOM Alpaca compliant devices
+
+@returns the list of action names supported by this driver.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__supportedactions
+*/
+func (t *Telescope) GetSupportedActions() ([]string, error) {
+	return t.GetSupportedActionsContext(context.Background())
+}
+
+/*
+GetSupportedActionsContext()
+
+GetSupportedActions() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetSupportedActionsContext(ctx context.Context) ([]string, error) {
+	return t.Alpaca.GetSupportedActionsContext(ctx, "telescope", t.DeviceNumber)
+}
+
+/*
+Action() common method to all ASCOM Alpaca compliant devices
+
+@param action string (a well known name that represents the action to be carried out, see GetSupportedActions())
+@param parameters string (a list of required parameters or an empty string if none are required)
+@returns the string response of the action, or an error matching ErrActionNotImplemented
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__action
+*/
+func (t *Telescope) Action(action string, parameters string) (string, error) {
+	return t.ActionContext(context.Background(), action, parameters)
+}
+
+/*
+ActionContext()
+
+Action() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) ActionContext(ctx context.Context, action string, parameters string) (string, error) {
+	return t.Alpaca.ActionContext(ctx, "telescope", t.DeviceNumber, action, parameters)
+}
+
+/*
+CommandBlind() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns an error or nil, if nil the command was transmitted to the device
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandblind
+*/
+func (t *Telescope) CommandBlind(command string, raw bool) error {
+	return t.CommandBlindContext(context.Background(), command, raw)
+}
+
+/*
+CommandBlindContext()
+
+CommandBlind() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CommandBlindContext(ctx context.Context, command string, raw bool) error {
+	return t.Alpaca.CommandBlindContext(ctx, "telescope", t.DeviceNumber, command, raw)
+}
+
+/*
+CommandBool() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the boolean response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandbool
+*/
+func (t *Telescope) CommandBool(command string, raw bool) (bool, error) {
+	return t.CommandBoolContext(context.Background(), command, raw)
+}
+
+/*
+CommandBoolContext()
+
+CommandBool() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CommandBoolContext(ctx context.Context, command string, raw bool) (bool, error) {
+	return t.Alpaca.CommandBoolContext(ctx, "telescope", t.DeviceNumber, command, raw)
+}
+
+/*
+CommandString() common method to all ASCOM Alpaca compliant devices
+
+@param command string (the literal command string to be transmitted)
+@param raw bool (if true, command is transmitted 'as-is', if false, protocol framing characters may be added)
+@returns the string response of the device to the command
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__commandstring
+*/
+func (t *Telescope) CommandString(command string, raw bool) (string, error) {
+	return t.CommandStringContext(context.Background(), command, raw)
+}
+
+/*
+CommandStringContext()
+
+CommandString() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CommandStringContext(ctx context.Context, command string, raw bool) (string, error) {
+	return t.Alpaca.CommandStringContext(ctx, "telescope", t.DeviceNumber, command, raw)
 }
 
 /*
@@ -130,7 +274,16 @@ IsConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connected
 */
 func (t *Telescope) IsConnected() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "connected")
+	return t.IsConnectedContext(context.Background())
+}
+
+/*
+IsConnectedContext()
+
+IsConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) IsConnectedContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "connected")
 }
 
 /*
@@ -141,16 +294,181 @@ SetConnected() common method to all ASCOM Alpaca compliant devices
 @see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connected
 */
 func (t *Telescope) SetConnected(connected bool) error {
-	t.Alpaca.TransactionId++
+	return t.SetConnectedContext(context.Background(), connected)
+}
+
+/*
+SetConnectedContext()
 
+SetConnected() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetConnectedContext(ctx context.Context, connected bool) error {
 	var form map[string]string = map[string]string{
 		// Set True to connect to the device hardware, set False to disconnect from the device hardware
-		"Connected":           fmt.Sprintf("%t", connected),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"Connected": fmt.Sprintf("%t", connected),
+	}
+
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "connected", form)
+}
+
+/*
+Connect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous connection to the device hardware, poll
+IsConnecting() until it returns false to determine when the connection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__connect
+*/
+func (t *Telescope) Connect() error {
+	return t.ConnectContext(context.Background())
+}
+
+/*
+ConnectContext()
+
+Connect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) ConnectContext(ctx context.Context) error {
+	return t.Alpaca.ConnectContext(ctx, "telescope", t.DeviceNumber)
+}
+
+/*
+Disconnect() common method to all ASCOM Alpaca compliant devices
+
+@returns an error or nil, if nil it starts an asynchronous disconnection from the device hardware, poll
+IsConnecting() until it returns false to determine when the disconnection has completed.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/put__device_type___device_number__disconnect
+*/
+func (t *Telescope) Disconnect() error {
+	return t.DisconnectContext(context.Background())
+}
+
+/*
+DisconnectContext()
+
+Disconnect() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) DisconnectContext(ctx context.Context) error {
+	return t.Alpaca.DisconnectContext(ctx, "telescope", t.DeviceNumber)
+}
+
+/*
+IsConnecting() common method to all ASCOM Alpaca compliant devices
+
+@returns true whilst an asynchronous Connect() or Disconnect() operation is in progress.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__connecting
+*/
+func (t *Telescope) IsConnecting() (bool, error) {
+	return t.IsConnectingContext(context.Background())
+}
+
+/*
+IsConnectingContext()
+
+IsConnecting() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) IsConnectingContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.IsConnectingContext(ctx, "telescope", t.DeviceNumber)
+}
+
+/*
+GetDeviceState() common method to all ASCOM Alpaca compliant devices
+
+@returns the operational properties of the telescope in a single round trip, see TelescopeDeviceState.
+@see https://ascom-standards.org/api/#/ASCOM%20Methods%20Common%20To%20All%20Devices/get__device_type___device_number__devicestate
+*/
+func (t *Telescope) GetDeviceState() (*TelescopeDeviceState, error) {
+	return t.GetDeviceStateContext(context.Background())
+}
+
+/*
+GetDeviceStateContext()
+
+GetDeviceState() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetDeviceStateContext(ctx context.Context) (*TelescopeDeviceState, error) {
+	values, err := t.Alpaca.GetDeviceStateContext(ctx, "telescope", t.DeviceNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := TelescopeDeviceState{}
+
+	if err := decodeDeviceState(values, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+/*
+GetDeviceStateWithFallback()
+
+@returns the operational properties of the telescope from devicestate or, for drivers which predate it, from
+the individual property reads. Properties not implemented by the driver are left as their zero value.
+*/
+func (t *Telescope) GetDeviceStateWithFallback() (*TelescopeDeviceState, error) {
+	return t.GetDeviceStateWithFallbackContext(context.Background())
+}
+
+/*
+GetDeviceStateWithFallbackContext()
+
+GetDeviceStateWithFallback() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetDeviceStateWithFallbackContext(ctx context.Context) (*TelescopeDeviceState, error) {
+	state, err := t.GetDeviceStateContext(ctx)
+
+	if err == nil || !isDeviceStateUnsupported(err) {
+		return state, err
+	}
+
+	state = &TelescopeDeviceState{
+		TimeStamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	var errs []error
+
+	state.Altitude, err = t.GetAltitudeContext(ctx)
+	errs = append(errs, err)
+
+	state.AtHome, err = t.IsAtHomeContext(ctx)
+	errs = append(errs, err)
+
+	state.AtPark, err = t.IsAtParkContext(ctx)
+	errs = append(errs, err)
+
+	state.Azimuth, err = t.GetAzimuthContext(ctx)
+	errs = append(errs, err)
+
+	state.Declination, err = t.GetDeclinationContext(ctx)
+	errs = append(errs, err)
+
+	state.IsPulseGuiding, err = t.IsPulseGuidingContext(ctx)
+	errs = append(errs, err)
+
+	state.RightAscension, err = t.GetRightAscensionContext(ctx)
+	errs = append(errs, err)
+
+	state.SideOfPier, err = t.GetSideOfPierContext(ctx)
+	errs = append(errs, err)
+
+	state.SiderealTime, err = t.GetSiderealTimeContext(ctx)
+	errs = append(errs, err)
+
+	state.Slewing, err = t.IsSlewingContext(ctx)
+	errs = append(errs, err)
+
+	state.Tracking, err = t.IsTrackingContext(ctx)
+	errs = append(errs, err)
+
+	utc, err := t.GetUTCDateContext(ctx)
+	if err == nil {
+		state.UTCDate = utc.Format(time.RFC3339Nano)
 	}
+	errs = append(errs, err)
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "connected", form)
+	return state, joinDeviceStateErrors(errs...)
 }
 
 /*
@@ -160,14 +478,18 @@ SetAbortSlew()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__abortslew
 */
 func (t *Telescope) SetAbortSlew() error {
-	t.Alpaca.TransactionId++
+	return t.SetAbortSlewContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
-	}
+/*
+SetAbortSlewContext()
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "abortslew", form)
+SetAbortSlew() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetAbortSlewContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "abortslew", form)
 }
 
 /*
@@ -179,7 +501,16 @@ AlignmentModes Enum.
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__alignmentmode
 */
 func (t *Telescope) GetAlignmentMode() (string, error) {
-	mode, err := t.Alpaca.GetInt32Response("telescope", t.DeviceNumber, "alignmentmode")
+	return t.GetAlignmentModeContext(context.Background())
+}
+
+/*
+GetAlignmentModeContext()
+
+GetAlignmentMode() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetAlignmentModeContext(ctx context.Context) (string, error) {
+	mode, err := t.Alpaca.GetInt32ResponseContext(ctx, "telescope", t.DeviceNumber, "alignmentmode")
 	return AlignmentMode(mode).String(), err
 }
 
@@ -190,7 +521,16 @@ GetAltitude()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__altitude
 */
 func (t *Telescope) GetAltitude() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "altitude")
+	return t.GetAltitudeContext(context.Background())
+}
+
+/*
+GetAltitudeContext()
+
+GetAltitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetAltitudeContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "altitude")
 }
 
 /*
@@ -200,7 +540,16 @@ GetApertureArea()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__aperturearea
 */
 func (t *Telescope) GetApertureArea() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "aperturearea")
+	return t.GetApertureAreaContext(context.Background())
+}
+
+/*
+GetApertureAreaContext()
+
+GetApertureArea() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetApertureAreaContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "aperturearea")
 }
 
 /*
@@ -210,7 +559,16 @@ GetApertureDiameter()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__aperturediameter
 */
 func (t *Telescope) GetApertureDiameter() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "aperturearea")
+	return t.GetApertureDiameterContext(context.Background())
+}
+
+/*
+GetApertureDiameterContext()
+
+GetApertureDiameter() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetApertureDiameterContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "aperturediameter")
 }
 
 /*
@@ -220,20 +578,24 @@ GetAxisRates()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__axisrates
 */
 func (t *Telescope) GetAxisRates(axis AxisType) (map[string]float64, error) {
-	url := t.Alpaca.getEndpoint("telescope", t.DeviceNumber, "axisrates")
+	return t.GetAxisRatesContext(context.Background(), axis)
+}
 
-	querystring := fmt.Sprintf("axis=%d&%s", axis, t.Alpaca.getQueryString())
+/*
+GetAxisRatesContext()
 
-	// Setup the resty client:
-	resp, err := t.Alpaca.Client.R().SetResult(&AxisRatesResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+GetAxisRates() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetAxisRatesContext(ctx context.Context, axis AxisType) (map[string]float64, error) {
+	result := &AxisRatesResponse{}
 
-	// If the response object has a REST error:
-	if err != nil {
+	if err := t.Alpaca.get(ctx, "telescope", t.DeviceNumber, "axisrates", map[string]string{"axis": fmt.Sprintf("%d", axis)}, result); err != nil {
 		return map[string]float64{}, err
 	}
 
-	// Return the result:
-	result := (resp.Result().(*AxisRatesResponse))
+	if len(result.Value) == 0 {
+		return map[string]float64{}, nil
+	}
 
 	return result.Value[0], nil
 }
@@ -245,7 +607,16 @@ IsAtHome()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__athome
 */
 func (t *Telescope) IsAtHome() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "athome")
+	return t.IsAtHomeContext(context.Background())
+}
+
+/*
+IsAtHomeContext()
+
+IsAtHome() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) IsAtHomeContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "athome")
 }
 
 /*
@@ -255,7 +626,16 @@ IsAtPark()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__atpark
 */
 func (t *Telescope) IsAtPark() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "atpark")
+	return t.IsAtParkContext(context.Background())
+}
+
+/*
+IsAtParkContext()
+
+IsAtPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) IsAtParkContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "atpark")
 }
 
 /*
@@ -265,7 +645,16 @@ GetAzimuth()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__azimuth
 */
 func (t *Telescope) GetAzimuth() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "azimuth")
+	return t.GetAzimuthContext(context.Background())
+}
+
+/*
+GetAzimuthContext()
+
+GetAzimuth() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetAzimuthContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "azimuth")
 }
 
 /*
@@ -275,7 +664,16 @@ CanFindHome()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canfindhome
 */
 func (t *Telescope) CanFindHome() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canfindhome")
+	return t.CanFindHomeContext(context.Background())
+}
+
+/*
+CanFindHomeContext()
+
+CanFindHome() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanFindHomeContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canfindhome")
 }
 
 /*
@@ -285,14 +683,18 @@ FindHome()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__findhome
 */
 func (t *Telescope) FindHome() error {
-	t.Alpaca.TransactionId++
+	return t.FindHomeContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
-	}
+/*
+FindHomeContext()
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "findhome", form)
+FindHome() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) FindHomeContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
+
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "findhome", form)
 }
 
 /*
@@ -302,26 +704,21 @@ CanMoveAxis()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canmoveaxis
 */
 func (t *Telescope) CanMoveAxis(axis AxisType) (bool, error) {
-	url := t.Alpaca.getEndpoint("telescope", t.DeviceNumber, "canmoveaxis")
+	return t.CanMoveAxisContext(context.Background(), axis)
+}
 
-	querystring := fmt.Sprintf("axis=%d&%s", axis, t.Alpaca.getQueryString())
+/*
+CanMoveAxisContext()
 
-	// Setup the resty client:
-	resp, err := t.Alpaca.Client.R().SetResult(&booleanResponse{}).SetQueryString(querystring).SetHeader("Accept", "application/json").Get(url)
+CanMoveAxis() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanMoveAxisContext(ctx context.Context, axis AxisType) (bool, error) {
+	result := &booleanResponse{}
 
-	if err != nil {
+	if err := t.Alpaca.get(ctx, "telescope", t.DeviceNumber, "canmoveaxis", map[string]string{"axis": fmt.Sprintf("%d", axis)}, result); err != nil {
 		return false, err
 	}
 
-	// If the response object has a REST error:
-	if resp.IsError() {
-		t.Alpaca.ErrorNumber = resp.StatusCode()
-		t.Alpaca.ErrorMessage = resp.String()
-	}
-
-	// Return the result:
-	result := (resp.Result().(*booleanResponse))
-
 	return result.Value, nil
 }
 
@@ -332,7 +729,16 @@ CanPark()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canpark
 */
 func (t *Telescope) CanPark() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canpark")
+	return t.CanParkContext(context.Background())
+}
+
+/*
+CanParkContext()
+
+CanPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanParkContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canpark")
 }
 
 /*
@@ -342,7 +748,16 @@ CanPulseGuide()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canpulseguide
 */
 func (t *Telescope) CanPulseGuide() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canpulseguide")
+	return t.CanPulseGuideContext(context.Background())
+}
+
+/*
+CanPulseGuideContext()
+
+CanPulseGuide() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanPulseGuideContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canpulseguide")
 }
 
 /*
@@ -352,7 +767,16 @@ CanSetDeclinationRate()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansetdeclinationrate
 */
 func (t *Telescope) CanSetDeclinationRate() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansetdeclinationrate")
+	return t.CanSetDeclinationRateContext(context.Background())
+}
+
+/*
+CanSetDeclinationRateContext()
+
+CanSetDeclinationRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSetDeclinationRateContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansetdeclinationrate")
 }
 
 /*
@@ -362,7 +786,16 @@ CanSetGuideRates()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansetguiderates
 */
 func (t *Telescope) CanSetGuideRates() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansetguiderates")
+	return t.CanSetGuideRatesContext(context.Background())
+}
+
+/*
+CanSetGuideRatesContext()
+
+CanSetGuideRates() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSetGuideRatesContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansetguiderates")
 }
 
 /*
@@ -372,7 +805,16 @@ CanSetPark()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansetpark
 */
 func (t *Telescope) CanSetPark() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansetpark")
+	return t.CanSetParkContext(context.Background())
+}
+
+/*
+CanSetParkContext()
+
+CanSetPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSetParkContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansetpark")
 }
 
 /*
@@ -382,7 +824,16 @@ CanSetPierSide()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansetpierside
 */
 func (t *Telescope) CanSetPierSide() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansetpierside")
+	return t.CanSetPierSideContext(context.Background())
+}
+
+/*
+CanSetPierSideContext()
+
+CanSetPierSide() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSetPierSideContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansetpierside")
 }
 
 /*
@@ -392,7 +843,16 @@ CanSetRightAscensionRate()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansetrightascensionrate
 */
 func (t *Telescope) CanSetRightAscensionRate() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansetrightascensionrate")
+	return t.CanSetRightAscensionRateContext(context.Background())
+}
+
+/*
+CanSetRightAscensionRateContext()
+
+CanSetRightAscensionRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSetRightAscensionRateContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansetrightascensionrate")
 }
 
 /*
@@ -402,7 +862,16 @@ CanSetTracking()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansettracking
 */
 func (t *Telescope) CanSetTracking() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansettracking")
+	return t.CanSetTrackingContext(context.Background())
+}
+
+/*
+CanSetTrackingContext()
+
+CanSetTracking() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSetTrackingContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansettracking")
 }
 
 /*
@@ -412,7 +881,16 @@ CanSlew()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canslew
 */
 func (t *Telescope) CanSlew() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canslew")
+	return t.CanSlewContext(context.Background())
+}
+
+/*
+CanSlewContext()
+
+CanSlew() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSlewContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canslew")
 }
 
 /*
@@ -422,7 +900,16 @@ CanSlewAltAz()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canslewaltaz
 */
 func (t *Telescope) CanSlewAltAz() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canslewaltaz")
+	return t.CanSlewAltAzContext(context.Background())
+}
+
+/*
+CanSlewAltAzContext()
+
+CanSlewAltAz() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSlewAltAzContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canslewaltaz")
 }
 
 /*
@@ -432,7 +919,16 @@ CanSlewAltAzAsync()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canslewaltazasync
 */
 func (t *Telescope) CanSlewAltAzAsync() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canslewaltazasync")
+	return t.CanSlewAltAzAsyncContext(context.Background())
+}
+
+/*
+CanSlewAltAzAsyncContext()
+
+CanSlewAltAzAsync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSlewAltAzAsyncContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canslewaltazasync")
 }
 
 /*
@@ -442,7 +938,16 @@ CanSlewAsync()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canslewasync
 */
 func (t *Telescope) CanSlewAsync() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canslewasync")
+	return t.CanSlewAsyncContext(context.Background())
+}
+
+/*
+CanSlewAsyncContext()
+
+CanSlewAsync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSlewAsyncContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canslewasync")
 }
 
 /*
@@ -452,7 +957,16 @@ CanSync()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansync
 */
 func (t *Telescope) CanSync() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansync")
+	return t.CanSyncContext(context.Background())
+}
+
+/*
+CanSyncContext()
+
+CanSync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSyncContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansync")
 }
 
 /*
@@ -462,7 +976,16 @@ CanSyncAltAz()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__cansyncaltaz
 */
 func (t *Telescope) CanSyncAltAz() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "cansyncaltaz")
+	return t.CanSyncAltAzContext(context.Background())
+}
+
+/*
+CanSyncAltAzContext()
+
+CanSyncAltAz() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanSyncAltAzContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "cansyncaltaz")
 }
 
 /*
@@ -472,7 +995,16 @@ CanUnPark()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__canunpark
 */
 func (t *Telescope) CanUnPark() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "canunpark")
+	return t.CanUnParkContext(context.Background())
+}
+
+/*
+CanUnParkContext()
+
+CanUnPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) CanUnParkContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "canunpark")
 }
 
 /*
@@ -482,14 +1014,18 @@ SetPark()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__unpark
 */
 func (t *Telescope) SetPark() error {
-	t.Alpaca.TransactionId++
+	return t.SetParkContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
-	}
+/*
+SetParkContext()
+
+SetPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetParkContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "park", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "park", form)
 }
 
 /*
@@ -500,14 +1036,18 @@ SetUnPark()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__unpark
 */
 func (t *Telescope) SetUnPark() error {
-	t.Alpaca.TransactionId++
+	return t.SetUnParkContext(context.Background())
+}
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
-	}
+/*
+SetUnParkContext()
+
+SetUnPark() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetUnParkContext(ctx context.Context) error {
+	var form map[string]string = map[string]string{}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "unpark", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "unpark", form)
 }
 
 /*
@@ -519,7 +1059,16 @@ is unavailable.
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__declination
 */
 func (t *Telescope) GetDeclination() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "declination")
+	return t.GetDeclinationContext(context.Background())
+}
+
+/*
+GetDeclinationContext()
+
+GetDeclination() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetDeclinationContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "declination")
 }
 
 /*
@@ -529,7 +1078,16 @@ GetDeclinationRate()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__declinationrate
 */
 func (t *Telescope) GetDeclinationRate() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "declinationrate")
+	return t.GetDeclinationRateContext(context.Background())
+}
+
+/*
+GetDeclinationRateContext()
+
+GetDeclinationRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetDeclinationRateContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "declinationrate")
 }
 
 /*
@@ -539,15 +1097,20 @@ SetDeclinationRate()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__declinationrate
 */
 func (t *Telescope) SetDeclinationRate(declinationRate float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetDeclinationRateContext(context.Background(), declinationRate)
+}
+
+/*
+SetDeclinationRateContext()
 
+SetDeclinationRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetDeclinationRateContext(ctx context.Context, declinationRate float64) error {
 	var form map[string]string = map[string]string{
-		"DeclinationRate":     fmt.Sprintf("%f", declinationRate),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"DeclinationRate": fmt.Sprintf("%f", declinationRate),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "declinationrate", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "declinationrate", form)
 }
 
 /*
@@ -557,7 +1120,16 @@ DoesRefraction()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__doesrefraction
 */
 func (t *Telescope) DoesRefraction() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "doesrefraction")
+	return t.DoesRefractionContext(context.Background())
+}
+
+/*
+DoesRefractionContext()
+
+DoesRefraction() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) DoesRefractionContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "doesrefraction")
 }
 
 /*
@@ -569,15 +1141,20 @@ Determines whether atmospheric refraction is applied to coordinates.
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__doesrefraction
 */
 func (t *Telescope) SetDoesRefraction(doesRefraction bool) error {
-	t.Alpaca.TransactionId++
+	return t.SetDoesRefractionContext(context.Background(), doesRefraction)
+}
 
+/*
+SetDoesRefractionContext()
+
+SetDoesRefraction() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetDoesRefractionContext(ctx context.Context, doesRefraction bool) error {
 	var form map[string]string = map[string]string{
-		"DoesRefraction":      fmt.Sprintf("%t", doesRefraction),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"DoesRefraction": fmt.Sprintf("%t", doesRefraction),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "doesrefraction", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "doesrefraction", form)
 }
 
 /*
@@ -587,7 +1164,16 @@ GetEquatorialSystem()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__equatorialsystem
 */
 func (t *Telescope) GetEquatorialSystem() (string, error) {
-	system, err := t.Alpaca.GetInt32Response("telescope", t.DeviceNumber, "equatorialsystem")
+	return t.GetEquatorialSystemContext(context.Background())
+}
+
+/*
+GetEquatorialSystemContext()
+
+GetEquatorialSystem() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetEquatorialSystemContext(ctx context.Context) (string, error) {
+	system, err := t.Alpaca.GetInt32ResponseContext(ctx, "telescope", t.DeviceNumber, "equatorialsystem")
 	return EquatorialSystem(system).String(), err
 }
 
@@ -598,7 +1184,16 @@ GetFocalLength()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__focallength
 */
 func (t *Telescope) GetFocalLength() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "focallength")
+	return t.GetFocalLengthContext(context.Background())
+}
+
+/*
+GetFocalLengthContext()
+
+GetFocalLength() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetFocalLengthContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "focallength")
 }
 
 /*
@@ -608,7 +1203,41 @@ IsPulseGuiding()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__ispulseguiding
 */
 func (t *Telescope) IsPulseGuiding() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "ispulseguiding")
+	return t.IsPulseGuidingContext(context.Background())
+}
+
+/*
+IsPulseGuidingContext()
+
+IsPulseGuiding() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) IsPulseGuidingContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "ispulseguiding")
+}
+
+/*
+SetPulseGuide()
+
+@returns an error or nil, if nil moves the scope in the given direction for the given interval or time at the rate
+given by the corresponding guide rate property
+@see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__pulseguide
+*/
+func (t *Telescope) SetPulseGuide(direction Direction, duration int32) error {
+	return t.SetPulseGuideContext(context.Background(), direction, duration)
+}
+
+/*
+SetPulseGuideContext()
+
+SetPulseGuide() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetPulseGuideContext(ctx context.Context, direction Direction, duration int32) error {
+	var form map[string]string = map[string]string{
+		"Direction": fmt.Sprintf("%d", direction),
+		"Duration":  fmt.Sprintf("%d", duration),
+	}
+
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "pulseguide", form)
 }
 
 /*
@@ -619,7 +1248,16 @@ system given by the EquatorialSystem property
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__rightascension
 */
 func (t *Telescope) GetRightAscension() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "rightascension")
+	return t.GetRightAscensionContext(context.Background())
+}
+
+/*
+GetRightAscensionContext()
+
+GetRightAscension() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetRightAscensionContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "rightascension")
 }
 
 /*
@@ -629,7 +1267,16 @@ GetRightAscensionRate()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__rightascensionrate
 */
 func (t *Telescope) GetRightAscensionRate() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "rightascensionrate")
+	return t.GetRightAscensionRateContext(context.Background())
+}
+
+/*
+GetRightAscensionRateContext()
+
+GetRightAscensionRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetRightAscensionRateContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "rightascensionrate")
 }
 
 /*
@@ -639,15 +1286,20 @@ SetRightAscensionRate()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__rightascensionrate
 */
 func (t *Telescope) SetRightAscensionRate(rightAscensionRate float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetRightAscensionRateContext(context.Background(), rightAscensionRate)
+}
 
+/*
+SetRightAscensionRateContext()
+
+SetRightAscensionRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetRightAscensionRateContext(ctx context.Context, rightAscensionRate float64) error {
 	var form map[string]string = map[string]string{
-		"RightAscensionRate":  fmt.Sprintf("%f", rightAscensionRate),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"RightAscensionRate": fmt.Sprintf("%f", rightAscensionRate),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "rightascensionrate", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "rightascensionrate", form)
 }
 
 /*
@@ -657,7 +1309,16 @@ GetSideOfPier()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__sideofpier
 */
 func (t *Telescope) GetSideOfPier() (PierPointingMode, error) {
-	mode, err := t.Alpaca.GetInt32Response("telescope", t.DeviceNumber, "sideofpier")
+	return t.GetSideOfPierContext(context.Background())
+}
+
+/*
+GetSideOfPierContext()
+
+GetSideOfPier() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetSideOfPierContext(ctx context.Context) (PierPointingMode, error) {
+	mode, err := t.Alpaca.GetInt32ResponseContext(ctx, "telescope", t.DeviceNumber, "sideofpier")
 	return PierPointingMode(mode), err
 }
 
@@ -668,19 +1329,24 @@ SetSideOfPier()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__sideofpier
 */
 func (t *Telescope) SetSideOfPier(sideOfPier PierPointingMode) error {
-	t.Alpaca.TransactionId++
+	return t.SetSideOfPierContext(context.Background(), sideOfPier)
+}
+
+/*
+SetSideOfPierContext()
 
+SetSideOfPier() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSideOfPierContext(ctx context.Context, sideOfPier PierPointingMode) error {
 	if sideOfPier != 1 && sideOfPier != 0 {
 		return errors.New("please provide a valid pointing state for the mount e.g., eiher 0 = pierEast, 1 = pierWest")
 	}
 
 	var form map[string]string = map[string]string{
-		"SideOfPier":          fmt.Sprintf("%d", sideOfPier),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"SideOfPier": fmt.Sprintf("%d", sideOfPier),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "sideofpier", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "sideofpier", form)
 }
 
 /*
@@ -690,7 +1356,16 @@ GetSiderealTime()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__siderealtime
 */
 func (t *Telescope) GetSiderealTime() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "siderealtime")
+	return t.GetSiderealTimeContext(context.Background())
+}
+
+/*
+GetSiderealTimeContext()
+
+GetSiderealTime() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetSiderealTimeContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "siderealtime")
 }
 
 /*
@@ -700,7 +1375,16 @@ GetSiteElevation()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__siteelevation
 */
 func (t *Telescope) GetSiteElevation() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "siteelevation")
+	return t.GetSiteElevationContext(context.Background())
+}
+
+/*
+GetSiteElevationContext()
+
+GetSiteElevation() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetSiteElevationContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "siteelevation")
 }
 
 /*
@@ -711,19 +1395,24 @@ SetSiteElevation()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__siteelevation
 */
 func (t *Telescope) SetSiteElevation(siteElevation float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetSiteElevationContext(context.Background(), siteElevation)
+}
+
+/*
+SetSiteElevationContext()
 
+SetSiteElevation() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSiteElevationContext(ctx context.Context, siteElevation float64) error {
 	if siteElevation < -1000 || siteElevation > 10000 {
 		return errors.New("please provide a realistic site elevation, e.g., greater than or equal to -1000m, but less than 10000m relative to mean sea level")
 	}
 
 	var form map[string]string = map[string]string{
-		"SiteElevation":       fmt.Sprintf("%f", siteElevation),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"SiteElevation": fmt.Sprintf("%f", siteElevation),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "siteelevation", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "siteelevation", form)
 }
 
 /*
@@ -733,7 +1422,16 @@ GetSiteLatitude()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__sitelatitude
 */
 func (t *Telescope) GetSiteLatitude() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "sitelatitude")
+	return t.GetSiteLatitudeContext(context.Background())
+}
+
+/*
+GetSiteLatitudeContext()
+
+GetSiteLatitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetSiteLatitudeContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "sitelatitude")
 }
 
 /*
@@ -743,19 +1441,24 @@ SetSiteLatitude()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__sitelatitude
 */
 func (t *Telescope) SetSiteLatitude(siteLatitude float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetSiteLatitudeContext(context.Background(), siteLatitude)
+}
 
+/*
+SetSiteLatitudeContext()
+
+SetSiteLatitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSiteLatitudeContext(ctx context.Context, siteLatitude float64) error {
 	if siteLatitude <= -90 || siteLatitude >= 90 {
 		return errors.New("please provide a valid latitude between -90° and +90°")
 	}
 
 	var form map[string]string = map[string]string{
-		"SiteLatitude":        fmt.Sprintf("%f", siteLatitude),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"SiteLatitude": fmt.Sprintf("%f", siteLatitude),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "sitelatitude", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "sitelatitude", form)
 }
 
 /*
@@ -765,7 +1468,16 @@ GetSiteLongitude()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__sitelongitude
 */
 func (t *Telescope) GetSiteLongitude() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "sitelongitude")
+	return t.GetSiteLongitudeContext(context.Background())
+}
+
+/*
+GetSiteLongitudeContext()
+
+GetSiteLongitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetSiteLongitudeContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "sitelongitude")
 }
 
 /*
@@ -775,19 +1487,24 @@ SetSiteLongitude()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__sitelongitude
 */
 func (t *Telescope) SetSiteLongitude(siteLongitude float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetSiteLongitudeContext(context.Background(), siteLongitude)
+}
+
+/*
+SetSiteLongitudeContext()
 
+SetSiteLongitude() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSiteLongitudeContext(ctx context.Context, siteLongitude float64) error {
 	if siteLongitude <= -180 || siteLongitude >= 180 {
 		return errors.New("please provide a valid longitude between -180° and +180°")
 	}
 
 	var form map[string]string = map[string]string{
-		"SiteLongitude":       fmt.Sprintf("%f", siteLongitude),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"SiteLongitude": fmt.Sprintf("%f", siteLongitude),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "sitelongitude", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "sitelongitude", form)
 }
 
 /*
@@ -798,7 +1515,16 @@ Double) method, false at all other times.
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__slewing
 */
 func (t *Telescope) IsSlewing() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "slewing")
+	return t.IsSlewingContext(context.Background())
+}
+
+/*
+IsSlewingContext()
+
+IsSlewing() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) IsSlewingContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "slewing")
 }
 
 /*
@@ -808,7 +1534,16 @@ GetSlewSettleTime()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__slewsettletime
 */
 func (t *Telescope) GetSlewSettleTime() (int32, error) {
-	return t.Alpaca.GetInt32Response("telescope", t.DeviceNumber, "slewsettletime")
+	return t.GetSlewSettleTimeContext(context.Background())
+}
+
+/*
+GetSlewSettleTimeContext()
+
+GetSlewSettleTime() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetSlewSettleTimeContext(ctx context.Context) (int32, error) {
+	return t.Alpaca.GetInt32ResponseContext(ctx, "telescope", t.DeviceNumber, "slewsettletime")
 }
 
 /*
@@ -818,15 +1553,20 @@ SetSlewSettleTime()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__slewsettletime
 */
 func (t *Telescope) SetSlewSettleTime(slewSettleTime int32) error {
-	t.Alpaca.TransactionId++
+	return t.SetSlewSettleTimeContext(context.Background(), slewSettleTime)
+}
 
+/*
+SetSlewSettleTimeContext()
+
+SetSlewSettleTime() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSlewSettleTimeContext(ctx context.Context, slewSettleTime int32) error {
 	var form map[string]string = map[string]string{
-		"SlewSettleTime":      fmt.Sprintf("%d", slewSettleTime),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"SlewSettleTime": fmt.Sprintf("%d", slewSettleTime),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "slewsettletime", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "slewsettletime", form)
 }
 
 /*
@@ -836,9 +1576,16 @@ SetSlewToAltAz
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__slewtoaltaz
 */
 func (t *Telescope) SetSlewToAltAz(altitude float64, azimuth float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetSlewToAltAzContext(context.Background(), altitude, azimuth)
+}
 
-	t.SetTracking(false)
+/*
+SetSlewToAltAzContext()
+
+SetSlewToAltAz() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSlewToAltAzContext(ctx context.Context, altitude float64, azimuth float64) error {
+	t.SetTrackingContext(ctx, false)
 
 	if altitude < -90 || altitude > 90 {
 		return errors.New("please provide a valid altitude between -90° and +90°")
@@ -849,13 +1596,11 @@ func (t *Telescope) SetSlewToAltAz(altitude float64, azimuth float64) error {
 	}
 
 	var form map[string]string = map[string]string{
-		"Altitude":            fmt.Sprintf("%f", altitude),
-		"Azimuth":             fmt.Sprintf("%f", azimuth),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"Altitude": fmt.Sprintf("%f", altitude),
+		"Azimuth":  fmt.Sprintf("%f", azimuth),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "slewtoaltaz", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "slewtoaltaz", form)
 }
 
 /*
@@ -866,9 +1611,16 @@ the slew starts. The client can poll the Slewing method to determine when the mo
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__slewtoaltazasync
 */
 func (t *Telescope) SetSlewToAltAzAsync(altitude float64, azimuth float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetSlewToAltAzAsyncContext(context.Background(), altitude, azimuth)
+}
 
-	t.SetTracking(false)
+/*
+SetSlewToAltAzAsyncContext()
+
+SetSlewToAltAzAsync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSlewToAltAzAsyncContext(ctx context.Context, altitude float64, azimuth float64) error {
+	t.SetTrackingContext(ctx, false)
 
 	if altitude < -90 || altitude > 90 {
 		return errors.New("please provide a valid altitude between -90° and +90°")
@@ -879,13 +1631,11 @@ func (t *Telescope) SetSlewToAltAzAsync(altitude float64, azimuth float64) error
 	}
 
 	var form map[string]string = map[string]string{
-		"Altitude":            fmt.Sprintf("%f", altitude),
-		"Azimuth":             fmt.Sprintf("%f", azimuth),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"Altitude": fmt.Sprintf("%f", altitude),
+		"Azimuth":  fmt.Sprintf("%f", azimuth),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "slewtoaltazasync", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "slewtoaltazasync", form)
 }
 
 /*
@@ -896,9 +1646,16 @@ the slew starts. The client can poll the Slewing method to determine when the mo
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__slewtocoordinates
 */
 func (t *Telescope) SetSlewToCoordinates(rightAscension float64, declination float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetSlewToCoordinatesContext(context.Background(), rightAscension, declination)
+}
+
+/*
+SetSlewToCoordinatesContext()
 
-	t.SetTracking(true)
+SetSlewToCoordinates() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSlewToCoordinatesContext(ctx context.Context, rightAscension float64, declination float64) error {
+	t.SetTrackingContext(ctx, true)
 
 	if declination < -90 || declination > 90 {
 		return errors.New("please provide a valid altitude between -90° and +90°")
@@ -911,13 +1668,11 @@ func (t *Telescope) SetSlewToCoordinates(rightAscension float64, declination flo
 	rightAscension /= 15
 
 	var form map[string]string = map[string]string{
-		"RightAscension":      fmt.Sprintf("%f", rightAscension),
-		"Declination":         fmt.Sprintf("%f", declination),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"RightAscension": fmt.Sprintf("%f", rightAscension),
+		"Declination":    fmt.Sprintf("%f", declination),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "slewtocoordinates", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "slewtocoordinates", form)
 }
 
 /*
@@ -928,9 +1683,16 @@ the slew starts. The client can poll the Slewing method to determine when the mo
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__slewtocoordinatesasync
 */
 func (t *Telescope) SetSlewToCoordinatesAsync(rightAscension float64, declination float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetSlewToCoordinatesAsyncContext(context.Background(), rightAscension, declination)
+}
+
+/*
+SetSlewToCoordinatesAsyncContext()
 
-	t.SetTracking(true)
+SetSlewToCoordinatesAsync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSlewToCoordinatesAsyncContext(ctx context.Context, rightAscension float64, declination float64) error {
+	t.SetTrackingContext(ctx, true)
 
 	if declination < -90 || declination > 90 {
 		return errors.New("please provide a valid altitude between -90° and +90°")
@@ -943,13 +1705,11 @@ func (t *Telescope) SetSlewToCoordinatesAsync(rightAscension float64, declinatio
 	rightAscension /= 15
 
 	var form map[string]string = map[string]string{
-		"RightAscension":      fmt.Sprintf("%f", rightAscension),
-		"Declination":         fmt.Sprintf("%f", declination),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"RightAscension": fmt.Sprintf("%f", rightAscension),
+		"Declination":    fmt.Sprintf("%f", declination),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "slewtocoordinatesasync", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "slewtocoordinatesasync", form)
 }
 
 /*
@@ -960,16 +1720,20 @@ equatorial coordinates, return when slew is complete
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__slewtotarget
 */
 func (t *Telescope) SetSlewToTarget() error {
-	t.Alpaca.TransactionId++
+	return t.SetSlewToTargetContext(context.Background())
+}
 
-	t.SetTracking(true)
+/*
+SetSlewToTargetContext()
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
-	}
+SetSlewToTarget() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSlewToTargetContext(ctx context.Context) error {
+	t.SetTrackingContext(ctx, true)
+
+	var form map[string]string = map[string]string{}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "slewtotarget", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "slewtotarget", form)
 }
 
 /*
@@ -981,16 +1745,20 @@ intended coordinates.
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__slewtotargetasync
 */
 func (t *Telescope) SetSlewToTargetAsync() error {
-	t.Alpaca.TransactionId++
+	return t.SetSlewToTargetAsyncContext(context.Background())
+}
 
-	t.SetTracking(true)
+/*
+SetSlewToTargetAsyncContext()
 
-	var form map[string]string = map[string]string{
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
-	}
+SetSlewToTargetAsync() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetSlewToTargetAsyncContext(ctx context.Context) error {
+	t.SetTrackingContext(ctx, true)
+
+	var form map[string]string = map[string]string{}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "slewtotargetasync", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "slewtotargetasync", form)
 }
 
 /*
@@ -1000,7 +1768,16 @@ GetTargetDeclination()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__targetdeclination
 */
 func (t *Telescope) GetTargetDeclination() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "targetdeclination")
+	return t.GetTargetDeclinationContext(context.Background())
+}
+
+/*
+GetTargetDeclinationContext()
+
+GetTargetDeclination() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetTargetDeclinationContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "targetdeclination")
 }
 
 /*
@@ -1010,15 +1787,20 @@ SetTargetDeclination()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__targetdeclination
 */
 func (t *Telescope) SetTargetDeclination(targetDeclination float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetTargetDeclinationContext(context.Background(), targetDeclination)
+}
+
+/*
+SetTargetDeclinationContext()
 
+SetTargetDeclination() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetTargetDeclinationContext(ctx context.Context, targetDeclination float64) error {
 	var form map[string]string = map[string]string{
-		"TargetDeclination":   fmt.Sprintf("%f", targetDeclination),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"TargetDeclination": fmt.Sprintf("%f", targetDeclination),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "targetdeclination", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "targetdeclination", form)
 }
 
 /*
@@ -1028,7 +1810,16 @@ GetTargetRightAscension()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__targetrightascension
 */
 func (t *Telescope) GetTargetRightAscension() (float64, error) {
-	return t.Alpaca.GetFloat64Response("telescope", t.DeviceNumber, "targetrightascension")
+	return t.GetTargetRightAscensionContext(context.Background())
+}
+
+/*
+GetTargetRightAscensionContext()
+
+GetTargetRightAscension() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetTargetRightAscensionContext(ctx context.Context) (float64, error) {
+	return t.Alpaca.GetFloat64ResponseContext(ctx, "telescope", t.DeviceNumber, "targetrightascension")
 }
 
 /*
@@ -1038,15 +1829,20 @@ SetTargetRightAscension()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__targetrightascension
 */
 func (t *Telescope) SetTargetRightAscension(targetRightAscension float64) error {
-	t.Alpaca.TransactionId++
+	return t.SetTargetRightAscensionContext(context.Background(), targetRightAscension)
+}
 
+/*
+SetTargetRightAscensionContext()
+
+SetTargetRightAscension() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetTargetRightAscensionContext(ctx context.Context, targetRightAscension float64) error {
 	var form map[string]string = map[string]string{
 		"TargetRightAscension": fmt.Sprintf("%f", targetRightAscension),
-		"ClientID":             fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID":  fmt.Sprintf("%d", t.Alpaca.TransactionId),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "targetrightascension", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "targetrightascension", form)
 }
 
 /*
@@ -1056,7 +1852,16 @@ IsTracking()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__tracking
 */
 func (t *Telescope) IsTracking() (bool, error) {
-	return t.Alpaca.GetBooleanResponse("telescope", t.DeviceNumber, "tracking")
+	return t.IsTrackingContext(context.Background())
+}
+
+/*
+IsTrackingContext()
+
+IsTracking() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) IsTrackingContext(ctx context.Context) (bool, error) {
+	return t.Alpaca.GetBooleanResponseContext(ctx, "telescope", t.DeviceNumber, "tracking")
 }
 
 /*
@@ -1066,15 +1871,20 @@ SetTracking()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__tracking
 */
 func (t *Telescope) SetTracking(tracking bool) error {
-	t.Alpaca.TransactionId++
+	return t.SetTrackingContext(context.Background(), tracking)
+}
+
+/*
+SetTrackingContext()
 
+SetTracking() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetTrackingContext(ctx context.Context, tracking bool) error {
 	var form map[string]string = map[string]string{
-		"Tracking":            fmt.Sprintf("%t", tracking),
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"Tracking": fmt.Sprintf("%t", tracking),
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "tracking", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "tracking", form)
 }
 
 /*
@@ -1084,7 +1894,16 @@ GetTrackingRate()
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__trackingrate
 */
 func (t *Telescope) GetTrackingRate() (int32, error) {
-	return t.Alpaca.GetInt32Response("telescope", t.DeviceNumber, "trackingrate")
+	return t.GetTrackingRateContext(context.Background())
+}
+
+/*
+GetTrackingRateContext()
+
+GetTrackingRate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetTrackingRateContext(ctx context.Context) (int32, error) {
+	return t.Alpaca.GetInt32ResponseContext(ctx, "telescope", t.DeviceNumber, "trackingrate")
 }
 
 /*
@@ -1097,7 +1916,16 @@ Z indicating the 'Zulu', UTC time zone.
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/get_telescope__device_number__utcdate
 */
 func (t *Telescope) GetUTCDate() (time.Time, error) {
-	utc, err := t.Alpaca.GetStringResponse("telescope", t.DeviceNumber, "utcdate")
+	return t.GetUTCDateContext(context.Background())
+}
+
+/*
+GetUTCDateContext()
+
+GetUTCDate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) GetUTCDateContext(ctx context.Context) (time.Time, error) {
+	utc, err := t.Alpaca.GetStringResponseContext(ctx, "telescope", t.DeviceNumber, "utcdate")
 
 	if err != nil {
 		return time.Time{}, err
@@ -1133,16 +1961,21 @@ Z indicating the 'Zulu', UTC time zone.
 @see https://ascom-standards.org/api/#/Telescope%20Specific%20Methods/put_telescope__device_number__utcdate
 */
 func (t *Telescope) SetUTCDate(UTCDate time.Time) error {
-	t.Alpaca.TransactionId++
+	return t.SetUTCDateContext(context.Background(), UTCDate)
+}
 
+/*
+SetUTCDateContext()
+
+SetUTCDate() with a context, which controls the cancellation and deadline of the request.
+*/
+func (t *Telescope) SetUTCDateContext(ctx context.Context, UTCDate time.Time) error {
 	// Don't ask, just read: https://go.dev/src/time/format.go
 	date := UTCDate.Format("2006-01-02T15:04:05.000000000Z")
 
 	var form map[string]string = map[string]string{
-		"UTCDate":             date,
-		"ClientID":            fmt.Sprintf("%d", t.Alpaca.ClientId),
-		"ClientTransactionID": fmt.Sprintf("%d", t.Alpaca.TransactionId),
+		"UTCDate": date,
 	}
 
-	return t.Alpaca.Put("telescope", t.DeviceNumber, "utcdate", form)
+	return t.Alpaca.PutContext(ctx, "telescope", t.DeviceNumber, "utcdate", form)
 }
@@ -4,6 +4,8 @@ import (
 	"math"
 	"testing"
 	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
 )
 
 var latitude float64 = 19.820667
@@ -218,6 +220,35 @@ func TestNewTelescopeApertureDiameter(t *testing.T) {
 	}
 }
 
+func TestTelescopeGetApertureDiameterQueriesApertureDiameter(t *testing.T) {
+	server := alpacatest.NewServer(t, map[string]string{
+		"telescope/0/aperturediameter": "0.2",
+		"telescope/0/aperturearea":     "0.0269",
+	}).Strict()
+
+	telescope := NewTelescopeWithOptions(server.URL, 0, NotTracking)
+
+	got, err := telescope.GetApertureDiameter()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got != 0.2 {
+		t.Errorf("got %f, wanted %f", got, 0.2)
+	}
+
+	got, err = telescope.GetApertureArea()
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got != 0.0269 {
+		t.Errorf("got %f, wanted %f", got, 0.0269)
+	}
+}
+
 func TestNewTelescopeAtHome(t *testing.T) {
 	var got, err = telescope.IsAtHome()
 
@@ -737,6 +768,18 @@ func TestNewTelescopeIsPulseGuiding(t *testing.T) {
 	}
 }
 
+func TestNewTelescopeSetPulseGuide(t *testing.T) {
+	var err = telescope.SetPulseGuide(North, 50)
+
+	if err != nil {
+		t.Errorf("got %q", err)
+	}
+
+	if telescope.Alpaca.ErrorNumber != 0 {
+		t.Errorf("got %q", telescope.Alpaca.ErrorMessage)
+	}
+}
+
 func TestNewTelescopeRightAscension(t *testing.T) {
 	var got, err = telescope.GetRightAscension()
 
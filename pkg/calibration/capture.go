@@ -0,0 +1,179 @@
+package calibration
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+/*
+CaptureProgressFunc
+
+Called on every poll of the camera during CaptureSeries(), with the zero based index of the frame being exposed.
+*/
+type CaptureProgressFunc func(frame int, count int, progress alpacago.ExposureProgress)
+
+/*
+CaptureSeries()
+
+Exposes a series of calibration frames of the type, reading the key of each frame from the camera and filter
+wheel after its exposure. Bias, dark and dark flat frames are exposed with the shutter closed.
+
+@param frameType FrameType (the type of the frames)
+@param duration float64 (the duration of each exposure, in seconds)
+@param count int (the number of frames)
+@param camera *alpacago.Camera (the camera)
+@param filterWheel *alpacago.FilterWheel (the filter wheel, or nil if there is none)
+@param progress CaptureProgressFunc (called on every poll of the camera, may be nil)
+@returns the frames and their keys.
+*/
+func CaptureSeries(ctx context.Context, frameType FrameType, duration float64, count int, camera *alpacago.Camera, filterWheel *alpacago.FilterWheel, progress CaptureProgressFunc) ([]*alpacago.Image, []Key, error) {
+	images := make([]*alpacago.Image, 0, count)
+
+	keys := make([]Key, 0, count)
+
+	light := frameType == Light || frameType == Flat
+
+	for i := 0; i < count; i++ {
+		var p alpacago.ExposureProgressFunc
+
+		if progress != nil {
+			frame := i
+
+			p = func(e alpacago.ExposureProgress) {
+				progress(frame, count, e)
+			}
+		}
+
+		exposure, err := camera.ExposeContext(ctx, duration, light, p)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key, err := NewKeyForDevices(ctx, frameType, duration, camera, filterWheel)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		images = append(images, exposure.Image)
+		keys = append(keys, key)
+	}
+
+	return images, keys, nil
+}
+
+/*
+CreateMaster()
+
+Combines the frames into a master, whose key is the key of the first frame with the mean sensor temperature of
+every frame. Flat frames are first calibrated with the masters of the library, see Calibrate(), and normalised
+to a mean of one, so that flats of varying brightness, and exposure as in sky flats, may be combined.
+
+@param frames []*alpacago.Image (the frames)
+@param keys []Key (the key of each frame, which must share the settings of the first, and its exposure unless a flat)
+@param opts CombineOptions (the method of combination)
+@returns the master, which is not added to the library.
+*/
+func (l *Library) CreateMaster(frames []*alpacago.Image, keys []Key, opts CombineOptions) (*Master, error) {
+	if len(frames) == 0 || len(frames) != len(keys) {
+		return nil, fmt.Errorf("calibration: got %d frames and %d keys", len(frames), len(keys))
+	}
+
+	key := keys[0]
+
+	var sum float64
+
+	for _, k := range keys {
+		if k.Type != key.Type || !k.sameSettings(key) || (k.Type != Flat && !k.sameExposure(key)) || k.Filter != key.Filter {
+			return nil, fmt.Errorf("calibration: cannot combine a %s frame with a %s frame", k, key)
+		}
+
+		sum += k.Temperature
+	}
+
+	// The sum is NaN if any temperature is unknown:
+	key.Temperature = sum / float64(len(keys))
+
+	if key.Type == Flat {
+		calibrated := make([]*alpacago.Image, len(frames))
+
+		for i, frame := range frames {
+			image, _, err := l.Calibrate(frame, keys[i])
+
+			if err != nil {
+				return nil, err
+			}
+
+			normalise(image)
+
+			calibrated[i] = image
+		}
+
+		frames = calibrated
+	}
+
+	image, err := Combine(frames, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Type == Flat {
+		normalise(image)
+	}
+
+	// The temperature is rounded to a tenth of a degree, as in the file name of the master:
+	if !math.IsNaN(key.Temperature) {
+		key.Temperature = math.Round(key.Temperature*10) / 10
+	}
+
+	return &Master{
+		Key:     key,
+		Image:   image,
+		Count:   len(frames),
+		Created: time.Now(),
+	}, nil
+}
+
+/*
+CaptureMaster()
+
+Captures a series of frames of the type, see CaptureSeries(), combines them into a master, see CreateMaster(), and
+adds the master to the library. A duration of zero for bias frames selects the shortest exposure of the camera.
+
+@returns the master.
+*/
+func (l *Library) CaptureMaster(ctx context.Context, frameType FrameType, duration float64, count int, camera *alpacago.Camera, filterWheel *alpacago.FilterWheel, opts CombineOptions, progress CaptureProgressFunc) (*Master, error) {
+	if frameType == Light {
+		return nil, fmt.Errorf("calibration: cannot create a master light frame")
+	}
+
+	if frameType == Bias && duration == 0 {
+		min, err := camera.GetExposureMinContext(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		duration = min
+	}
+
+	frames, keys, err := CaptureSeries(ctx, frameType, duration, count, camera, filterWheel, progress)
+
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := l.CreateMaster(frames, keys, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return master, l.Add(master)
+}
@@ -0,0 +1,148 @@
+package calibration
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+type Method int
+
+const (
+	// The median of each pixel, which rejects outliers without any parameters:
+	Median Method = iota
+	// The mean of each pixel, which has the least noise but does not reject outliers:
+	Average
+	// The mean of each pixel after iteratively rejecting values beyond Kappa standard deviations from the median,
+	// where the standard deviation is estimated from the MAD, so that an outlier does not inflate it:
+	SigmaClip
+)
+
+func (m Method) String() string {
+	name := []string{"Median", "Average", "SigmaClip"}
+
+	if m < Median || m > SigmaClip {
+		return fmt.Sprintf("Unknown Method value: %d", m)
+	}
+
+	return name[m]
+}
+
+/*
+CombineOptions
+
+The options of Combine(). A zero value for Kappa or Iterations selects its default.
+*/
+type CombineOptions struct {
+	Method Method
+	// The rejection threshold of SigmaClip, in standard deviations (default 3):
+	Kappa float64
+	// The greatest number of rejection iterations of SigmaClip (default 5):
+	Iterations int
+}
+
+/*
+Combine()
+
+@param images []*alpacago.Image (the frames, which must all have the same dimensions)
+@param opts CombineOptions (the method of combination)
+@returns a single precision image, each pixel of which is the combination of that pixel of every frame.
+*/
+func Combine(images []*alpacago.Image, opts CombineOptions) (*alpacago.Image, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("calibration: no frames to combine")
+	}
+
+	if opts.Kappa == 0 {
+		opts.Kappa = 3
+	}
+
+	if opts.Iterations == 0 {
+		opts.Iterations = 5
+	}
+
+	first := images[0]
+
+	for _, image := range images[1:] {
+		if image.Width != first.Width || image.Height != first.Height || image.Planes != first.Planes {
+			return nil, fmt.Errorf("calibration: cannot combine a %dx%dx%d frame with a %dx%dx%d frame", image.Width, image.Height, image.Planes, first.Width, first.Height, first.Planes)
+		}
+	}
+
+	master := alpacago.NewImage(alpacago.ImageElementSingle, first.Width, first.Height, first.Planes)
+
+	values := make([]float64, len(images))
+
+	for i := range master.Pix {
+		for j, image := range images {
+			values[j] = image.Pix[i]
+		}
+
+		switch opts.Method {
+		case Median:
+			master.Pix[i] = stats.Median(values)
+		case Average:
+			master.Pix[i] = mean(values)
+		case SigmaClip:
+			master.Pix[i] = sigmaClippedMean(values, opts.Kappa, opts.Iterations)
+		default:
+			return nil, fmt.Errorf("calibration: unknown method %d", opts.Method)
+		}
+	}
+
+	return master, nil
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+/*
+sigmaClippedMean()
+
+@returns the mean of the values after rejecting, on each iteration, those further than kappa standard deviations
+from the median of the remaining values, until no value is rejected. The standard deviation is estimated from the
+median absolute deviation, rather than from the values themselves, as a single outlier among N values is never
+more than (N-1)/√N of their standard deviations from their mean, and so would never be rejected from fewer than
+ten frames with a kappa of 3. The values are reordered in place.
+*/
+func sigmaClippedMean(values []float64, kappa float64, iterations int) float64 {
+	kept := values
+
+	deviations := make([]float64, len(values))
+
+	for i := 0; i < iterations && len(kept) > 2; i++ {
+		median := stats.Median(kept)
+
+		for j, v := range kept {
+			deviations[j] = math.Abs(v - median)
+		}
+
+		sigma := stats.MAD_TO_SIGMA * stats.Median(deviations[:len(kept)])
+
+		n := 0
+
+		for _, v := range kept {
+			if math.Abs(v-median) <= kappa*sigma {
+				kept[n] = v
+				n++
+			}
+		}
+
+		if n == len(kept) || n == 0 {
+			break
+		}
+
+		kept = kept[:n]
+	}
+
+	return mean(kept)
+}
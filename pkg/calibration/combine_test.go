@@ -0,0 +1,81 @@
+package calibration
+
+import (
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+func newFrames(values ...float64) []*alpacago.Image {
+	frames := []*alpacago.Image{}
+
+	for _, v := range values {
+		image := alpacago.NewImage(alpacago.ImageElementInt32, 2, 1, 1)
+
+		image.Pix[0], image.Pix[1] = v, 2*v
+
+		frames = append(frames, image)
+	}
+
+	return frames
+}
+
+func TestCombine(t *testing.T) {
+	// Ten frames about 100, with a cosmic ray in the last:
+	frames := newFrames(98, 99, 100, 101, 102, 98, 99, 100, 101, 1000)
+
+	tests := []struct {
+		method Method
+		want   float64
+	}{
+		{Median, 100},
+		{Average, 189.8},
+		{SigmaClip, 99.777778},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method.String(), func(t *testing.T) {
+			got, err := Combine(frames, CombineOptions{Method: tt.method})
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if got.ElementType != alpacago.ImageElementSingle {
+				t.Errorf("got %v, wanted %v", got.ElementType, alpacago.ImageElementSingle)
+			}
+
+			if d := got.Pix[0] - tt.want; d > 1e-3 || d < -1e-3 {
+				t.Errorf("got %v, wanted %v", got.Pix[0], tt.want)
+			}
+
+			if d := got.Pix[1] - 2*tt.want; d > 1e-3 || d < -1e-3 {
+				t.Errorf("got %v, wanted %v", got.Pix[1], 2*tt.want)
+			}
+		})
+	}
+}
+
+func TestCombineSigmaClipFewFrames(t *testing.T) {
+	// Eight frames about 100, with a hot pixel in one, which is within three standard deviations of the values
+	// including it:
+	frames := newFrames(100, 101, 99, 100, 102, 98, 100, 5000)
+
+	got, err := Combine(frames, CombineOptions{Method: SigmaClip})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.Pix[0] != 100 {
+		t.Errorf("got %v, wanted %v", got.Pix[0], 100)
+	}
+}
+
+func TestCombineMismatch(t *testing.T) {
+	frames := append(newFrames(1), alpacago.NewImage(alpacago.ImageElementInt32, 3, 1, 1))
+
+	if _, err := Combine(frames, CombineOptions{}); err == nil {
+		t.Errorf("got nil, wanted an error for frames of different dimensions")
+	}
+}
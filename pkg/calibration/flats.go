@@ -0,0 +1,570 @@
+package calibration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+// ErrSkyTooDark is returned by CaptureFlats() when the sky is too faint for flats at the longest exposure.
+var ErrSkyTooDark = errors.New("calibration: the sky is too dark for flats")
+
+// ErrSkyTooBright is returned by CaptureFlats() at dawn when the sky is too bright for flats at the shortest exposure.
+var ErrSkyTooBright = errors.New("calibration: the sky is too bright for flats")
+
+// ErrFlatsNotConverged is returned by CaptureFlats() when too many consecutive flats miss the target level.
+var ErrFlatsNotConverged = errors.New("calibration: flats did not converge on the target level")
+
+// ErrCoverCalibratorError is returned by CaptureFlats() when the cover or calibrator enters its error state.
+var ErrCoverCalibratorError = errors.New("calibration: the cover calibrator is in the error state")
+
+/*
+FlatsOptions
+
+The options of CaptureFlats(). A zero value for any option selects its default.
+*/
+type FlatsOptions struct {
+	// The number of flats to capture through each filter (default 20):
+	Count int
+	// The target mean level of each flat, as a fraction of the MaxADU of the camera (default 0.4):
+	Target float64
+	// The greatest difference of an accepted flat from the target level, as a fraction of the target (default 0.1):
+	Tolerance float64
+	// The duration of the first exposure, in seconds (default 1):
+	Exposure float64
+	// The shortest exposure, in seconds (default the ExposureMin of the camera):
+	MinExposure float64
+	// The longest exposure, in seconds (default 30):
+	MaxExposure float64
+	// The filter wheel positions to capture flats through (default every position):
+	Positions []int32
+	// True if sky flats are captured at dawn, as the sky brightens, rather than at dusk:
+	Dawn bool
+	// The greatest number of consecutive flats which may miss the target level (default 10):
+	MaxAttempts int
+	// The interval between polls of the cover calibrator and filter wheel (default 1 second):
+	PollInterval time.Duration
+	// The interval between exposures whilst waiting for the sky to darken, or brighten at dawn (default 30 seconds):
+	SkyInterval time.Duration
+	// The longest wait for the cover, calibrator or filter wheel to reach a state (default 2 minutes):
+	Timeout time.Duration
+}
+
+func (o FlatsOptions) withDefaults() FlatsOptions {
+	if o.Count == 0 {
+		o.Count = 20
+	}
+
+	if o.Target == 0 {
+		o.Target = 0.4
+	}
+
+	if o.Tolerance == 0 {
+		o.Tolerance = 0.1
+	}
+
+	if o.Exposure == 0 {
+		o.Exposure = 1
+	}
+
+	if o.MaxExposure == 0 {
+		o.MaxExposure = 30
+	}
+
+	if o.MaxAttempts == 0 {
+		o.MaxAttempts = 10
+	}
+
+	if o.PollInterval == 0 {
+		o.PollInterval = time.Second
+	}
+
+	if o.SkyInterval == 0 {
+		o.SkyInterval = 30 * time.Second
+	}
+
+	if o.Timeout == 0 {
+		o.Timeout = 2 * time.Minute
+	}
+
+	return o
+}
+
+/*
+FlatsStatus
+
+The result of each exposure of CaptureFlats(). Frame is the number of flats accepted through the filter so far, and
+Brightness is zero for sky flats.
+*/
+type FlatsStatus struct {
+	Filter     string
+	Position   int32
+	Frame      int
+	Count      int
+	Exposure   float64
+	Brightness int32
+	Mean       float64
+	Target     float64
+	Accepted   bool
+}
+
+type FlatsProgressFunc func(status FlatsStatus)
+
+/*
+FlatSeries
+
+The flats captured through a filter by CaptureFlats(). Position is -1 if there is no filter wheel. Sky flats may
+vary in exposure, which is recorded in the key of each flat.
+*/
+type FlatSeries struct {
+	Filter   string
+	Position int32
+	Images   []*alpacago.Image
+	Keys     []Key
+}
+
+type flatCapture struct {
+	camera        *alpacago.Camera
+	filterWheel   *alpacago.FilterWheel
+	calibrator    *alpacago.CoverCalibrator
+	opts          FlatsOptions
+	progress      FlatsProgressFunc
+	target        float64
+	maxADU        float64
+	exposure      float64
+	flux          float64
+	brightness    int32
+	maxBrightness int32
+	lit           int32
+	cover         bool
+}
+
+/*
+CaptureFlats()
+
+Captures flats through each filter, adjusting each exposure so that the mean level of the flats approaches the
+target. If there is a cover calibrator, the cover is closed and the brightness of the calibrator adjusted, with
+the exposure only adjusted when the brightness is at its limit. Afterwards the calibrator is turned off and the
+cover reopened, even if the capture fails. Otherwise sky flats are captured, with the exposure adjusted after every
+flat to follow the changing twilight, waiting whilst the sky is too bright at dusk, or too dark at dawn.
+
+@param camera *alpacago.Camera (the camera)
+@param filterWheel *alpacago.FilterWheel (the filter wheel, or nil if there is none)
+@param calibrator *alpacago.CoverCalibrator (the cover calibrator, or nil for sky flats)
+@param opts FlatsOptions (the options of the capture)
+@param progress FlatsProgressFunc (called after every exposure, may be nil)
+@returns the flats through each filter, including those through the filters completed before any error.
+*/
+func CaptureFlats(ctx context.Context, camera *alpacago.Camera, filterWheel *alpacago.FilterWheel, calibrator *alpacago.CoverCalibrator, opts FlatsOptions, progress FlatsProgressFunc) (series []FlatSeries, err error) {
+	opts = opts.withDefaults()
+
+	f := &flatCapture{
+		camera:      camera,
+		filterWheel: filterWheel,
+		calibrator:  calibrator,
+		opts:        opts,
+		progress:    progress,
+		exposure:    opts.Exposure,
+	}
+
+	maxADU, err := camera.GetMaxADUContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f.target = opts.Target * float64(maxADU)
+
+	f.maxADU = float64(maxADU)
+
+	if f.opts.MinExposure == 0 {
+		if f.opts.MinExposure, err = camera.GetExposureMinContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var names []string
+
+	positions := opts.Positions
+
+	if filterWheel == nil {
+		positions = []int32{-1}
+	} else {
+		if names, err = filterWheel.GetNamesContext(ctx); err != nil {
+			return nil, err
+		}
+
+		if len(positions) == 0 {
+			for i := range names {
+				positions = append(positions, int32(i))
+			}
+		}
+	}
+
+	if calibrator != nil {
+		defer func() {
+			err = errors.Join(err, f.restoreCalibrator(ctx))
+		}()
+
+		if err := f.prepareCalibrator(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, position := range positions {
+		s := FlatSeries{
+			Position: position,
+		}
+
+		if position >= 0 && int(position) < len(names) {
+			s.Filter = names[position]
+		}
+
+		if err := f.captureFilter(ctx, &s); err != nil {
+			return series, err
+		}
+
+		series = append(series, s)
+	}
+
+	return series, nil
+}
+
+/*
+prepareCalibrator()
+
+Closes the cover, if there is one, and reads the maximum brightness of the calibrator, which starts at half of it.
+*/
+func (f *flatCapture) prepareCalibrator(ctx context.Context) error {
+	calibratorState, err := f.calibrator.GetCalibratorStateContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if calibratorState == alpacago.CalibratorNotPresent {
+		return fmt.Errorf("calibration: the cover calibrator has no calibrator")
+	}
+
+	if f.maxBrightness, err = f.calibrator.GetMaxBrightnessContext(ctx); err != nil {
+		return err
+	}
+
+	f.brightness = max(f.maxBrightness/2, 1)
+
+	coverState, err := f.calibrator.GetCoverStateContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if coverState == alpacago.CoverNotPresent {
+		return nil
+	}
+
+	f.cover = true
+
+	if err := f.calibrator.CloseCoverContext(ctx); err != nil {
+		return err
+	}
+
+	return f.waitForCover(ctx, alpacago.CoverClosed)
+}
+
+/*
+restoreCalibrator()
+
+Turns the calibrator off and reopens the cover, using a fresh context if the context of CaptureFlats() has been
+cancelled, so that the telescope is left ready for imaging.
+*/
+func (f *flatCapture) restoreCalibrator(ctx context.Context) error {
+	// Nothing has been changed if the calibrator could not be prepared:
+	if f.maxBrightness == 0 {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), f.opts.Timeout)
+
+		defer cancel()
+	}
+
+	if err := f.calibrator.SetCalibratorOffContext(ctx); err != nil {
+		return err
+	}
+
+	if err := f.waitFor(ctx, "the calibrator to turn off", func(ctx context.Context) (bool, error) {
+		state, err := f.calibrator.GetCalibratorStateContext(ctx)
+
+		if err == nil && state == alpacago.CalibratorError {
+			err = ErrCoverCalibratorError
+		}
+
+		return state == alpacago.CalibratorOff, err
+	}); err != nil {
+		return err
+	}
+
+	if !f.cover {
+		return nil
+	}
+
+	if err := f.calibrator.OpenCoverContext(ctx); err != nil {
+		return err
+	}
+
+	return f.waitForCover(ctx, alpacago.CoverOpen)
+}
+
+func (f *flatCapture) waitForCover(ctx context.Context, want alpacago.CoverState) error {
+	return f.waitFor(ctx, fmt.Sprintf("the cover to be %s", want), func(ctx context.Context) (bool, error) {
+		state, err := f.calibrator.GetCoverStateContext(ctx)
+
+		if err == nil && state == alpacago.CoverError {
+			err = ErrCoverCalibratorError
+		}
+
+		return state == want, err
+	})
+}
+
+/*
+setBrightness()
+
+Turns the calibrator on at the current brightness, if it is not already lit at that brightness, and waits for it
+to become ready.
+*/
+func (f *flatCapture) setBrightness(ctx context.Context) error {
+	if f.lit == f.brightness {
+		return nil
+	}
+
+	if err := f.calibrator.SetCalibratorOnContext(ctx, f.brightness); err != nil {
+		return err
+	}
+
+	f.lit = f.brightness
+
+	return f.waitFor(ctx, "the calibrator to be ready", func(ctx context.Context) (bool, error) {
+		state, err := f.calibrator.GetCalibratorStateContext(ctx)
+
+		if err == nil && state == alpacago.CalibratorError {
+			err = ErrCoverCalibratorError
+		}
+
+		return state == alpacago.CalibratorReady, err
+	})
+}
+
+/*
+waitFor()
+
+Polls until done, or the timeout of the options elapses.
+*/
+func (f *flatCapture) waitFor(ctx context.Context, description string, done func(ctx context.Context) (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, f.opts.Timeout)
+
+	defer cancel()
+
+	for {
+		ok, err := done(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("calibration: waiting for %s: %w", description, ctx.Err())
+		case <-time.After(f.opts.PollInterval):
+		}
+	}
+}
+
+/*
+captureFilter()
+
+Moves the filter wheel to the position of the series, then exposes until the series has the number of flats of
+the options.
+*/
+func (f *flatCapture) captureFilter(ctx context.Context, s *FlatSeries) error {
+	if s.Position >= 0 {
+		if err := f.filterWheel.SetPositionContext(ctx, s.Position); err != nil {
+			return err
+		}
+
+		// The position is -1 whilst the wheel is moving:
+		if err := f.waitFor(ctx, fmt.Sprintf("the filter wheel to reach position %d", s.Position), func(ctx context.Context) (bool, error) {
+			position, err := f.filterWheel.GetPositionContext(ctx)
+			return position == s.Position, err
+		}); err != nil {
+			return err
+		}
+	}
+
+	rejected := 0
+
+	// The sky flux is unknown through a new filter:
+	f.flux = 0
+
+	for len(s.Images) < f.opts.Count {
+		if f.calibrator != nil {
+			if err := f.setBrightness(ctx); err != nil {
+				return err
+			}
+		}
+
+		duration := f.exposure
+
+		exposure, err := f.camera.ExposeContext(ctx, duration, true, nil)
+
+		if err != nil {
+			return err
+		}
+
+		m := stats.Compute(exposure.Image.Pix, 0).Mean
+
+		accepted := math.Abs(m-f.target) <= f.opts.Tolerance*f.target
+
+		if accepted {
+			key, err := NewKeyForDevices(ctx, Flat, duration, f.camera, f.filterWheel)
+
+			if err != nil {
+				return err
+			}
+
+			s.Images = append(s.Images, exposure.Image)
+			s.Keys = append(s.Keys, key)
+
+			rejected = 0
+		} else {
+			rejected++
+		}
+
+		if f.progress != nil {
+			status := FlatsStatus{
+				Filter:   s.Filter,
+				Position: s.Position,
+				Frame:    len(s.Images),
+				Count:    f.opts.Count,
+				Exposure: duration,
+				Mean:     m,
+				Target:   f.target,
+				Accepted: accepted,
+			}
+
+			if f.calibrator != nil {
+				status.Brightness = f.brightness
+			}
+
+			f.progress(status)
+		}
+
+		wait, err := f.adjust(m, accepted)
+
+		if err != nil {
+			return err
+		}
+
+		if wait {
+			rejected, f.flux = 0, 0
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(f.opts.SkyInterval):
+			}
+
+			continue
+		}
+
+		if rejected >= f.opts.MaxAttempts {
+			return fmt.Errorf("%w: %d consecutive flats with a mean of %.0f ADU, wanted %.0f ADU", ErrFlatsNotConverged, rejected, m, f.target)
+		}
+	}
+
+	return nil
+}
+
+/*
+adjust()
+
+Scales the brightness of the calibrator, or the exposure if the calibrator is at the limit of its brightness, by the
+ratio of the target to the mean of the last flat. Panel flats are only adjusted after a rejected flat, whereas sky
+flats are adjusted after every flat, extrapolating the change in the sky flux between the last two flats to
+follow the twilight.
+
+@returns true if the sky is too bright at dusk, or too dark at dawn, to reach the target within the limits of the
+exposure, so that the capture should wait for the sky to change.
+*/
+func (f *flatCapture) adjust(m float64, accepted bool) (bool, error) {
+	if accepted && f.calibrator != nil {
+		return false, nil
+	}
+
+	// The ratio is bounded, as the mean of a saturated or black flat says little of the true level:
+	ratio := 16.0
+
+	if m > 0 {
+		ratio = math.Min(math.Max(f.target/m, 1.0/16), 16)
+	}
+
+	if f.calibrator == nil {
+		flux := m / f.exposure
+
+		if m > 0 && m < 0.9*f.maxADU {
+			if f.flux > 0 {
+				ratio /= math.Min(math.Max(flux/f.flux, 0.5), 2)
+			}
+
+			f.flux = flux
+		} else {
+			f.flux = 0
+		}
+	}
+
+	if f.calibrator != nil {
+		brightness := int32(math.Round(float64(f.brightness) * ratio))
+
+		brightness = min(max(brightness, 1), f.maxBrightness)
+
+		if brightness != f.brightness {
+			f.brightness = brightness
+			return false, nil
+		}
+	}
+
+	exposure := math.Min(math.Max(f.exposure*ratio, f.opts.MinExposure), f.opts.MaxExposure)
+
+	if accepted || exposure != f.exposure {
+		f.exposure = exposure
+		return false, nil
+	}
+
+	// The exposure is at its limit, and still misses the target:
+	tooBright := m > f.target
+
+	switch {
+	case f.calibrator != nil && tooBright:
+		return false, fmt.Errorf("%w: the calibrator is too bright at its lowest brightness and the shortest exposure", ErrFlatsNotConverged)
+	case f.calibrator != nil:
+		return false, fmt.Errorf("%w: the calibrator is too faint at its highest brightness and the longest exposure", ErrFlatsNotConverged)
+	case tooBright && f.opts.Dawn:
+		return false, ErrSkyTooBright
+	case !tooBright && !f.opts.Dawn:
+		return false, ErrSkyTooDark
+	default:
+		return true, nil
+	}
+}
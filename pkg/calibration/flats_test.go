@@ -0,0 +1,304 @@
+package calibration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+/*
+observatory
+
+A simulated camera, filter wheel and cover calibrator, whose flats have a level of the flux through the filter
+multiplied by the exposure. The flux is that of the calibrator if it is on, otherwise that of the sky, which
+changes by the fade factor after every exposure.
+*/
+type observatory struct {
+	mu           sync.Mutex
+	t            *testing.T
+	sky          float64
+	fade         float64
+	transmission []float64
+	position     int32
+	brightness   int32
+	cover        alpacago.CoverState
+	calibrator   alpacago.CalibratorState
+	level        float64
+	exposures    []float64
+	polls        int
+	events       []string
+}
+
+func (o *observatory) respond(device string, name string, r *http.Request) alpacatest.Response {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	value := ""
+
+	switch device + "/" + name {
+	case "camera/maxadu":
+		value = "65535"
+	case "camera/exposuremin":
+		value = "0.001"
+	case "camera/startexposure":
+		duration, _ := strconv.ParseFloat(r.FormValue("Duration"), 64)
+
+		flux := o.sky
+
+		if o.calibrator == alpacago.CalibratorReady {
+			flux = float64(o.brightness) * 100
+		}
+
+		o.level = math.Min(flux*o.transmission[max(o.position, 0)]*duration, 65535)
+		o.exposures = append(o.exposures, duration)
+		o.sky *= o.fade
+		value = "null"
+	case "camera/camerastate":
+		value = "0"
+	case "camera/imageready":
+		value = "true"
+	case "camera/imagearray":
+		v := int(o.level)
+
+		return alpacatest.ImageArray(fmt.Sprintf("[[%d,%d],[%d,%d]]", v, v, v, v))
+	case "camera/lastexposurestarttime":
+		value = `"2026-10-17T18:30:00"`
+	case "camera/lastexposureduration":
+		value = fmt.Sprint(o.exposures[len(o.exposures)-1])
+	case "camera/binx", "camera/biny":
+		value = "1"
+	case "camera/gain", "camera/offset", "camera/readoutmode":
+		value = "0"
+	case "camera/ccdtemperature":
+		value = "-10"
+	case "filterwheel/names":
+		value = `["L","Ha"]`
+	case "filterwheel/position":
+		if r.Method == http.MethodPut {
+			o.position, o.polls = -1, 0
+			o.events = append(o.events, "position "+r.FormValue("Position"))
+			value = "null"
+			break
+		}
+
+		// The wheel arrives after being polled once whilst moving:
+		if o.position == -1 && o.polls > 0 {
+			p := o.events[len(o.events)-1]
+			n, _ := strconv.Atoi(strings.TrimPrefix(p, "position "))
+			o.position = int32(n)
+		}
+
+		o.polls++
+		value = fmt.Sprint(o.position)
+	case "covercalibrator/maxbrightness":
+		value = "1000"
+	case "covercalibrator/coverstate":
+		value = fmt.Sprint(int32(o.cover))
+
+		// The cover finishes moving once polled:
+		if o.cover == alpacago.CoverMoving {
+			o.cover = alpacago.CoverClosed
+
+			if o.events[len(o.events)-1] == "opencover" {
+				o.cover = alpacago.CoverOpen
+			}
+		}
+	case "covercalibrator/calibratorstate":
+		value = fmt.Sprint(int32(o.calibrator))
+
+		if o.calibrator == alpacago.CalibratorNotReady {
+			o.calibrator = alpacago.CalibratorReady
+		}
+	case "covercalibrator/closecover", "covercalibrator/opencover":
+		o.cover = alpacago.CoverMoving
+		o.events = append(o.events, name)
+		value = "null"
+	case "covercalibrator/calibratoron":
+		o.brightness, _ = func() (int32, error) {
+			n, err := strconv.Atoi(r.FormValue("Brightness"))
+			return int32(n), err
+		}()
+		o.calibrator = alpacago.CalibratorNotReady
+		o.events = append(o.events, "on "+r.FormValue("Brightness"))
+		value = "null"
+	case "covercalibrator/calibratoroff":
+		o.calibrator = alpacago.CalibratorOff
+		o.events = append(o.events, name)
+		value = "null"
+	default:
+		o.t.Errorf("got an unexpected %s request for %s", r.Method, r.URL.Path)
+
+		return alpacatest.NotImplemented
+	}
+
+	return alpacatest.Value(value)
+}
+
+func newObservatory(t *testing.T) (*observatory, *alpacago.Camera, *alpacago.FilterWheel, *alpacago.CoverCalibrator) {
+	o := &observatory{
+		t:            t,
+		fade:         1,
+		transmission: []float64{1, 0.5},
+		cover:        alpacago.CoverOpen,
+		calibrator:   alpacago.CalibratorOff,
+	}
+
+	ts := alpacatest.NewServer(t, nil)
+
+	for _, device := range []string{"camera", "filterwheel", "covercalibrator"} {
+		device := device
+
+		ts.HandleFunc(device+"/0/", func(name string, r *http.Request) alpacatest.Response {
+			return o.respond(device, name, r)
+		})
+	}
+
+	return o, alpacago.NewCameraWithOptions(ts.URL, 0), alpacago.NewFilterWheelWithOptions(ts.URL, 0), alpacago.NewCoverCalibratorWithOptions(ts.URL, 0)
+}
+
+var testFlatsOptions = FlatsOptions{
+	Count:        3,
+	PollInterval: time.Millisecond,
+	SkyInterval:  time.Millisecond,
+}
+
+func TestCaptureFlatsPanel(t *testing.T) {
+	o, camera, filterWheel, calibrator := newObservatory(t)
+
+	statuses := []FlatsStatus{}
+
+	series, err := CaptureFlats(context.Background(), camera, filterWheel, calibrator, testFlatsOptions, func(status FlatsStatus) {
+		statuses = append(statuses, status)
+	})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(series) != 2 || series[0].Filter != "L" || series[1].Filter != "Ha" {
+		t.Fatalf("got %+v, wanted flats through the L and Ha filters", series)
+	}
+
+	for _, s := range series {
+		if len(s.Images) != 3 || len(s.Keys) != 3 {
+			t.Errorf("got %d flats through %s, wanted 3", len(s.Images), s.Filter)
+		}
+
+		for _, k := range s.Keys {
+			if k.Type != Flat || k.Filter != s.Filter || k.Exposure != 1 {
+				t.Errorf("got %v, wanted a 1s flat through %s", k, s.Filter)
+			}
+		}
+	}
+
+	// The half brightness of 500 is too bright, and the Ha filter passes half the light of the L filter:
+	want := []string{"closecover", "position 0", "on 500", "on 262", "position 1", "on 524", "calibratoroff", "opencover"}
+
+	if fmt.Sprint(o.events) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", o.events, want)
+	}
+
+	if o.cover != alpacago.CoverOpen || o.calibrator != alpacago.CalibratorOff {
+		t.Errorf("got cover %v and calibrator %v, wanted the cover open and the calibrator off", o.cover, o.calibrator)
+	}
+
+	if len(statuses) != 8 || statuses[0].Accepted || statuses[0].Brightness != 500 || !statuses[1].Accepted {
+		t.Errorf("got %+v, wanted a rejected flat then three accepted flats through each filter", statuses)
+	}
+}
+
+func TestCaptureFlatsPanelTooFaint(t *testing.T) {
+	o, camera, _, calibrator := newObservatory(t)
+
+	o.transmission = []float64{0.0001}
+
+	opts := testFlatsOptions
+
+	opts.MaxExposure = 4
+
+	_, err := CaptureFlats(context.Background(), camera, nil, calibrator, opts, nil)
+
+	if !errors.Is(err, ErrFlatsNotConverged) {
+		t.Errorf("got %v, wanted %v", err, ErrFlatsNotConverged)
+	}
+
+	// The exposure is only lengthened once the calibrator is at its brightest:
+	if fmt.Sprint(o.exposures) != "[1 1 4]" {
+		t.Errorf("got %v, wanted the exposures to be lengthened to the longest", o.exposures)
+	}
+
+	if o.cover != alpacago.CoverOpen || o.calibrator != alpacago.CalibratorOff {
+		t.Errorf("got cover %v and calibrator %v, wanted the cover reopened after the error", o.cover, o.calibrator)
+	}
+}
+
+func TestCaptureFlatsSky(t *testing.T) {
+	tests := []struct {
+		name string
+		sky  float64
+		fade float64
+		dawn bool
+		want error
+	}{
+		{"Dusk", 30000, 0.8, false, nil},
+		{"DuskTooBright", 1e9, 0.5, false, nil},
+		{"DuskTooDark", 100, 0.8, false, ErrSkyTooDark},
+		{"Dawn", 10000, 1.25, true, nil},
+		{"DawnTooDark", 1, 2, true, nil},
+		{"DawnTooBright", 1e9, 1.25, true, ErrSkyTooBright},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, camera, _, _ := newObservatory(t)
+
+			o.sky, o.fade = tt.sky, tt.fade
+
+			opts := testFlatsOptions
+
+			opts.Dawn = tt.dawn
+
+			series, err := CaptureFlats(context.Background(), camera, nil, nil, opts, func(status FlatsStatus) {
+				if status.Accepted && math.Abs(status.Mean-status.Target) > 0.1*status.Target {
+					t.Errorf("got an accepted flat with a mean of %v, wanted %v", status.Mean, status.Target)
+				}
+			})
+
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, wanted %v", err, tt.want)
+			}
+
+			if tt.want == nil && (len(series) != 1 || len(series[0].Images) != 3 || series[0].Position != -1) {
+				t.Errorf("got %+v, wanted 3 flats without a filter wheel", series)
+			}
+		})
+	}
+}
+
+func TestCreateMasterSkyFlats(t *testing.T) {
+	l, _ := NewLibrary("")
+
+	frames := []*alpacago.Image{newUniform(20000), newUniform(30000)}
+
+	keys := []Key{newKey(Flat, 1.5, -10, "L"), newKey(Flat, 0.8, -10, "L")}
+
+	if _, err := l.CreateMaster(frames, keys, CombineOptions{}); err != nil {
+		t.Errorf("got %q, wanted flats of differing exposure to combine", err)
+	}
+
+	keys = []Key{newKey(Dark, 1.5, -10, ""), newKey(Dark, 0.8, -10, "")}
+
+	if _, err := l.CreateMaster(frames, keys, CombineOptions{}); err == nil {
+		t.Errorf("got nil, wanted an error for darks of differing exposure")
+	}
+}
@@ -0,0 +1,286 @@
+package calibration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/fits"
+)
+
+type FrameType int
+
+const (
+	Light FrameType = iota
+	Bias
+	Dark
+	Flat
+	DarkFlat
+)
+
+// The IMAGETYP of each frame type, as written by most acquisition software:
+var imageTypes = []string{"Light Frame", "Bias Frame", "Dark Frame", "Flat Field", "Dark Flat"}
+
+func (f FrameType) String() string {
+	if f < Light || f > DarkFlat {
+		return fmt.Sprintf("Unknown FrameType value: %d", f)
+	}
+
+	return imageTypes[f]
+}
+
+/*
+Key
+
+The camera settings of a frame, which a master frame must share with the frames it calibrates. Exposure is in
+seconds, and Temperature is the sensor temperature in °C, or NaN if the camera cannot report it. Filter is the
+name of the filter, or empty if there is no filter wheel.
+*/
+type Key struct {
+	Type        FrameType
+	Exposure    float64
+	Gain        int32
+	Offset      int32
+	BinX        int32
+	BinY        int32
+	ReadoutMode int32
+	Temperature float64
+	Filter      string
+}
+
+/*
+sameSettings()
+
+@returns true if the keys have the same gain, offset, binning and readout mode, which determine the bias level,
+dark current and pixel geometry of a frame.
+*/
+func (k Key) sameSettings(other Key) bool {
+	return k.Gain == other.Gain && k.Offset == other.Offset && k.BinX == other.BinX && k.BinY == other.BinY && k.ReadoutMode == other.ReadoutMode
+}
+
+/*
+sameExposure()
+
+@returns true if the exposures are equal, to within a millisecond or a part in a thousand.
+*/
+func (k Key) sameExposure(other Key) bool {
+	return math.Abs(k.Exposure-other.Exposure) <= math.Max(0.001, 0.001*math.Max(k.Exposure, other.Exposure))
+}
+
+/*
+temperatureDifference()
+
+@returns the difference between the sensor temperatures, or zero if either is unknown.
+*/
+func (k Key) temperatureDifference(other Key) float64 {
+	if math.IsNaN(k.Temperature) || math.IsNaN(other.Temperature) {
+		return 0
+	}
+
+	return math.Abs(k.Temperature - other.Temperature)
+}
+
+func (k Key) String() string {
+	s := fmt.Sprintf("%s %gs gain %d offset %d bin %dx%d readout %d", k.Type, k.Exposure, k.Gain, k.Offset, k.BinX, k.BinY, k.ReadoutMode)
+
+	if !math.IsNaN(k.Temperature) {
+		s += fmt.Sprintf(" %.1f°C", k.Temperature)
+	}
+
+	if k.Filter != "" {
+		s += " " + k.Filter
+	}
+
+	return s
+}
+
+var unsafeFileName = regexp.MustCompile(`[^A-Za-z0-9.+-]+`)
+
+/*
+fileName()
+
+@returns a file name unique to the key, e.g., "dark_300s_g100_o10_bin1x1_r0_-10.0C.fits".
+*/
+func (k Key) fileName() string {
+	parts := []string{
+		strings.ToLower(strings.Fields(k.Type.String())[0]),
+		fmt.Sprintf("%gs", k.Exposure),
+		fmt.Sprintf("g%d", k.Gain),
+		fmt.Sprintf("o%d", k.Offset),
+		fmt.Sprintf("bin%dx%d", k.BinX, k.BinY),
+		fmt.Sprintf("r%d", k.ReadoutMode),
+	}
+
+	if k.Type == DarkFlat {
+		parts[0] = "darkflat"
+	}
+
+	if !math.IsNaN(k.Temperature) {
+		parts = append(parts, fmt.Sprintf("%.1fC", k.Temperature))
+	}
+
+	if k.Filter != "" {
+		parts = append(parts, unsafeFileName.ReplaceAllString(k.Filter, "-"))
+	}
+
+	return strings.Join(parts, "_") + ".fits"
+}
+
+/*
+AddToHeader()
+
+Sets the IMAGETYP, EXPTIME, GAIN, OFFSET, XBINNING, YBINNING, READOUTM, CCD-TEMP and FILTER keywords of the key.
+*/
+func (k Key) AddToHeader(h *fits.Header) {
+	h.Set("IMAGETYP", k.Type.String(), "type of frame")
+	h.Set("EXPTIME", k.Exposure, "[s] exposure duration")
+	h.Set("GAIN", k.Gain, "sensor gain")
+	h.Set("OFFSET", k.Offset, "sensor offset")
+	h.Set("XBINNING", k.BinX, "binning factor in width")
+	h.Set("YBINNING", k.BinY, "binning factor in height")
+	h.Set("READOUTM", k.ReadoutMode, "readout mode")
+
+	if !math.IsNaN(k.Temperature) {
+		h.Set("CCD-TEMP", k.Temperature, "[C] sensor temperature")
+	}
+
+	if k.Filter != "" {
+		h.Set("FILTER", k.Filter, "name of filter")
+	}
+}
+
+/*
+NewKeyFromHeader()
+
+@returns the key of a frame from its FITS header, see AddToHeader().
+*/
+func NewKeyFromHeader(h *fits.Header) (Key, error) {
+	k := Key{
+		Temperature: math.NaN(),
+	}
+
+	imageType, ok := h.GetString("IMAGETYP")
+
+	if !ok {
+		return k, fmt.Errorf("calibration: header has no IMAGETYP")
+	}
+
+	k.Type = -1
+
+	for i, t := range imageTypes {
+		if strings.EqualFold(imageType, t) {
+			k.Type = FrameType(i)
+		}
+	}
+
+	if k.Type < 0 {
+		return k, fmt.Errorf("calibration: unknown IMAGETYP %q", imageType)
+	}
+
+	k.Exposure, _ = h.GetFloat("EXPTIME")
+
+	for keyword, value := range map[string]*int32{"GAIN": &k.Gain, "OFFSET": &k.Offset, "XBINNING": &k.BinX, "YBINNING": &k.BinY, "READOUTM": &k.ReadoutMode} {
+		if v, ok := h.GetInt(keyword); ok {
+			*value = int32(v)
+		}
+	}
+
+	if v, ok := h.GetFloat("CCD-TEMP"); ok {
+		k.Temperature = v
+	}
+
+	k.Filter, _ = h.GetString("FILTER")
+
+	return k, nil
+}
+
+/*
+NewKeyForDevices()
+
+Reads the key of a frame from the current settings of the camera, and the current filter of the filter wheel,
+which should be read immediately after the exposure so that they are the settings in effect during it. Gain,
+offset and readout mode are zero, and the temperature NaN, if the camera does not implement them.
+
+@param frameType FrameType (the type of the frame)
+@param exposure float64 (the duration of the exposure, in seconds)
+@param camera *alpacago.Camera (the camera)
+@param filterWheel *alpacago.FilterWheel (the filter wheel, or nil if there is none)
+@returns the key of the frame.
+*/
+func NewKeyForDevices(ctx context.Context, frameType FrameType, exposure float64, camera *alpacago.Camera, filterWheel *alpacago.FilterWheel) (Key, error) {
+	k := Key{
+		Type:        frameType,
+		Exposure:    exposure,
+		Temperature: math.NaN(),
+	}
+
+	var err error
+
+	if k.BinX, err = camera.GetBinXContext(ctx); err != nil {
+		return k, err
+	}
+
+	if k.BinY, err = camera.GetBinYContext(ctx); err != nil {
+		return k, err
+	}
+
+	optional := func(err error) error {
+		if errors.Is(err, alpacago.ErrNotImplemented) {
+			return nil
+		}
+
+		return err
+	}
+
+	gain, err := camera.GetGainContext(ctx)
+
+	if err := optional(err); err != nil {
+		return k, err
+	}
+
+	offset, err := camera.GetOffsetContext(ctx)
+
+	if err := optional(err); err != nil {
+		return k, err
+	}
+
+	readoutMode, err := camera.GetReadOutModeContext(ctx)
+
+	if err := optional(err); err != nil {
+		return k, err
+	}
+
+	k.Gain, k.Offset, k.ReadoutMode = gain, offset, readoutMode
+
+	if temperature, err := camera.GetCCDTemperatureContext(ctx); err == nil {
+		k.Temperature = temperature
+	} else if err := optional(err); err != nil {
+		return k, err
+	}
+
+	if filterWheel == nil {
+		return k, nil
+	}
+
+	position, err := filterWheel.GetPositionContext(ctx)
+
+	if err != nil {
+		return k, err
+	}
+
+	names, err := filterWheel.GetNamesContext(ctx)
+
+	if err != nil {
+		return k, err
+	}
+
+	if position >= 0 && int(position) < len(names) {
+		k.Filter = names[position]
+	}
+
+	return k, nil
+}
@@ -0,0 +1,323 @@
+package calibration
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/fits"
+)
+
+// The default greatest difference in sensor temperature between a master dark or bias and a light frame, in °C:
+const DEFAULT_TEMPERATURE_TOLERANCE = 1.0
+
+/*
+Master
+
+A master calibration frame, combined from Count frames of the same key. Master flats are normalised to a mean of
+one in each plane.
+*/
+type Master struct {
+	Key     Key
+	Image   *alpacago.Image
+	Count   int
+	Created time.Time
+}
+
+/*
+Library
+
+A set of master calibration frames, selected by the key of the frames they calibrate. If the library has a
+directory, masters are stored there as FITS files, with their keys in the header.
+*/
+type Library struct {
+	mu      sync.Mutex
+	dir     string
+	masters []*Master
+	// The greatest difference in sensor temperature between a master bias, dark or dark flat and the frames it calibrates, in °C:
+	TemperatureTolerance float64
+}
+
+/*
+NewLibrary()
+
+@param dir string (the directory of the stored masters, or empty for a library held only in memory)
+@returns a library of the masters stored in the directory, which is created if it does not exist.
+*/
+func NewLibrary(dir string) (*Library, error) {
+	l := &Library{
+		dir:                  dir,
+		TemperatureTolerance: DEFAULT_TEMPERATURE_TOLERANCE,
+	}
+
+	if dir == "" {
+		return l, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.fits"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		image, header, err := fits.ReadFile(path)
+
+		if err != nil {
+			return nil, fmt.Errorf("calibration: %s: %w", path, err)
+		}
+
+		key, err := NewKeyFromHeader(header)
+
+		if err != nil {
+			return nil, fmt.Errorf("calibration: %s: %w", path, err)
+		}
+
+		master := &Master{
+			Key:   key,
+			Image: image,
+		}
+
+		if count, ok := header.GetInt("NCOMBINE"); ok {
+			master.Count = int(count)
+		}
+
+		if created, ok := header.GetString("DATE"); ok {
+			master.Created, _ = time.Parse(fits.DATE_OBS_FORMAT, created)
+		}
+
+		l.masters = append(l.masters, master)
+	}
+
+	return l, nil
+}
+
+/*
+Masters()
+
+@returns the masters of the library.
+*/
+func (l *Library) Masters() []*Master {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]*Master{}, l.masters...)
+}
+
+/*
+Add()
+
+Adds the master to the library, replacing any master with an identical key, and stores it in the directory of
+the library.
+*/
+func (l *Library) Add(master *Master) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.dir != "" {
+		h := fits.NewHeader()
+
+		master.Key.AddToHeader(h)
+
+		h.Set("NCOMBINE", master.Count, "number of frames combined")
+		h.Set("DATE", master.Created.UTC().Format(fits.DATE_OBS_FORMAT), "[UTC] date the master was created")
+
+		if err := fits.WriteFile(filepath.Join(l.dir, master.Key.fileName()), master.Image, h, fits.Options{Bitpix: -32}); err != nil {
+			return err
+		}
+	}
+
+	// Keys with an unknown temperature are not equal to themselves, so are compared by their file names:
+	for i, m := range l.masters {
+		if m.Key.fileName() == master.Key.fileName() {
+			l.masters[i] = master
+			return nil
+		}
+	}
+
+	l.masters = append(l.masters, master)
+
+	return nil
+}
+
+/*
+Find()
+
+Selects the master of the frame type which calibrates frames of the key. Every master must share the gain, offset,
+binning and readout mode of the key. A bias, dark or dark flat must be within the temperature tolerance of the
+key, a dark or dark flat must share its exposure, and a flat must share its filter. Of the matching masters, that
+closest in temperature is selected.
+
+@returns the master, or nil if there is none.
+*/
+func (l *Library) Find(frameType FrameType, key Key) *Master {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var best *Master
+
+	for _, m := range l.masters {
+		if m.Key.Type != frameType || !m.Key.sameSettings(key) {
+			continue
+		}
+
+		switch frameType {
+		case Dark, DarkFlat:
+			if !m.Key.sameExposure(key) {
+				continue
+			}
+		case Flat:
+			if m.Key.Filter != key.Filter {
+				continue
+			}
+		}
+
+		if frameType != Flat && m.Key.temperatureDifference(key) > l.TemperatureTolerance {
+			continue
+		}
+
+		if best == nil || m.Key.temperatureDifference(key) < best.Key.temperatureDifference(key) {
+			best = m
+		}
+	}
+
+	return best
+}
+
+/*
+Applied
+
+The masters applied by Calibrate(), each of which is nil if there was no matching master. The bias is only applied
+if there is no matching dark, as a master dark includes the bias.
+*/
+type Applied struct {
+	Dark *Master
+	Bias *Master
+	Flat *Master
+}
+
+/*
+Calibrate()
+
+Subtracts the matching master dark, or failing that the matching master bias, from a light frame, then divides it
+by the matching master flat, see Find(). A flat frame is calibrated with the matching master dark flat, or failing
+that the matching master dark or bias, and is not divided by a flat. Other frame types are not calibrated.
+
+@param image *alpacago.Image (the frame)
+@param key Key (the key of the frame)
+@returns the calibrated frame, as a single precision image, and the masters that were applied.
+*/
+func (l *Library) Calibrate(image *alpacago.Image, key Key) (*alpacago.Image, Applied, error) {
+	applied := Applied{}
+
+	switch key.Type {
+	case Light:
+		applied.Dark = l.Find(Dark, key)
+		applied.Flat = l.Find(Flat, key)
+	case Flat:
+		applied.Dark = l.Find(DarkFlat, key)
+
+		if applied.Dark == nil {
+			applied.Dark = l.Find(Dark, key)
+		}
+	}
+
+	if applied.Dark == nil && (key.Type == Light || key.Type == Flat) {
+		applied.Bias = l.Find(Bias, key)
+	}
+
+	calibrated := alpacago.NewImage(alpacago.ImageElementSingle, image.Width, image.Height, image.Planes)
+
+	copy(calibrated.Pix, image.Pix)
+
+	for _, m := range []*Master{applied.Dark, applied.Bias} {
+		if m == nil {
+			continue
+		}
+
+		if err := checkDimensions(image, m); err != nil {
+			return nil, applied, err
+		}
+
+		for i, v := range m.Image.Pix {
+			calibrated.Pix[i] -= v
+		}
+	}
+
+	if applied.Flat != nil {
+		if err := checkDimensions(image, applied.Flat); err != nil {
+			return nil, applied, err
+		}
+
+		for i, v := range applied.Flat.Image.Pix {
+			// Dead pixels of the flat are left uncorrected, rather than divided by zero:
+			if v > 0 {
+				calibrated.Pix[i] /= v
+			}
+		}
+	}
+
+	return calibrated, applied, nil
+}
+
+/*
+CalibrateExposure()
+
+Calibrates the image of the exposure, selecting the masters from the settings of the camera and filter wheel
+read immediately after the exposure, see NewKeyForDevices() and Calibrate().
+*/
+func (l *Library) CalibrateExposure(ctx context.Context, exposure *alpacago.Exposure, camera *alpacago.Camera, filterWheel *alpacago.FilterWheel) (*alpacago.Image, Applied, error) {
+	key, err := NewKeyForDevices(ctx, Light, exposure.Duration, camera, filterWheel)
+
+	if err != nil {
+		return nil, Applied{}, err
+	}
+
+	return l.Calibrate(exposure.Image, key)
+}
+
+func checkDimensions(image *alpacago.Image, m *Master) error {
+	if image.Width != m.Image.Width || image.Height != m.Image.Height || image.Planes != m.Image.Planes {
+		return fmt.Errorf("calibration: the %dx%dx%d master %s does not match the %dx%dx%d frame", m.Image.Width, m.Image.Height, m.Image.Planes, m.Key, image.Width, image.Height, image.Planes)
+	}
+
+	return nil
+}
+
+/*
+normalise()
+
+Divides each plane of the image by its mean, in place.
+*/
+func normalise(image *alpacago.Image) {
+	n := image.Width * image.Height
+
+	for p := 0; p < image.Planes; p++ {
+		plane := image.Pix[p*n : (p+1)*n]
+
+		var sum float64
+
+		for _, v := range plane {
+			sum += v
+		}
+
+		m := sum / float64(n)
+
+		if m == 0 || math.IsNaN(m) {
+			continue
+		}
+
+		for i := range plane {
+			plane[i] /= m
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package calibration
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+func newUniform(value float64) *alpacago.Image {
+	image := alpacago.NewImage(alpacago.ImageElementSingle, 2, 2, 1)
+
+	for i := range image.Pix {
+		image.Pix[i] = value
+	}
+
+	return image
+}
+
+func newKey(frameType FrameType, exposure float64, temperature float64, filter string) Key {
+	return Key{Type: frameType, Exposure: exposure, Gain: 100, Offset: 10, BinX: 1, BinY: 1, Temperature: temperature, Filter: filter}
+}
+
+func TestLibraryFind(t *testing.T) {
+	l, err := NewLibrary("")
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	masters := []*Master{
+		{Key: newKey(Dark, 300, -10, ""), Image: newUniform(1)},
+		{Key: newKey(Dark, 300, -9.6, ""), Image: newUniform(2)},
+		{Key: newKey(Dark, 60, -10, ""), Image: newUniform(3)},
+		{Key: newKey(Bias, 0.001, -10, ""), Image: newUniform(4)},
+		{Key: newKey(Flat, 2, 20, "Ha"), Image: newUniform(5)},
+	}
+
+	for _, m := range masters {
+		if err := l.Add(m); err != nil {
+			t.Fatalf("got %q", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		frameType FrameType
+		key       Key
+		want      *Master
+	}{
+		{"NearestTemperature", Dark, newKey(Light, 300, -9.7, "Ha"), masters[1]},
+		{"Exposure", Dark, newKey(Light, 60, -10, "Ha"), masters[2]},
+		{"NoExposure", Dark, newKey(Light, 120, -10, "Ha"), nil},
+		{"TooWarm", Dark, newKey(Light, 300, -5, "Ha"), nil},
+		{"UnknownTemperature", Bias, newKey(Light, 300, math.NaN(), "Ha"), masters[3]},
+		{"Filter", Flat, newKey(Light, 300, -10, "Ha"), masters[4]},
+		{"NoFilter", Flat, newKey(Light, 300, -10, "OIII"), nil},
+		{"Gain", Dark, Key{Type: Light, Exposure: 300, Gain: 200, Offset: 10, BinX: 1, BinY: 1, Temperature: -10}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.Find(tt.frameType, tt.key); got != tt.want {
+				t.Errorf("got %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLibraryCalibrate(t *testing.T) {
+	l, _ := NewLibrary("")
+
+	flat := newUniform(1)
+
+	copy(flat.Pix, []float64{0.5, 1, 1.5, 0})
+
+	l.Add(&Master{Key: newKey(Dark, 300, -10, ""), Image: newUniform(100)})
+	l.Add(&Master{Key: newKey(Bias, 0, -10, ""), Image: newUniform(50)})
+	l.Add(&Master{Key: newKey(Flat, 2, 20, "L"), Image: flat})
+
+	light := alpacago.NewImage(alpacago.ImageElementInt32, 2, 2, 1)
+
+	copy(light.Pix, []float64{150, 200, 250, 300})
+
+	got, applied, err := l.Calibrate(light, newKey(Light, 300, -10, "L"))
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if applied.Dark == nil || applied.Bias != nil || applied.Flat == nil {
+		t.Errorf("got %+v, wanted the dark and flat to be applied", applied)
+	}
+
+	// The dead pixel of the flat is left uncorrected:
+	if want := []float64{100, 100, 100, 200}; fmt.Sprint(got.Pix) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got.Pix, want)
+	}
+
+	// Without a dark of the exposure, the bias is subtracted instead:
+	got, applied, err = l.Calibrate(light, newKey(Light, 120, -10, ""))
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if applied.Dark != nil || applied.Bias == nil || applied.Flat != nil {
+		t.Errorf("got %+v, wanted the bias to be applied", applied)
+	}
+
+	if want := []float64{100, 150, 200, 250}; fmt.Sprint(got.Pix) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got.Pix, want)
+	}
+}
+
+func TestLibraryCreateMasterFlat(t *testing.T) {
+	l, _ := NewLibrary("")
+
+	l.Add(&Master{Key: newKey(DarkFlat, 2, 20, ""), Image: newUniform(100)})
+
+	frames := []*alpacago.Image{}
+	keys := []Key{}
+
+	// Flats of differing brightness, with the same vignetting:
+	for _, level := range []float64{1000, 2000, 4000} {
+		frame := alpacago.NewImage(alpacago.ImageElementInt32, 2, 2, 1)
+
+		for i, v := range []float64{0.5, 1, 1, 1.5} {
+			frame.Pix[i] = 100 + level*v
+		}
+
+		frames = append(frames, frame)
+		keys = append(keys, newKey(Flat, 2, 20, "L"))
+	}
+
+	master, err := l.CreateMaster(frames, keys, CombineOptions{Method: Median})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if want := []float64{0.5, 1, 1, 1.5}; fmt.Sprint(master.Image.Pix) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", master.Image.Pix, want)
+	}
+
+	if master.Count != 3 || master.Key.Filter != "L" {
+		t.Errorf("got %+v, wanted a master of 3 L flats", master)
+	}
+}
+
+func TestLibraryStore(t *testing.T) {
+	dir := t.TempDir()
+
+	l, err := NewLibrary(dir)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	want := &Master{Key: newKey(Flat, 2.5, -10.2, "Sii 3nm"), Image: newUniform(0.75), Count: 20}
+
+	if err := l.Add(want); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	l, err = NewLibrary(dir)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	masters := l.Masters()
+
+	if len(masters) != 1 {
+		t.Fatalf("got %d masters, wanted 1", len(masters))
+	}
+
+	if got := masters[0]; got.Key != want.Key || got.Count != 20 || got.Image.Pix[3] != 0.75 {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+}
+
+func TestLibraryCaptureMaster(t *testing.T) {
+	var mu sync.Mutex
+
+	exposures := []string{}
+
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/exposuremin":           "0.0001",
+		"camera/0/camerastate":           "0",
+		"camera/0/imageready":            "true",
+		"camera/0/lastexposurestarttime": `"2026-10-17T21:30:00"`,
+		"camera/0/lastexposureduration":  "0.0001",
+		"camera/0/binx":                  "1",
+		"camera/0/biny":                  "1",
+		"camera/0/gain":                  "100",
+		"camera/0/offset":                "10",
+		"camera/0/ccdtemperature":        "-10",
+		"camera/0/percentcompleted":      "100",
+	}).Strict()
+
+	// The camera does not implement readout modes:
+	ts.HandleFunc("camera/0/readoutmode", func(name string, r *http.Request) alpacatest.Response {
+		return alpacatest.NotImplemented
+	})
+
+	ts.HandleFunc("camera/0/startexposure", func(name string, r *http.Request) alpacatest.Response {
+		mu.Lock()
+		defer mu.Unlock()
+
+		exposures = append(exposures, r.FormValue("Duration")+" "+r.FormValue("Light"))
+
+		return alpacatest.Value("null")
+	})
+
+	// Each bias differs, so that the median is distinct from every frame:
+	ts.HandleFunc("camera/0/imagearray", func(name string, r *http.Request) alpacatest.Response {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return alpacatest.ImageArray(fmt.Sprintf("[[%d,0],[0,0]]", 500+len(exposures)))
+	})
+
+	l, _ := NewLibrary("")
+
+	frames := 0
+
+	master, err := l.CaptureMaster(context.Background(), Bias, 0, 3, alpacago.NewCameraWithOptions(ts.URL, 0), nil, CombineOptions{Method: Median}, func(frame int, count int, progress alpacago.ExposureProgress) {
+		frames = frame + 1
+	})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if fmt.Sprint(exposures) != "[0.000100 false 0.000100 false 0.000100 false]" {
+		t.Errorf("got %v, wanted three dark exposures of the shortest duration", exposures)
+	}
+
+	if frames != 3 {
+		t.Errorf("got progress for %d frames, wanted 3", frames)
+	}
+
+	if master.Image.Pix[0] != 502 || master.Key.Gain != 100 || master.Key.Temperature != -10 {
+		t.Errorf("got %+v with %v, wanted the median of the bias frames", master.Key, master.Image.Pix)
+	}
+
+	if l.Find(Bias, newKey(Light, 60, -10, "")) != master {
+		t.Errorf("got no bias in the library, wanted %v", master.Key)
+	}
+}
@@ -0,0 +1,269 @@
+package debayer
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+type Algorithm int
+
+const (
+	// Bilinear interpolation of each colour from the nearest pixels of that colour:
+	Bilinear Algorithm = iota
+	// Variable Number of Gradients interpolation, which interpolates along the directions of least change, to
+	// preserve edges and reduce colour fringing:
+	VNG
+)
+
+func (a Algorithm) String() string {
+	switch a {
+	case Bilinear:
+		return "Bilinear"
+	case VNG:
+		return "VNG"
+	default:
+		return fmt.Sprintf("Unknown Algorithm value: %d", a)
+	}
+}
+
+/*
+mosaic
+
+A single plane Bayer image, with pixel access mirrored at the edges. Mirroring preserves the parity of the
+coordinates, and therefore the colour of the mirrored pixel.
+*/
+type mosaic struct {
+	pix     []float64
+	width   int
+	height  int
+	pattern Pattern
+}
+
+func (m *mosaic) at(x int, y int) float64 {
+	if x < 0 {
+		x = -x
+	} else if x >= m.width {
+		x = 2*(m.width-1) - x
+	}
+
+	if y < 0 {
+		y = -y
+	} else if y >= m.height {
+		y = 2*(m.height-1) - y
+	}
+
+	return m.pix[y*m.width+x]
+}
+
+/*
+Debayer()
+
+@param image *alpacago.Image (a rank 2 Bayer image, of at least 3x3 pixels)
+@param pattern Pattern (the colour filter pattern of the image, see NewPattern())
+@param algorithm Algorithm (the demosaicing algorithm)
+@returns a rank 3 image of red, green and blue planes.
+*/
+func Debayer(image *alpacago.Image, pattern Pattern, algorithm Algorithm) (*alpacago.Image, error) {
+	if image.Rank != 2 || image.Planes != 1 {
+		return nil, fmt.Errorf("debayer: expected a rank 2 image, not rank %d", image.Rank)
+	}
+
+	if image.Width < 3 || image.Height < 3 {
+		return nil, fmt.Errorf("debayer: image of %dx%d pixels is too small", image.Width, image.Height)
+	}
+
+	m := &mosaic{
+		pix:     image.Pix,
+		width:   image.Width,
+		height:  image.Height,
+		pattern: pattern,
+	}
+
+	rgb := alpacago.NewImage(image.ElementType, image.Width, image.Height, 3)
+
+	var interpolate func(m *mosaic, x int, y int, out *[3]float64)
+
+	switch algorithm {
+	case Bilinear:
+		interpolate = bilinear
+	case VNG:
+		interpolate = vng
+	default:
+		return nil, fmt.Errorf("debayer: unknown algorithm %d", algorithm)
+	}
+
+	var out [3]float64
+
+	for y := 0; y < image.Height; y++ {
+		for x := 0; x < image.Width; x++ {
+			interpolate(m, x, y, &out)
+
+			for c := 0; c < 3; c++ {
+				rgb.Set(x, y, c, out[c])
+			}
+		}
+	}
+
+	return rgb, nil
+}
+
+/*
+ForCamera()
+
+Only RGGB Bayer sensors are supported. The complementary colour arrays of CMYG, CMYG2 and LRGB sensors need a
+conversion from their colours to RGB which is not implemented, so these sensors, like monochrome sensors, return
+ErrUnsupportedSensor, and their images are stacked and previewed as monochrome images.
+
+@returns the image, taken by the camera at its current subframe, debayered with the pattern read from the camera,
+see NewPatternForCamera().
+*/
+func ForCamera(ctx context.Context, camera *alpacago.Camera, image *alpacago.Image, algorithm Algorithm) (*alpacago.Image, error) {
+	pattern, err := NewPatternForCamera(ctx, camera)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Debayer(image, pattern, algorithm)
+}
+
+/*
+bilinear()
+
+Sets each colour of the pixel to the mean of the pixels of that colour in the surrounding 3x3 neighbourhood,
+including the pixel itself for its own colour.
+*/
+func bilinear(m *mosaic, x int, y int, out *[3]float64) {
+	var sum [3]float64
+
+	var count [3]int
+
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			c := m.pattern.At(x+dx, y+dy)
+
+			sum[c] += m.at(x+dx, y+dy)
+			count[c]++
+		}
+	}
+
+	own := m.pattern.At(x, y)
+
+	for c := 0; c < 3; c++ {
+		if Channel(c) == own {
+			out[c] = m.at(x, y)
+		} else {
+			out[c] = sum[c] / float64(count[c])
+		}
+	}
+}
+
+// The eight directions of the VNG gradients, and the unit vector perpendicular to each:
+var directions = [8][4]int{
+	// dx, dy, px, py
+	{0, -1, 1, 0},
+	{1, -1, 1, 1},
+	{1, 0, 0, 1},
+	{1, 1, 1, -1},
+	{0, 1, 1, 0},
+	{-1, 1, 1, 1},
+	{-1, 0, 0, 1},
+	{-1, -1, 1, -1},
+}
+
+/*
+vng()
+
+Interpolates the pixel by the Variable Number of Gradients method, after Chang, Cheung and Pang (1999). A gradient
+is computed in each of eight directions from the differences of same-coloured pixels along that direction, and
+the directions whose gradient is below a threshold of 1.5 times the minimum plus half of the range of gradients
+are selected. The missing colours are the pixel value plus the mean, over the selected directions, of the
+difference between each colour and the pixel's own colour in a small region in that direction.
+*/
+func vng(m *mosaic, x int, y int, out *[3]float64) {
+	var gradients [8]float64
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+
+	centre := m.at(x, y)
+
+	for i, d := range directions {
+		dx, dy, px, py := d[0], d[1], d[2], d[3]
+
+		// Pairs of pixels two steps apart in the direction, which are always the same colour:
+		g := math.Abs(m.at(x+dx, y+dy)-m.at(x-dx, y-dy)) +
+			math.Abs(m.at(x+2*dx, y+2*dy)-centre) +
+			0.5*math.Abs(m.at(x+px+dx, y+py+dy)-m.at(x+px-dx, y+py-dy)) +
+			0.5*math.Abs(m.at(x-px+dx, y-py+dy)-m.at(x-px-dx, y-py-dy))
+
+		gradients[i] = g
+
+		lo = math.Min(lo, g)
+		hi = math.Max(hi, g)
+	}
+
+	threshold := 1.5*lo + 0.5*(hi-lo)
+
+	own := m.pattern.At(x, y)
+
+	var differences [3]float64
+
+	selected := 0
+
+	for i, d := range directions {
+		if gradients[i] > threshold {
+			continue
+		}
+
+		dx, dy, px, py := d[0], d[1], d[2], d[3]
+
+		// The region in the direction, which covers every phase, and so every colour, of the array:
+		region := [6][2]int{
+			{dx, dy},
+			{2 * dx, 2 * dy},
+			{dx + px, dy + py},
+			{dx - px, dy - py},
+			{2*dx + px, 2*dy + py},
+			{2*dx - px, 2*dy - py},
+		}
+
+		if dx != 0 && dy != 0 {
+			region = [6][2]int{
+				{dx, 0},
+				{0, dy},
+				{dx, dy},
+				{2 * dx, 2 * dy},
+				{2 * dx, dy},
+				{dx, 2 * dy},
+			}
+		}
+
+		var sum [3]float64
+
+		var count [3]int
+
+		for _, o := range region {
+			c := m.pattern.At(x+o[0], y+o[1])
+
+			sum[c] += m.at(x+o[0], y+o[1])
+			count[c]++
+		}
+
+		for c := 0; c < 3; c++ {
+			differences[c] += sum[c]/float64(count[c]) - sum[own]/float64(count[own])
+		}
+
+		selected++
+	}
+
+	for c := 0; c < 3; c++ {
+		if Channel(c) == own {
+			out[c] = centre
+		} else {
+			out[c] = centre + differences[c]/float64(selected)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package debayer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// newMosaic returns a Bayer image of a uniform colour, as taken through the pattern:
+func newMosaic(width int, height int, pattern Pattern, colour [3]float64) *alpacago.Image {
+	image := alpacago.NewImage(alpacago.ImageElementInt32, width, height, 1)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			image.Set(x, y, 0, colour[pattern.At(x, y)])
+		}
+	}
+
+	return image
+}
+
+func TestDebayerUniform(t *testing.T) {
+	colour := [3]float64{1000, 2000, 3000}
+
+	for _, algorithm := range []Algorithm{Bilinear, VNG} {
+		for _, pattern := range []Pattern{RGGB, GRBG, GBRG, BGGR} {
+			t.Run(fmt.Sprintf("%s/%s", algorithm, pattern), func(t *testing.T) {
+				got, err := Debayer(newMosaic(7, 5, pattern, colour), pattern, algorithm)
+
+				if err != nil {
+					t.Fatalf("got %q", err)
+				}
+
+				if got.Rank != 3 || got.Planes != 3 || got.Width != 7 || got.Height != 5 {
+					t.Fatalf("got a rank %d image of %dx%dx%d, wanted a rank 3 image of 7x5x3", got.Rank, got.Width, got.Height, got.Planes)
+				}
+
+				for y := 0; y < got.Height; y++ {
+					for x := 0; x < got.Width; x++ {
+						for c := 0; c < 3; c++ {
+							if v := got.At(x, y, c); math.Abs(float64(v-colour[c])) > 1e-3 {
+								t.Fatalf("got %v for %s at (%d, %d), wanted %v", v, Channel(c), x, y, colour[c])
+							}
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDebayerEdge(t *testing.T) {
+	// A vertical edge between a dark and a bright grey, which VNG should blur less than bilinear interpolation:
+	image := alpacago.NewImage(alpacago.ImageElementInt32, 12, 12, 1)
+
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 12; x++ {
+			if x >= 6 {
+				image.Set(x, y, 0, 4000)
+			} else {
+				image.Set(x, y, 0, 100)
+			}
+		}
+	}
+
+	bilinear, err := Debayer(image, RGGB, Bilinear)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	vng, err := Debayer(image, RGGB, VNG)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	// The red pixel at (6, 6) lies on the bright side of the edge, with its green neighbour at (5, 6) on the dark side:
+	got, want := 4000-vng.At(6, 6, int(Green)), 4000-bilinear.At(6, 6, int(Green))
+
+	if got < 0 || got >= want {
+		t.Errorf("got an error of %v, wanted less than the bilinear error of %v", got, want)
+	}
+}
+
+func TestDebayerInvalid(t *testing.T) {
+	if _, err := Debayer(alpacago.NewImage(alpacago.ImageElementInt32, 4, 4, 3), RGGB, Bilinear); err == nil {
+		t.Errorf("got nil, wanted an error for a rank 3 image")
+	}
+
+	if _, err := Debayer(alpacago.NewImage(alpacago.ImageElementInt32, 2, 2, 1), RGGB, Bilinear); err == nil {
+		t.Errorf("got nil, wanted an error for a 2x2 image")
+	}
+}
+
+func TestForCamera(t *testing.T) {
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/sensortype":   fmt.Sprint(int32(alpacago.RGGBBayerEncoding)),
+		"camera/0/binx":         "1",
+		"camera/0/biny":         "1",
+		"camera/0/bayeroffsetx": "0",
+		"camera/0/bayeroffsety": "1",
+		"camera/0/startx":       "1",
+		"camera/0/starty":       "0",
+	}).Strict()
+
+	camera := alpacago.NewCameraWithOptions(ts.URL, 0)
+
+	pattern, err := NewPatternForCamera(context.Background(), camera)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if pattern != BGGR {
+		t.Errorf("got %v, wanted %v", pattern, BGGR)
+	}
+
+	colour := [3]float64{10, 20, 30}
+
+	got, err := ForCamera(context.Background(), camera, newMosaic(4, 4, BGGR, colour), Bilinear)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.At(0, 0, int(Red)) != 10 || got.At(0, 0, int(Green)) != 20 || got.At(0, 0, int(Blue)) != 30 {
+		t.Errorf("got %v, wanted %v", []float64{got.At(0, 0, 0), got.At(0, 0, 1), got.At(0, 0, 2)}, colour)
+	}
+
+	ts.Set("camera/0/binx", "2")
+
+	if _, err := NewPatternForCamera(context.Background(), camera); err == nil {
+		t.Errorf("got nil, wanted %v", ErrBinned)
+	}
+}
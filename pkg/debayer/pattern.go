@@ -0,0 +1,153 @@
+package debayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+type Channel int
+
+const (
+	Red Channel = iota
+	Green
+	Blue
+)
+
+func (c Channel) String() string {
+	switch c {
+	case Red:
+		return "R"
+	case Green:
+		return "G"
+	case Blue:
+		return "B"
+	default:
+		return fmt.Sprintf("Unknown Channel value: %d", c)
+	}
+}
+
+// ErrUnsupportedSensor is returned for sensors which are monochrome, or whose colour filter array is not a Bayer RGGB array.
+var ErrUnsupportedSensor = errors.New("debayer: unsupported sensor type")
+
+// ErrBinned is returned by ForCamera when the camera is binned, as binning mixes the colours of the Bayer array.
+var ErrBinned = errors.New("debayer: binned images cannot be debayered")
+
+/*
+Pattern
+
+The colour filter of each pixel of a 2x2 tile of the image, indexed [y%2][x%2] by the image pixel coordinates.
+*/
+type Pattern [2][2]Channel
+
+var (
+	RGGB = Pattern{{Red, Green}, {Green, Blue}}
+	GRBG = Pattern{{Green, Red}, {Blue, Green}}
+	GBRG = Pattern{{Green, Blue}, {Red, Green}}
+	BGGR = Pattern{{Blue, Green}, {Green, Red}}
+)
+
+func (p Pattern) String() string {
+	return p[0][0].String() + p[0][1].String() + p[1][0].String() + p[1][1].String()
+}
+
+/*
+At()
+
+@returns the colour filter of the image pixel at (x, y).
+*/
+func (p Pattern) At(x int, y int) Channel {
+	return p[y&1][x&1]
+}
+
+/*
+NewPattern()
+
+@param sensor alpacago.SensorType (the sensor type of the camera)
+@param offsetX, offsetY int32 (the Bayer offsets of the camera, i.e., the sensor pixel at which the RGGB array starts)
+@param startX, startY int32 (the origin of the subframe on the sensor, which shifts the phase of the array)
+@returns the colour filter pattern of the image, in image pixel coordinates, or ErrUnsupportedSensor if the
+sensor is monochrome or does not have an RGGB Bayer array.
+@see https://ascom-standards.org/api/#/Camera%20Specific%20Methods/get_camera__device_number__bayeroffsetx
+*/
+func NewPattern(sensor alpacago.SensorType, offsetX int32, offsetY int32, startX int32, startY int32) (Pattern, error) {
+	if sensor != alpacago.RGGBBayerEncoding {
+		return Pattern{}, fmt.Errorf("%w: %s", ErrUnsupportedSensor, sensor)
+	}
+
+	// The image pixel (x, y) is the sensor pixel (startX + x, startY + y), whose phase within the RGGB array is
+	// relative to the Bayer offsets:
+	phaseX, phaseY := (startX-offsetX)&1, (startY-offsetY)&1
+
+	p := Pattern{}
+
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			p[y][x] = RGGB[(int32(y)+phaseY)&1][(int32(x)+phaseX)&1]
+		}
+	}
+
+	return p, nil
+}
+
+/*
+NewPatternForCamera()
+
+@returns the colour filter pattern of images from the camera at its current subframe, read from the sensor type,
+Bayer offsets and subframe origin of the camera, see NewPattern().
+*/
+func NewPatternForCamera(ctx context.Context, camera *alpacago.Camera) (Pattern, error) {
+	sensor, err := camera.GetSensorTypeContext(ctx)
+
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	if sensor != alpacago.RGGBBayerEncoding {
+		return Pattern{}, fmt.Errorf("%w: %s", ErrUnsupportedSensor, sensor)
+	}
+
+	binX, err := camera.GetBinXContext(ctx)
+
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	binY, err := camera.GetBinYContext(ctx)
+
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	if binX != 1 || binY != 1 {
+		return Pattern{}, fmt.Errorf("%w: binning is %dx%d", ErrBinned, binX, binY)
+	}
+
+	offsetX, err := camera.GetBayerOffsetXContext(ctx)
+
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	offsetY, err := camera.GetBayerOffsetYContext(ctx)
+
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	startX, err := camera.GetStartXContext(ctx)
+
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	startY, err := camera.GetStartYContext(ctx)
+
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	return NewPattern(sensor, offsetX, offsetY, startX, startY)
+}
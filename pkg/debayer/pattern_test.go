@@ -0,0 +1,46 @@
+package debayer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+func TestNewPattern(t *testing.T) {
+	tests := []struct {
+		name                             string
+		offsetX, offsetY, startX, startY int32
+		want                             Pattern
+	}{
+		{"RGGB", 0, 0, 0, 0, RGGB},
+		{"OffsetX", 1, 0, 0, 0, GRBG},
+		{"OffsetY", 0, 1, 0, 0, GBRG},
+		{"OffsetXY", 1, 1, 0, 0, BGGR},
+		{"StartX", 0, 0, 1, 0, GRBG},
+		{"StartY", 0, 0, 0, 3, GBRG},
+		{"OffsetAndStart", 1, 1, 101, 51, RGGB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPattern(alpacago.RGGBBayerEncoding, tt.offsetX, tt.offsetY, tt.startX, tt.startY)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPatternUnsupported(t *testing.T) {
+	for _, sensor := range []alpacago.SensorType{alpacago.Monochrome, alpacago.CMYGBayerEncoding, alpacago.CMYG2BayerEncoding, alpacago.LRGBTRUESENSEBayerEncoding} {
+		if _, err := NewPattern(sensor, 0, 0, 0, 0); !errors.Is(err, ErrUnsupportedSensor) {
+			t.Errorf("got %v for %s, wanted %v", err, sensor, ErrUnsupportedSensor)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package fits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// The format of the DATE-OBS keyword, in UTC:
+const DATE_OBS_FORMAT = "2006-01-02T15:04:05.000"
+
+/*
+Devices
+
+The device handles from which the FITS header of an exposure is populated. Any handle may be nil, in which
+case the keywords of that device are omitted.
+*/
+type Devices struct {
+	Camera      *alpacago.Camera
+	Telescope   *alpacago.Telescope
+	Focuser     *alpacago.Focuser
+	FilterWheel *alpacago.FilterWheel
+	Rotator     *alpacago.Rotator
+}
+
+/*
+headerErrors
+
+Collects the errors of the individual property reads when populating a header, ignoring any property that
+is not implemented by the driver.
+*/
+type headerErrors []error
+
+func (e *headerErrors) add(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	if !errors.Is(err, alpacago.ErrNotImplemented) {
+		*e = append(*e, err)
+	}
+
+	return false
+}
+
+func (e headerErrors) join() error {
+	return errors.Join(e...)
+}
+
+/*
+AddExposure()
+
+Sets the EXPTIME, DATE-OBS and IMAGETYP keywords from an exposure taken by Camera.Expose().
+*/
+func (h *Header) AddExposure(exposure *alpacago.Exposure) {
+	h.Set("EXPTIME", exposure.Duration, "[s] exposure duration")
+
+	if exposure.StartTime != nil {
+		h.Set("DATE-OBS", exposure.StartTime.UTC().Format(DATE_OBS_FORMAT), "[UTC] start of exposure")
+	}
+
+	if exposure.Light {
+		h.Set("IMAGETYP", "Light Frame", "type of image")
+	} else {
+		h.Set("IMAGETYP", "Dark Frame", "type of image")
+	}
+}
+
+/*
+AddCamera()
+
+Sets the INSTRUME, XBINNING, YBINNING, CCD-TEMP, SET-TEMP, GAIN, EGAIN, OFFSET, XPIXSZ, YPIXSZ, XORGSUBF,
+YORGSUBF and, for colour sensors, the BAYERPAT, XBAYROFF and YBAYROFF keywords from the camera. GAIN is the gain
+setting of the camera, in its own units, whereas EGAIN is the conversion gain, in electrons per ADU.
+
+@returns the errors of any property reads, other than those not implemented by the driver.
+*/
+func (h *Header) AddCamera(ctx context.Context, camera *alpacago.Camera) error {
+	var errs headerErrors
+
+	if name, err := camera.Alpaca.GetNameContext(ctx, "camera", camera.DeviceNumber); errs.add(err) {
+		h.Set("INSTRUME", name, "name of camera")
+	}
+
+	binX, err := camera.GetBinXContext(ctx)
+
+	if errs.add(err) {
+		h.Set("XBINNING", binX, "binning factor in width")
+	}
+
+	binY, err := camera.GetBinYContext(ctx)
+
+	if errs.add(err) {
+		h.Set("YBINNING", binY, "binning factor in height")
+	}
+
+	if temperature, err := camera.GetCCDTemperatureContext(ctx); errs.add(err) {
+		h.Set("CCD-TEMP", temperature, "[C] sensor temperature")
+	}
+
+	if cooler, err := camera.IsCoolerOnContext(ctx); errs.add(err) && cooler {
+		if setpoint, err := camera.GetCCDTemperatureCoolerSetPointContext(ctx); errs.add(err) {
+			h.Set("SET-TEMP", setpoint, "[C] sensor temperature setpoint")
+		}
+	}
+
+	if gain, err := camera.GetGainContext(ctx); errs.add(err) {
+		h.Set("GAIN", gain, "sensor gain")
+	}
+
+	if electrons, err := camera.GetGainInElectronsPerADUnitContext(ctx); errs.add(err) {
+		h.Set("EGAIN", electrons, "[e-/ADU] electrons per ADU")
+	}
+
+	if offset, err := camera.GetOffsetContext(ctx); errs.add(err) {
+		h.Set("OFFSET", offset, "sensor offset")
+	}
+
+	// The pixel sizes are of the binned pixels:
+	if size, err := camera.GetPixelSizeXContext(ctx); errs.add(err) && binX > 0 {
+		h.Set("XPIXSZ", size*float64(binX), "[um] pixel width, including binning")
+	}
+
+	if size, err := camera.GetPixelSizeYContext(ctx); errs.add(err) && binY > 0 {
+		h.Set("YPIXSZ", size*float64(binY), "[um] pixel height, including binning")
+	}
+
+	if x, err := camera.GetStartXContext(ctx); errs.add(err) {
+		h.Set("XORGSUBF", x, "subframe x origin, in binned pixels")
+	}
+
+	if y, err := camera.GetStartYContext(ctx); errs.add(err) {
+		h.Set("YORGSUBF", y, "subframe y origin, in binned pixels")
+	}
+
+	sensor, err := camera.GetSensorTypeContext(ctx)
+
+	if errs.add(err) && sensor >= alpacago.RGGBBayerEncoding {
+		h.Set("BAYERPAT", sensor.String(), "bayer colour filter array pattern")
+
+		if x, err := camera.GetBayerOffsetXContext(ctx); errs.add(err) {
+			h.Set("XBAYROFF", x, "x offset of the bayer pattern")
+		}
+
+		if y, err := camera.GetBayerOffsetYContext(ctx); errs.add(err) {
+			h.Set("YBAYROFF", y, "y offset of the bayer pattern")
+		}
+	}
+
+	return errs.join()
+}
+
+/*
+AddTelescope()
+
+Sets the TELESCOP, RA, DEC, OBJCTRA, OBJCTDEC, OBJCTALT, OBJCTAZ, PIERSIDE, SITELAT, SITELONG, SITEELEV,
+FOCALLEN and APTDIA keywords from the telescope.
+
+@returns the errors of any property reads, other than those not implemented by the driver.
+*/
+func (h *Header) AddTelescope(ctx context.Context, telescope *alpacago.Telescope) error {
+	var errs headerErrors
+
+	if name, err := telescope.Alpaca.GetNameContext(ctx, "telescope", telescope.DeviceNumber); errs.add(err) {
+		h.Set("TELESCOP", name, "name of telescope")
+	}
+
+	// The right ascension of the telescope is in hours, and is recorded in degrees:
+	if ra, err := telescope.GetRightAscensionContext(ctx); errs.add(err) {
+		h.Set("RA", ra*15, "[deg] right ascension of telescope")
+		h.Set("OBJCTRA", sexagesimal(ra, " "), "[hms] right ascension of telescope")
+	}
+
+	if dec, err := telescope.GetDeclinationContext(ctx); errs.add(err) {
+		h.Set("DEC", dec, "[deg] declination of telescope")
+		h.Set("OBJCTDEC", sexagesimal(dec, " "), "[dms] declination of telescope")
+	}
+
+	if alt, err := telescope.GetAltitudeContext(ctx); errs.add(err) {
+		h.Set("OBJCTALT", alt, "[deg] altitude of telescope")
+	}
+
+	if az, err := telescope.GetAzimuthContext(ctx); errs.add(err) {
+		h.Set("OBJCTAZ", az, "[deg] azimuth of telescope")
+	}
+
+	if side, err := telescope.GetSideOfPierContext(ctx); errs.add(err) {
+		switch side {
+		case alpacago.PierEast:
+			h.Set("PIERSIDE", "EAST", "side of pier of telescope")
+		case alpacago.PierWest:
+			h.Set("PIERSIDE", "WEST", "side of pier of telescope")
+		}
+	}
+
+	if lat, err := telescope.GetSiteLatitudeContext(ctx); errs.add(err) {
+		h.Set("SITELAT", lat, "[deg] latitude of site")
+	}
+
+	if lon, err := telescope.GetSiteLongitudeContext(ctx); errs.add(err) {
+		h.Set("SITELONG", lon, "[deg] longitude of site, east positive")
+	}
+
+	if elev, err := telescope.GetSiteElevationContext(ctx); errs.add(err) {
+		h.Set("SITEELEV", elev, "[m] elevation of site")
+	}
+
+	// The focal length and aperture of the telescope are in metres, and are recorded in millimetres:
+	if focal, err := telescope.GetFocalLengthContext(ctx); errs.add(err) && focal > 0 {
+		h.Set("FOCALLEN", focal*1000, "[mm] focal length of telescope")
+	}
+
+	if aperture, err := telescope.GetApertureDiameterContext(ctx); errs.add(err) && aperture > 0 {
+		h.Set("APTDIA", aperture*1000, "[mm] aperture diameter of telescope")
+	}
+
+	return errs.join()
+}
+
+/*
+AddFocuser()
+
+Sets the FOCUSPOS and FOCTEMP keywords from the focuser.
+
+@returns the errors of any property reads, other than those not implemented by the driver.
+*/
+func (h *Header) AddFocuser(ctx context.Context, focuser *alpacago.Focuser) error {
+	var errs headerErrors
+
+	if position, err := focuser.GetPositionContext(ctx); errs.add(err) {
+		h.Set("FOCUSPOS", position, "[step] focuser position")
+	}
+
+	if temperature, err := focuser.GetTemperatureContext(ctx); errs.add(err) {
+		h.Set("FOCTEMP", temperature, "[C] focuser temperature")
+	}
+
+	return errs.join()
+}
+
+/*
+AddFilterWheel()
+
+Sets the FILTER keyword to the name of the current filter of the filter wheel.
+
+@returns the errors of any property reads, other than those not implemented by the driver.
+*/
+func (h *Header) AddFilterWheel(ctx context.Context, filterWheel *alpacago.FilterWheel) error {
+	var errs headerErrors
+
+	position, err := filterWheel.GetPositionContext(ctx)
+
+	// The position is -1 whilst the filter wheel is moving:
+	if !errs.add(err) || position < 0 {
+		return errs.join()
+	}
+
+	if names, err := filterWheel.GetNamesContext(ctx); errs.add(err) && int(position) < len(names) {
+		h.Set("FILTER", names[position], "name of filter")
+	}
+
+	return errs.join()
+}
+
+/*
+AddRotator()
+
+Sets the ROTATANG keyword from the rotator.
+
+@returns the errors of any property reads, other than those not implemented by the driver.
+*/
+func (h *Header) AddRotator(ctx context.Context, rotator *alpacago.Rotator) error {
+	var errs headerErrors
+
+	if position, err := rotator.GetPositionContext(ctx); errs.add(err) {
+		h.Set("ROTATANG", position, "[deg] rotator angle")
+	}
+
+	return errs.join()
+}
+
+/*
+AddDevices()
+
+Sets the keywords of each of the non-nil device handles.
+
+@returns the errors of every device, joined.
+*/
+func (h *Header) AddDevices(ctx context.Context, devices Devices) error {
+	var errs []error
+
+	if devices.Camera != nil {
+		errs = append(errs, h.AddCamera(ctx, devices.Camera))
+	}
+
+	if devices.Telescope != nil {
+		errs = append(errs, h.AddTelescope(ctx, devices.Telescope))
+	}
+
+	if devices.Focuser != nil {
+		errs = append(errs, h.AddFocuser(ctx, devices.Focuser))
+	}
+
+	if devices.FilterWheel != nil {
+		errs = append(errs, h.AddFilterWheel(ctx, devices.FilterWheel))
+	}
+
+	if devices.Rotator != nil {
+		errs = append(errs, h.AddRotator(ctx, devices.Rotator))
+	}
+
+	return errors.Join(errs...)
+}
+
+/*
+NewHeaderFromDevices()
+
+@returns a new header populated from the exposure, if non-nil, and from each of the non-nil device handles.
+*/
+func NewHeaderFromDevices(ctx context.Context, exposure *alpacago.Exposure, devices Devices) (*Header, error) {
+	h := NewHeader()
+
+	if exposure != nil {
+		h.AddExposure(exposure)
+	}
+
+	return h, h.AddDevices(ctx, devices)
+}
+
+/*
+sexagesimal()
+
+@returns the value in sexagesimal notation, e.g., "+12 34 56.78", separated by the separator.
+*/
+func sexagesimal(v float64, separator string) string {
+	sign := "+"
+
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	// Round to hundredths of a second, so that 59.999 seconds carries into the minutes:
+	hundredths := int64(math.Round(v * 360000))
+
+	d := hundredths / 360000
+	m := (hundredths / 6000) % 60
+	s := float64(hundredths%6000) / 100
+
+	return fmt.Sprintf("%s%02d%s%02d%s%05.2f", sign, d, separator, m, separator, s)
+}
@@ -0,0 +1,96 @@
+package fits
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+func TestNewHeaderFromDevices(t *testing.T) {
+	// Any other property is not implemented by the drivers:
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/name":                `"Alpaca Camera Sim"`,
+		"camera/0/binx":                `2`,
+		"camera/0/biny":                `2`,
+		"camera/0/ccdtemperature":      `-10.0`,
+		"camera/0/cooleron":            `true`,
+		"camera/0/setccdtemperature":   `-10.0`,
+		"camera/0/gain":                `100`,
+		"camera/0/electronsperadu":     `1.5`,
+		"camera/0/offset":              `50`,
+		"camera/0/pixelsizex":          `3.76`,
+		"camera/0/pixelsizey":          `3.76`,
+		"camera/0/sensortype":          `2`,
+		"camera/0/bayeroffsetx":        `0`,
+		"camera/0/bayeroffsety":        `1`,
+		"telescope/0/rightascension":   `5.5`,
+		"telescope/0/declination":      `-5.391`,
+		"telescope/0/sideofpier":       `1`,
+		"telescope/0/sitelatitude":     `19.82`,
+		"telescope/0/focallength":      `0.53`,
+		"focuser/0/position":           `12500`,
+		"focuser/0/temperature":        `4.5`,
+		"filterwheel/0/position":       `1`,
+		"filterwheel/0/names":          `["L","Ha","OIII"]`,
+		"rotator/0/position":           `90.5`,
+		"telescope/0/aperturediameter": `0.1`,
+	})
+
+	server := alpacago.NewAlpacaServer(ts.URL)
+
+	start := time.Date(2026, 10, 17, 21, 30, 0, 123000000, time.UTC)
+
+	header, err := NewHeaderFromDevices(context.Background(), &alpacago.Exposure{Light: true, StartTime: &start, Duration: 300}, Devices{
+		Camera:      server.Camera(0),
+		Telescope:   server.Telescope(0),
+		Focuser:     server.Focuser(0),
+		FilterWheel: server.FilterWheel(0),
+		Rotator:     server.Rotator(0),
+	})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	want := map[string]interface{}{
+		"EXPTIME":  300.0,
+		"DATE-OBS": "2026-10-17T21:30:00.123",
+		"INSTRUME": "Alpaca Camera Sim",
+		"XBINNING": int32(2),
+		"CCD-TEMP": -10.0,
+		"SET-TEMP": -10.0,
+		"GAIN":     int32(100),
+		"EGAIN":    1.5,
+		"OFFSET":   int32(50),
+		"XPIXSZ":   7.52,
+		"BAYERPAT": "RGGB",
+		"YBAYROFF": int32(1),
+		"RA":       82.5,
+		"OBJCTRA":  "+05 30 00.00",
+		"OBJCTDEC": "-05 23 27.60",
+		"PIERSIDE": "WEST",
+		"SITELAT":  19.82,
+		"FOCALLEN": 530.0,
+		"APTDIA":   100.0,
+		"FOCUSPOS": int32(12500),
+		"FOCTEMP":  4.5,
+		"FILTER":   "Ha",
+		"ROTATANG": 90.5,
+	}
+
+	for keyword, value := range want {
+		got, ok := header.Get(keyword)
+
+		if !ok || got != value {
+			t.Errorf("got %s = %v (%T), wanted %v (%T)", keyword, got, got, value, value)
+		}
+	}
+
+	// The telescope does not implement the altitude, so the keyword is omitted:
+	if _, ok := header.Get("OBJCTALT"); ok {
+		t.Errorf("got OBJCTALT, wanted it to be omitted")
+	}
+}
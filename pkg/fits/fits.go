@@ -0,0 +1,362 @@
+package fits
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+/*
+Options
+
+Configures the FITS file written by Write(). A zero Bitpix chooses the BITPIX from the element type of the
+image, see Bitpix().
+*/
+type Options struct {
+	Bitpix int
+	Rice   bool
+}
+
+/*
+Bitpix()
+
+@returns the FITS BITPIX for the image. Images of 32 bit integers whose values all fit within 16 bits, as is
+usual for cameras which transmit 16 bit data as 32 bit integers, are narrowed to unsigned 16 bit integers.
+*/
+func Bitpix(image *alpacago.Image) int {
+	switch image.ElementType {
+	case alpacago.ImageElementByte:
+		return 8
+	case alpacago.ImageElementInt16, alpacago.ImageElementUInt16:
+		return 16
+	case alpacago.ImageElementInt32:
+		for _, v := range image.Pix {
+			if v < 0 || v > math.MaxUint16 {
+				return 32
+			}
+		}
+		return 16
+	case alpacago.ImageElementInt64, alpacago.ImageElementUInt64:
+		return 64
+	case alpacago.ImageElementDouble:
+		return -64
+	default:
+		return -32
+	}
+}
+
+/*
+bzero()
+
+@returns the BZERO offset for the BITPIX, which allows unsigned 16 bit images to be stored as signed 16 bit
+integers, and is 0 otherwise. A BITPIX of 8 is always unsigned.
+*/
+func bzero(image *alpacago.Image, bitpix int) int64 {
+	if bitpix != 16 || image.ElementType == alpacago.ImageElementInt16 {
+		return 0
+	}
+
+	return 32768
+}
+
+/*
+imageHeader()
+
+@returns the mandatory keywords of an image of the given BITPIX, followed by the cards of the given header.
+*/
+func imageHeader(image *alpacago.Image, bitpix int, header *Header) *Header {
+	h := NewHeader()
+
+	h.Set("SIMPLE", true, "conforms to FITS standard")
+	h.Set("BITPIX", bitpix, "array data type")
+
+	appendAxes(h, "NAXIS", image)
+
+	if z := bzero(image, bitpix); z != 0 {
+		h.Set("BZERO", z, "offset data range to that of unsigned short")
+		h.Set("BSCALE", 1, "default scaling factor")
+	}
+
+	return appendHeader(h, header)
+}
+
+func appendAxes(h *Header, prefix string, image *alpacago.Image) {
+	h.Set(prefix, image.Rank, "number of array dimensions")
+	h.Set(prefix+"1", image.Width, "")
+	h.Set(prefix+"2", image.Height, "")
+
+	if image.Rank == 3 {
+		h.Set(prefix+"3", image.Planes, "")
+	}
+}
+
+func appendHeader(h *Header, header *Header) *Header {
+	if header == nil {
+		return h
+	}
+
+	for _, c := range header.Cards {
+		// The structural keywords are owned by the writer:
+		switch c.Keyword {
+		case "SIMPLE", "XTENSION", "BITPIX", "NAXIS", "NAXIS1", "NAXIS2", "NAXIS3", "PCOUNT", "GCOUNT", "EXTEND", "BZERO", "BSCALE", "END":
+			continue
+		}
+
+		h.Cards = append(h.Cards, c)
+	}
+
+	return h
+}
+
+/*
+integerPixels()
+
+@returns the stored integer values of the image pixels for an integer BITPIX, i.e., the physical values less
+BZERO, rounded and clamped to the range of the BITPIX.
+*/
+func integerPixels(image *alpacago.Image, bitpix int) []int64 {
+	z := bzero(image, bitpix)
+
+	lo, hi := int64(math.MinInt64), int64(math.MaxInt64)
+
+	switch bitpix {
+	case 8:
+		lo, hi = 0, math.MaxUint8
+	case 16:
+		lo, hi = math.MinInt16, math.MaxInt16
+	case 32:
+		lo, hi = math.MinInt32, math.MaxInt32
+	}
+
+	pixels := make([]int64, len(image.Pix))
+
+	for i, v := range image.Pix {
+		f := math.Round(v) - float64(z)
+
+		switch {
+		case math.IsNaN(f):
+			pixels[i] = 0
+		case f <= float64(lo):
+			pixels[i] = lo
+		case f >= float64(hi):
+			pixels[i] = hi
+		default:
+			pixels[i] = int64(f)
+		}
+	}
+
+	return pixels
+}
+
+/*
+writeData()
+
+Writes the image pixels, as big-endian values of the BITPIX, padded with zeros to a whole number of blocks.
+*/
+func writeData(w io.Writer, image *alpacago.Image, bitpix int) error {
+	size := bitpix / 8
+
+	if size < 0 {
+		size = -size
+	}
+
+	buf := make([]byte, len(image.Pix)*size, len(image.Pix)*size+BLOCK_LENGTH)
+
+	switch bitpix {
+	case -32:
+		for i, v := range image.Pix {
+			binary.BigEndian.PutUint32(buf[i*4:], math.Float32bits(float32(v)))
+		}
+	case -64:
+		for i, v := range image.Pix {
+			binary.BigEndian.PutUint64(buf[i*8:], math.Float64bits(v))
+		}
+	default:
+		for i, v := range integerPixels(image, bitpix) {
+			switch bitpix {
+			case 8:
+				buf[i] = byte(v)
+			case 16:
+				binary.BigEndian.PutUint16(buf[i*2:], uint16(v))
+			case 32:
+				binary.BigEndian.PutUint32(buf[i*4:], uint32(v))
+			case 64:
+				binary.BigEndian.PutUint64(buf[i*8:], uint64(v))
+			}
+		}
+	}
+
+	_, err := w.Write(pad(buf, 0))
+
+	return err
+}
+
+func pad(b []byte, fill byte) []byte {
+	if r := len(b) % BLOCK_LENGTH; r > 0 {
+		for i := r; i < BLOCK_LENGTH; i++ {
+			b = append(b, fill)
+		}
+	}
+
+	return b
+}
+
+/*
+Write()
+
+Writes the image, with the given header cards, as a FITS file. If Rice compression is requested, the image is
+written as a tile compressed BINTABLE extension, with one tile per row, following an empty primary HDU.
+
+@returns an error if the image cannot be written, or if Rice compression is requested for floating point pixels.
+*/
+func Write(w io.Writer, image *alpacago.Image, header *Header, opts Options) error {
+	bitpix := opts.Bitpix
+
+	if bitpix == 0 {
+		bitpix = Bitpix(image)
+	}
+
+	switch bitpix {
+	case 8, 16, 32, 64, -32, -64:
+	default:
+		return fmt.Errorf("fits: invalid BITPIX %d", bitpix)
+	}
+
+	if image.Rank != 2 && image.Rank != 3 {
+		return fmt.Errorf("fits: unsupported image rank %d", image.Rank)
+	}
+
+	if opts.Rice {
+		return writeRice(w, image, header, bitpix)
+	}
+
+	if _, err := w.Write(imageHeader(image, bitpix, header).Bytes()); err != nil {
+		return err
+	}
+
+	return writeData(w, image, bitpix)
+}
+
+/*
+WriteFile()
+
+Writes the image, with the given header cards, as a FITS file at the given path, see Write().
+*/
+func WriteFile(path string, image *alpacago.Image, header *Header, opts Options) error {
+	f, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+
+	if err := Write(w, image, header, opts); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+/*
+writeRice()
+
+Writes the image as an empty primary HDU, followed by a RICE_1 tile compressed BINTABLE extension, with a tile
+for each row of each plane of the image.
+*/
+func writeRice(w io.Writer, image *alpacago.Image, header *Header, bitpix int) error {
+	if bitpix < 0 || bitpix == 64 {
+		return fmt.Errorf("fits: rice compression requires 8, 16 or 32 bit integer pixels, not BITPIX %d", bitpix)
+	}
+
+	bytepix := bitpix / 8
+
+	pixels := integerPixels(image, bitpix)
+
+	// Compress each row of each plane as a separate tile, collecting the tiles into the heap:
+	rows := image.Height * image.Planes
+
+	descriptors := make([]byte, rows*8)
+
+	heap := []byte{}
+
+	longest := 0
+
+	for row := 0; row < rows; row++ {
+		tile := riceCompress(pixels[row*image.Width:(row+1)*image.Width], bytepix)
+
+		binary.BigEndian.PutUint32(descriptors[row*8:], uint32(len(tile)))
+		binary.BigEndian.PutUint32(descriptors[row*8+4:], uint32(len(heap)))
+
+		heap = append(heap, tile...)
+
+		if len(tile) > longest {
+			longest = len(tile)
+		}
+	}
+
+	primary := NewHeader()
+
+	primary.Set("SIMPLE", true, "conforms to FITS standard")
+	primary.Set("BITPIX", 8, "array data type")
+	primary.Set("NAXIS", 0, "number of array dimensions")
+	primary.Set("EXTEND", true, "")
+
+	if _, err := w.Write(primary.Bytes()); err != nil {
+		return err
+	}
+
+	h := NewHeader()
+
+	h.Set("XTENSION", "BINTABLE", "binary table extension")
+	h.Set("BITPIX", 8, "8-bit bytes")
+	h.Set("NAXIS", 2, "2-dimensional binary table")
+	h.Set("NAXIS1", 8, "width of table in bytes")
+	h.Set("NAXIS2", rows, "number of rows in table")
+	h.Set("PCOUNT", len(heap), "size of special data area")
+	h.Set("GCOUNT", 1, "one data group (required keyword)")
+	h.Set("TFIELDS", 1, "number of fields in each row")
+	h.Set("TTYPE1", "COMPRESSED_DATA", "label for field 1")
+	h.Set("TFORM1", fmt.Sprintf("1PB(%d)", longest), "data format of field: variable length array")
+	h.Set("ZIMAGE", true, "extension contains compressed image")
+	h.Set("ZBITPIX", bitpix, "data type of original image")
+
+	appendAxes(h, "ZNAXIS", image)
+
+	h.Set("ZTILE1", image.Width, "size of tiles to be compressed")
+	h.Set("ZTILE2", 1, "size of tiles to be compressed")
+
+	if image.Rank == 3 {
+		h.Set("ZTILE3", 1, "size of tiles to be compressed")
+	}
+
+	h.Set("ZCMPTYPE", "RICE_1", "compression algorithm")
+	h.Set("ZNAME1", "BLOCKSIZE", "compression block size")
+	h.Set("ZVAL1", RICE_BLOCK_SIZE, "pixels per block")
+	h.Set("ZNAME2", "BYTEPIX", "bytes per pixel (1, 2, 4, or 8)")
+	h.Set("ZVAL2", bytepix, "bytes per pixel (1, 2, 4, or 8)")
+
+	if z := bzero(image, bitpix); z != 0 {
+		h.Set("BZERO", z, "offset data range to that of unsigned short")
+		h.Set("BSCALE", 1, "default scaling factor")
+	}
+
+	if _, err := w.Write(appendHeader(h, header).Bytes()); err != nil {
+		return err
+	}
+
+	_, err := w.Write(pad(append(descriptors, heap...), 0))
+
+	return err
+}
@@ -0,0 +1,320 @@
+package fits
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// readHeader parses the header starting at the given offset, returning the keyword values and the offset of the data:
+func readHeader(t *testing.T, b []byte, offset int) (map[string]string, int) {
+	values := map[string]string{}
+
+	for ; offset+CARD_LENGTH <= len(b); offset += CARD_LENGTH {
+		card := string(b[offset : offset+CARD_LENGTH])
+
+		keyword := strings.TrimSpace(card[:8])
+
+		if keyword == "END" {
+			offset += CARD_LENGTH
+			return values, offset + (BLOCK_LENGTH-offset%BLOCK_LENGTH)%BLOCK_LENGTH
+		}
+
+		if card[8:10] == "= " {
+			value := card[10:]
+
+			if strings.HasPrefix(strings.TrimSpace(value), "'") {
+				value = strings.TrimSpace(value)
+				value = strings.TrimSpace(value[1 : 1+strings.Index(value[1:], "'")])
+			} else if i := strings.Index(value, "/"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			} else {
+				value = strings.TrimSpace(value)
+			}
+
+			values[keyword] = value
+		}
+	}
+
+	t.Fatalf("got no END card")
+
+	return nil, 0
+}
+
+func atoi(t *testing.T, s string) int {
+	i, err := strconv.Atoi(s)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return i
+}
+
+// riceDecompress decodes a RICE_1 tile, following the fits_rdecomp algorithm of CFITSIO:
+func riceDecompress(t *testing.T, c []byte, n int, bytepix int) []int64 {
+	var fsbits, fsmax int
+
+	bbits := bytepix * 8
+
+	switch bytepix {
+	case 1:
+		fsbits, fsmax = 3, 6
+	case 2:
+		fsbits, fsmax = 4, 14
+	default:
+		fsbits, fsmax = 5, 25
+	}
+
+	pos := 0
+
+	read := func(nbits int) uint64 {
+		var v uint64
+
+		for i := 0; i < nbits; i++ {
+			if pos/8 >= len(c) {
+				t.Fatalf("got a truncated tile")
+			}
+
+			v = v<<1 | uint64(c[pos/8]>>(7-pos%8)&1)
+			pos++
+		}
+
+		return v
+	}
+
+	// Bytes are unsigned, wider pixels are signed:
+	wrap := func(v int64) int64 {
+		if bytepix == 1 {
+			return v & 0xFF
+		}
+
+		shift := 64 - bbits
+
+		return (v << shift) >> shift
+	}
+
+	pixels := make([]int64, n)
+
+	last := wrap(int64(read(bbits)))
+
+	for i := 0; i < n; {
+		fs := int(read(fsbits)) - 1
+
+		end := i + RICE_BLOCK_SIZE
+
+		if end > n {
+			end = n
+		}
+
+		for ; i < end; i++ {
+			var diff uint64
+
+			switch {
+			case fs < 0:
+				diff = 0
+			case fs == fsmax:
+				diff = read(bbits)
+			default:
+				top := uint64(0)
+
+				for read(1) == 0 {
+					top++
+				}
+
+				diff = top<<fs | read(fs)
+			}
+
+			var d int64
+
+			if diff&1 == 0 {
+				d = int64(diff >> 1)
+			} else {
+				d = ^int64(diff >> 1)
+			}
+
+			last = wrap(last + d)
+
+			pixels[i] = last
+		}
+	}
+
+	return pixels
+}
+
+func newTestImage(elementType alpacago.ImageArrayElementType, width int, height int, planes int, max float64) *alpacago.Image {
+	image := alpacago.NewImage(elementType, width, height, planes)
+
+	r := rand.New(rand.NewSource(42))
+
+	for i := range image.Pix {
+		// A noisy gradient, with occasional hot pixels to exercise the high entropy blocks:
+		image.Pix[i] = float64(int(float64(i%width)*max/float64(2*width)) + r.Intn(64))
+
+		if r.Intn(50) == 0 {
+			image.Pix[i] = max
+		}
+	}
+
+	return image
+}
+
+func TestWriteUInt16(t *testing.T) {
+	image := newTestImage(alpacago.ImageElementInt32, 37, 11, 1, 65535)
+
+	header := NewHeader()
+
+	header.Set("FILTER", "Ha", "name of filter")
+
+	// The writer owns the structural keywords:
+	header.Set("BITPIX", -64, "")
+
+	var buf bytes.Buffer
+
+	if err := Write(&buf, image, header, Options{}); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	b := buf.Bytes()
+
+	if len(b)%BLOCK_LENGTH != 0 {
+		t.Errorf("got %d bytes, wanted a whole number of blocks", len(b))
+	}
+
+	values, data := readHeader(t, b, 0)
+
+	if values["BITPIX"] != "16" || values["BZERO"] != "32768" || values["NAXIS1"] != "37" || values["NAXIS2"] != "11" || values["FILTER"] != "Ha" {
+		t.Errorf("got %v, wanted an unsigned 16 bit 37x11 image", values)
+	}
+
+	for i, v := range image.Pix {
+		got := int(int16(binary.BigEndian.Uint16(b[data+i*2:]))) + 32768
+
+		if got != int(v) {
+			t.Fatalf("got %d at pixel %d, wanted %d", got, i, int(v))
+		}
+	}
+}
+
+func TestWriteFloat(t *testing.T) {
+	image := alpacago.NewImage(alpacago.ImageElementSingle, 2, 2, 3)
+
+	image.Set(1, 1, 2, 0.5)
+
+	var buf bytes.Buffer
+
+	if err := Write(&buf, image, nil, Options{}); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	values, data := readHeader(t, buf.Bytes(), 0)
+
+	if values["BITPIX"] != "-32" || values["NAXIS"] != "3" || values["NAXIS3"] != "3" {
+		t.Errorf("got %v, wanted a rank 3 float image", values)
+	}
+
+	// The last pixel of the last plane:
+	got := binary.BigEndian.Uint32(buf.Bytes()[data+11*4:])
+
+	if got != 0x3F000000 {
+		t.Errorf("got %#x, wanted %#x", got, 0x3F000000)
+	}
+
+	if err := Write(&buf, image, nil, Options{Rice: true}); err == nil {
+		t.Errorf("got nil, wanted an error for rice compressed float pixels")
+	}
+}
+
+func TestWriteRice(t *testing.T) {
+	tests := []struct {
+		name   string
+		image  *alpacago.Image
+		bitpix int
+	}{
+		{"UInt16", newTestImage(alpacago.ImageElementUInt16, 100, 7, 1, 65535), 16},
+		{"Int16", newTestImage(alpacago.ImageElementInt16, 33, 5, 1, 30000), 16},
+		{"Int32", newTestImage(alpacago.ImageElementInt32, 65, 4, 2, 1<<20), 32},
+		{"Byte", newTestImage(alpacago.ImageElementByte, 31, 3, 1, 255), 8},
+		{"Flat", alpacago.NewImage(alpacago.ImageElementUInt16, 64, 2, 1), 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := Write(&buf, tt.image, nil, Options{Rice: true}); err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			b := buf.Bytes()
+
+			primary, offset := readHeader(t, b, 0)
+
+			if primary["NAXIS"] != "0" || primary["EXTEND"] != "T" {
+				t.Errorf("got %v, wanted an empty primary HDU", primary)
+			}
+
+			values, data := readHeader(t, b, offset)
+
+			if values["XTENSION"] != "BINTABLE" || values["ZCMPTYPE"] != "RICE_1" || atoi(t, values["ZBITPIX"]) != tt.bitpix {
+				t.Fatalf("got %v, wanted a RICE_1 compressed BINTABLE of BITPIX %d", values, tt.bitpix)
+			}
+
+			rows := atoi(t, values["NAXIS2"])
+
+			heap := data + rows*atoi(t, values["NAXIS1"])
+
+			if len(b) != data+(rows*8+atoi(t, values["PCOUNT"])+BLOCK_LENGTH-1)/BLOCK_LENGTH*BLOCK_LENGTH {
+				t.Errorf("got %d bytes, wanted the table and heap padded to a whole number of blocks", len(b))
+			}
+
+			bytepix := atoi(t, values["ZVAL2"])
+
+			zero := 0
+
+			if z, ok := values["BZERO"]; ok {
+				zero = atoi(t, z)
+			}
+
+			for row := 0; row < rows; row++ {
+				n := binary.BigEndian.Uint32(b[data+row*8:])
+				o := binary.BigEndian.Uint32(b[data+row*8+4:])
+
+				pixels := riceDecompress(t, b[heap+int(o):heap+int(o+n)], tt.image.Width, bytepix)
+
+				for x, v := range pixels {
+					want := int64(tt.image.Pix[row*tt.image.Width+x])
+
+					if v+int64(zero) != want {
+						t.Fatalf("got %d at pixel (%d, %d), wanted %d", v+int64(zero), x, row, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "light.fits")
+
+	if err := WriteFile(path, newTestImage(alpacago.ImageElementUInt16, 8, 8, 1, 1000), nil, Options{}); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if info.Size() != 2*BLOCK_LENGTH {
+		t.Errorf("got %d bytes, wanted %d", info.Size(), 2*BLOCK_LENGTH)
+	}
+}
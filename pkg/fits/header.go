@@ -0,0 +1,257 @@
+package fits
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The length, in bytes, of a single FITS header card:
+const CARD_LENGTH = 80
+
+// The length, in bytes, of a FITS block, to which every header and data unit is padded:
+const BLOCK_LENGTH = 2880
+
+// The greatest length of a string value within its quotes, which fills a card from column 12 to column 79:
+const MAX_STRING_LENGTH = CARD_LENGTH - 12
+
+/*
+Card
+
+A single keyword, value and comment record of a FITS header. The Value may be a bool, any integer or float
+type, a string or nil, for a keyword without a value.
+*/
+type Card struct {
+	Keyword string
+	Value   interface{}
+	Comment string
+}
+
+/*
+Header
+
+An ordered set of FITS header cards, unique by keyword.
+*/
+type Header struct {
+	Cards []Card
+}
+
+/*
+NewHeader()
+
+@returns a new, empty FITS header.
+*/
+func NewHeader() *Header {
+	return &Header{
+		Cards: []Card{},
+	}
+}
+
+/*
+Set()
+
+Sets the value and comment of the keyword, replacing any existing card with the same keyword in place,
+otherwise appending a new card. A NaN or infinite float, e.g., from an unreadable sensor temperature, has no FITS
+representation, so the keyword is removed instead.
+*/
+func (h *Header) Set(keyword string, value interface{}, comment string) {
+	keyword = strings.ToUpper(keyword)
+
+	if !finite(value) {
+		h.Delete(keyword)
+		return
+	}
+
+	for i := range h.Cards {
+		if h.Cards[i].Keyword == keyword {
+			h.Cards[i].Value = value
+			h.Cards[i].Comment = comment
+			return
+		}
+	}
+
+	h.Cards = append(h.Cards, Card{
+		Keyword: keyword,
+		Value:   value,
+		Comment: comment,
+	})
+}
+
+/*
+Get()
+
+@returns the value of the keyword, and true if the header has a card with the keyword.
+*/
+func (h *Header) Get(keyword string) (interface{}, bool) {
+	keyword = strings.ToUpper(keyword)
+
+	for _, c := range h.Cards {
+		if c.Keyword == keyword {
+			return c.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+/*
+Delete()
+
+Removes the card with the keyword, if any.
+*/
+func (h *Header) Delete(keyword string) {
+	keyword = strings.ToUpper(keyword)
+
+	for i := range h.Cards {
+		if h.Cards[i].Keyword == keyword {
+			h.Cards = append(h.Cards[:i], h.Cards[i+1:]...)
+			return
+		}
+	}
+}
+
+// finite returns false for a NaN or infinite float value, and true for any other value:
+func finite(value interface{}) bool {
+	switch v := value.(type) {
+	case float32:
+		return !math.IsNaN(float64(v)) && !math.IsInf(float64(v), 0)
+	case float64:
+		return !math.IsNaN(v) && !math.IsInf(v, 0)
+	default:
+		return true
+	}
+}
+
+/*
+formatValue()
+
+@returns the FITS fixed-format representation of the value, or an error if the type is unsupported. A string
+longer than MAX_STRING_LENGTH, once its quotes are escaped, is truncated within its quotes.
+*/
+func formatValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case bool:
+		if v {
+			return fmt.Sprintf("%20s", "T"), nil
+		}
+		return fmt.Sprintf("%20s", "F"), nil
+	case int:
+		return fmt.Sprintf("%20d", v), nil
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%20d", v), nil
+	case float32:
+		return fmt.Sprintf("%20s", formatFloat(float64(v), 32)), nil
+	case float64:
+		return fmt.Sprintf("%20s", formatFloat(v, 64)), nil
+	case string:
+		// Quotes are escaped by doubling, and the string is padded to at least eight characters:
+		s := strings.ReplaceAll(v, "'", "''")
+
+		if len(s) > MAX_STRING_LENGTH {
+			s = s[:MAX_STRING_LENGTH]
+
+			// An escaped quote split by the truncation is dropped, leaving an even number of trailing quotes:
+			if quotes := len(s) - len(strings.TrimRight(s, "'")); quotes%2 == 1 {
+				s = s[:len(s)-1]
+			}
+		}
+
+		return fmt.Sprintf("'%-8s'", s), nil
+	default:
+		return "", fmt.Errorf("fits: unsupported value type %T", value)
+	}
+}
+
+func formatFloat(v float64, bitSize int) string {
+	s := strconv.FormatFloat(v, 'G', -1, bitSize)
+
+	// Ensure that the value is read as a floating point value, and not as an integer:
+	if !strings.ContainsAny(s, ".EN") {
+		s += ".0"
+	}
+
+	return s
+}
+
+/*
+ValueString()
+
+@returns the value of the card in its FITS representation without padding, e.g., 'Ha' or -10.0, as used where
+keywords are embedded in other formats, such as XISF.
+*/
+func (c Card) ValueString() string {
+	if s, ok := c.Value.(string); ok {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+
+	value, err := formatValue(c.Value)
+
+	if err != nil {
+		return fmt.Sprintf("'%v'", c.Value)
+	}
+
+	return strings.TrimSpace(value)
+}
+
+/*
+String()
+
+@returns the card as an 80 character FITS header record, truncating the comment to fit.
+*/
+func (c Card) String() string {
+	keyword := fmt.Sprintf("%-8s", c.Keyword)
+
+	value, err := formatValue(c.Value)
+
+	if err != nil {
+		value = fmt.Sprintf("'%v'", c.Value)
+	}
+
+	var card string
+
+	switch {
+	case c.Keyword == "COMMENT" || c.Keyword == "HISTORY" || c.Keyword == "":
+		card = keyword + fmt.Sprint(c.Comment)
+	case c.Value == nil:
+		card = keyword + "  / " + c.Comment
+	case len(c.Comment) > 0:
+		card = keyword + "= " + value + " / " + c.Comment
+	default:
+		card = keyword + "= " + value
+	}
+
+	if len(card) > CARD_LENGTH {
+		return card[:CARD_LENGTH]
+	}
+
+	return card + strings.Repeat(" ", CARD_LENGTH-len(card))
+}
+
+/*
+Bytes()
+
+@returns the header as FITS header records, terminated by the END card and padded with spaces to a
+whole number of FITS blocks. Cards with a NaN or infinite value, which FITS cannot represent, are omitted.
+*/
+func (h *Header) Bytes() []byte {
+	var b strings.Builder
+
+	for _, c := range h.Cards {
+		if !finite(c.Value) {
+			continue
+		}
+
+		b.WriteString(c.String())
+	}
+
+	b.WriteString(fmt.Sprintf("%-80s", "END"))
+
+	if r := b.Len() % BLOCK_LENGTH; r > 0 {
+		b.WriteString(strings.Repeat(" ", BLOCK_LENGTH-r))
+	}
+
+	return []byte(b.String())
+}
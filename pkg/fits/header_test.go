@@ -0,0 +1,125 @@
+package fits
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCardString(t *testing.T) {
+	tests := []struct {
+		card Card
+		want string
+	}{
+		{Card{"SIMPLE", true, "conforms to FITS standard"}, "SIMPLE  =                    T / conforms to FITS standard"},
+		{Card{"NAXIS1", 4096, ""}, "NAXIS1  =                 4096"},
+		{Card{"CCD-TEMP", -10.0, "[C] sensor temperature"}, "CCD-TEMP=                -10.0 / [C] sensor temperature"},
+		{Card{"EXPTIME", 0.25, ""}, "EXPTIME =                 0.25"},
+		{Card{"FILTER", "Ha", "name of filter"}, "FILTER  = 'Ha      ' / name of filter"},
+		{Card{"OBSERVER", "O'Brien", ""}, "OBSERVER= 'O''Brien'"},
+		{Card{"HISTORY", nil, "written by alpacago"}, "HISTORY written by alpacago"},
+		// Long strings are truncated within their quotes, before the comment:
+		{Card{"OBJECT", strings.Repeat("x", 70), "name of object"}, "OBJECT  = '" + strings.Repeat("x", 68) + "'"},
+		{Card{"OBSERVER", strings.Repeat("x", 66) + "'s", ""}, "OBSERVER= '" + strings.Repeat("x", 66) + "''" + "'"},
+		{Card{"OBSERVER", strings.Repeat("x", 67) + "'s", ""}, "OBSERVER= '" + strings.Repeat("x", 67) + "'"},
+	}
+
+	for _, tt := range tests {
+		got := tt.card.String()
+
+		if len(got) != CARD_LENGTH {
+			t.Errorf("got %d characters, wanted %d", len(got), CARD_LENGTH)
+		}
+
+		if strings.TrimRight(got, " ") != tt.want {
+			t.Errorf("got %q, wanted %q", got, tt.want)
+		}
+	}
+}
+
+func TestCardStringFixedFormat(t *testing.T) {
+	got := Card{"BITPIX", 16, "array data type"}.String()
+
+	// Fixed format values are right justified to column 30:
+	if got[29] != '6' || got[10:29] != strings.Repeat(" ", 18)+"1" {
+		t.Errorf("got %q, wanted the value right justified to column 30", got)
+	}
+}
+
+func TestCardValueString(t *testing.T) {
+	tests := []struct {
+		card Card
+		want string
+	}{
+		{Card{"SIMPLE", true, ""}, "T"},
+		{Card{"XBINNING", int32(2), ""}, "2"},
+		{Card{"CCD-TEMP", -10.0, ""}, "-10.0"},
+		{Card{"FILTER", "Ha", ""}, "'Ha'"},
+		{Card{"OBSERVER", "O'Brien", ""}, "'O''Brien'"},
+		{Card{"HISTORY", nil, ""}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.card.ValueString(); got != tt.want {
+			t.Errorf("got %q, wanted %q", got, tt.want)
+		}
+	}
+}
+
+func TestHeaderSet(t *testing.T) {
+	h := NewHeader()
+
+	h.Set("exptime", 1.0, "")
+	h.Set("FILTER", "L", "")
+	h.Set("EXPTIME", 2.0, "[s] exposure duration")
+
+	if len(h.Cards) != 2 {
+		t.Fatalf("got %d cards, wanted %d", len(h.Cards), 2)
+	}
+
+	got, ok := h.Get("EXPTIME")
+
+	if !ok || got != 2.0 {
+		t.Errorf("got %v, wanted %v", got, 2.0)
+	}
+
+	h.Delete("FILTER")
+
+	if _, ok := h.Get("FILTER"); ok {
+		t.Errorf("got FILTER, wanted it to be deleted")
+	}
+}
+
+func TestHeaderSetNonFinite(t *testing.T) {
+	h := NewHeader()
+
+	h.Set("CCD-TEMP", -10.0, "[C] sensor temperature")
+	h.Set("CCD-TEMP", math.NaN(), "[C] sensor temperature")
+	h.Set("SET-TEMP", math.Inf(-1), "[C] sensor temperature setpoint")
+
+	if len(h.Cards) != 0 {
+		t.Errorf("got %v, wanted no cards for non-finite values", h.Cards)
+	}
+
+	h.Cards = append(h.Cards, Card{"EGAIN", float32(math.Inf(1)), ""})
+
+	if got := h.Bytes(); strings.Contains(string(got), "EGAIN") {
+		t.Errorf("got %q, wanted the non-finite card to be omitted", got[:CARD_LENGTH])
+	}
+}
+
+func TestHeaderBytes(t *testing.T) {
+	h := NewHeader()
+
+	h.Set("SIMPLE", true, "")
+
+	got := h.Bytes()
+
+	if len(got) != BLOCK_LENGTH {
+		t.Errorf("got %d bytes, wanted %d", len(got), BLOCK_LENGTH)
+	}
+
+	if string(got[CARD_LENGTH:CARD_LENGTH+3]) != "END" {
+		t.Errorf("got %q, wanted the END card", got[CARD_LENGTH:2*CARD_LENGTH])
+	}
+}
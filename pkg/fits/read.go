@@ -0,0 +1,316 @@
+package fits
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+/*
+parseCard()
+
+@returns the card of an 80 character FITS header record. Integer values are parsed as int64, real values as
+float64, logical values as bool and string values as string, without their trailing spaces.
+*/
+func parseCard(record string) (Card, error) {
+	keyword := strings.TrimSpace(record[:8])
+
+	// Cards without a value indicator are commentary:
+	if len(record) < 10 || record[8:10] != "= " {
+		return Card{Keyword: keyword, Comment: strings.TrimSpace(record[8:])}, nil
+	}
+
+	rest := record[10:]
+
+	card := Card{Keyword: keyword}
+
+	if s := strings.TrimLeft(rest, " "); strings.HasPrefix(s, "'") {
+		// A quote within a string is escaped by doubling:
+		var b strings.Builder
+
+		i := 1
+
+		for ; i < len(s); i++ {
+			if s[i] == '\'' {
+				if i+1 < len(s) && s[i+1] == '\'' {
+					b.WriteByte('\'')
+					i++
+					continue
+				}
+				break
+			}
+
+			b.WriteByte(s[i])
+		}
+
+		if i >= len(s) {
+			return card, fmt.Errorf("fits: unterminated string value of %s", keyword)
+		}
+
+		card.Value = strings.TrimRight(b.String(), " ")
+
+		rest = s[i+1:]
+	} else {
+		value := rest
+
+		if j := strings.Index(rest, "/"); j >= 0 {
+			value, rest = rest[:j], rest[j:]
+		} else {
+			rest = ""
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch {
+		case value == "":
+			card.Value = nil
+		case value == "T":
+			card.Value = true
+		case value == "F":
+			card.Value = false
+		default:
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				card.Value = v
+			} else if v, err := strconv.ParseFloat(strings.Replace(value, "D", "E", 1), 64); err == nil {
+				card.Value = v
+			} else {
+				return card, fmt.Errorf("fits: invalid value %q of %s", value, keyword)
+			}
+		}
+	}
+
+	if j := strings.Index(rest, "/"); j >= 0 {
+		card.Comment = strings.TrimSpace(rest[j+1:])
+	}
+
+	return card, nil
+}
+
+/*
+decodeHeader()
+
+@returns the header of the next HDU, read up to and including the block of its END card.
+*/
+func decodeHeader(r io.Reader) (*Header, error) {
+	h := NewHeader()
+
+	block := make([]byte, BLOCK_LENGTH)
+
+	for {
+		if _, err := io.ReadFull(r, block); err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < BLOCK_LENGTH; i += CARD_LENGTH {
+			record := string(block[i : i+CARD_LENGTH])
+
+			if strings.TrimSpace(record[:8]) == "END" {
+				return h, nil
+			}
+
+			card, err := parseCard(record)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if card.Keyword == "" && card.Comment == "" {
+				continue
+			}
+
+			h.Cards = append(h.Cards, card)
+		}
+	}
+}
+
+/*
+GetInt()
+
+@returns the value of the keyword as an integer, and true if the header has an integer card with the keyword.
+*/
+func (h *Header) GetInt(keyword string) (int64, bool) {
+	value, ok := h.Get(keyword)
+
+	if !ok {
+		return 0, false
+	}
+
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+/*
+GetFloat()
+
+@returns the value of the keyword as a float, and true if the header has a numeric card with the keyword.
+*/
+func (h *Header) GetFloat(keyword string) (float64, bool) {
+	value, ok := h.Get(keyword)
+
+	if !ok {
+		return 0, false
+	}
+
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	if v, ok := h.GetInt(keyword); ok {
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
+/*
+GetString()
+
+@returns the value of the keyword as a string, and true if the header has a string card with the keyword.
+*/
+func (h *Header) GetString(keyword string) (string, bool) {
+	value, ok := h.Get(keyword)
+
+	if !ok {
+		return "", false
+	}
+
+	s, ok := value.(string)
+
+	return s, ok
+}
+
+/*
+Read()
+
+Reads the image and header of the primary HDU of a FITS file, as written by Write() without compression. The
+element type of the image follows from the BITPIX and BZERO of the header.
+
+@returns the image, and the header cards other than the structural keywords.
+*/
+func Read(r io.Reader) (*alpacago.Image, *Header, error) {
+	h, err := decodeHeader(r)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bitpix, _ := h.GetInt("BITPIX")
+
+	rank, _ := h.GetInt("NAXIS")
+
+	if rank != 2 && rank != 3 {
+		return nil, nil, fmt.Errorf("fits: unsupported primary HDU of %d axes, compressed images cannot be read", rank)
+	}
+
+	width, _ := h.GetInt("NAXIS1")
+	height, _ := h.GetInt("NAXIS2")
+
+	planes := int64(1)
+
+	if rank == 3 {
+		planes, _ = h.GetInt("NAXIS3")
+	}
+
+	zero, _ := h.GetFloat("BZERO")
+
+	scale, ok := h.GetFloat("BSCALE")
+
+	if !ok {
+		scale = 1
+	}
+
+	var elementType alpacago.ImageArrayElementType
+
+	switch bitpix {
+	case 8:
+		elementType = alpacago.ImageElementByte
+	case 16:
+		elementType = alpacago.ImageElementInt16
+
+		if zero == 32768 {
+			elementType = alpacago.ImageElementUInt16
+		}
+	case 32:
+		elementType = alpacago.ImageElementInt32
+	case 64:
+		elementType = alpacago.ImageElementInt64
+	case -32:
+		elementType = alpacago.ImageElementSingle
+	case -64:
+		elementType = alpacago.ImageElementDouble
+	default:
+		return nil, nil, fmt.Errorf("fits: invalid BITPIX %d", bitpix)
+	}
+
+	image := alpacago.NewImage(elementType, int(width), int(height), int(planes))
+
+	size := int(bitpix / 8)
+
+	if size < 0 {
+		size = -size
+	}
+
+	buf := make([]byte, len(image.Pix)*size)
+
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, nil, err
+	}
+
+	for i := range image.Pix {
+		var v float64
+
+		switch bitpix {
+		case 8:
+			v = float64(buf[i])
+		case 16:
+			v = float64(int16(binary.BigEndian.Uint16(buf[i*2:])))
+		case 32:
+			v = float64(int32(binary.BigEndian.Uint32(buf[i*4:])))
+		case 64:
+			v = float64(int64(binary.BigEndian.Uint64(buf[i*8:])))
+		case -32:
+			v = float64(math.Float32frombits(binary.BigEndian.Uint32(buf[i*4:])))
+		case -64:
+			v = math.Float64frombits(binary.BigEndian.Uint64(buf[i*8:]))
+		}
+
+		image.Pix[i] = v*scale + zero
+	}
+
+	return image, appendHeader(NewHeader(), h), nil
+}
+
+/*
+ReadFile()
+
+Reads the image and header of the FITS file at the given path, see Read().
+*/
+func ReadFile(path string) (*alpacago.Image, *Header, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer f.Close()
+
+	return Read(bufio.NewReader(f))
+}
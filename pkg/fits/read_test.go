@@ -0,0 +1,119 @@
+package fits
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		record string
+		want   Card
+	}{
+		{Card{Keyword: "EXPTIME", Value: 1.5, Comment: "[s] exposure duration"}.String(), Card{Keyword: "EXPTIME", Value: 1.5, Comment: "[s] exposure duration"}},
+		{Card{Keyword: "GAIN", Value: 100}.String(), Card{Keyword: "GAIN", Value: int64(100)}},
+		{Card{Keyword: "OBJECT", Value: "M 31's core", Comment: "target"}.String(), Card{Keyword: "OBJECT", Value: "M 31's core", Comment: "target"}},
+		{Card{Keyword: "SIMPLE", Value: true}.String(), Card{Keyword: "SIMPLE", Value: true}},
+		{Card{Keyword: "HISTORY", Comment: "calibrated"}.String(), Card{Keyword: "HISTORY", Comment: "calibrated"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want.Keyword, func(t *testing.T) {
+			got, err := parseCard(tt.record)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %#v, wanted %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		elementType alpacago.ImageArrayElementType
+		pixels      []float64
+	}{
+		{"UInt16", alpacago.ImageElementInt32, []float64{0, 1, 32768, 65535, 12, 40000}},
+		{"Int32", alpacago.ImageElementInt32, []float64{-1, 0, 70000, 1, 2, 3}},
+		{"Single", alpacago.ImageElementSingle, []float64{0.5, -1.25, 3, 1e6, 0, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image := alpacago.NewImage(tt.elementType, 3, 2, 1)
+
+			copy(image.Pix, tt.pixels)
+
+			header := NewHeader()
+
+			header.Set("IMAGETYP", "Dark", "type of frame")
+			header.Set("EXPTIME", 30.0, "[s] exposure duration")
+
+			var b bytes.Buffer
+
+			if err := Write(&b, image, header, Options{}); err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			got, h, err := Read(&b)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if got.Width != 3 || got.Height != 2 || got.Rank != 2 {
+				t.Fatalf("got a rank %d image of %dx%d, wanted a rank 2 image of 3x2", got.Rank, got.Width, got.Height)
+			}
+
+			for i, v := range tt.pixels {
+				if got.Pix[i] != v {
+					t.Errorf("got %v at %d, wanted %v", got.Pix[i], i, v)
+				}
+			}
+
+			if v, _ := h.GetString("IMAGETYP"); v != "Dark" {
+				t.Errorf("got %q, wanted %q", v, "Dark")
+			}
+
+			if v, _ := h.GetFloat("EXPTIME"); v != 30 {
+				t.Errorf("got %v, wanted %v", v, 30)
+			}
+
+			if _, ok := h.Get("BITPIX"); ok {
+				t.Errorf("got a BITPIX card, wanted the structural keywords to be removed")
+			}
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.fits")
+
+	image := alpacago.NewImage(alpacago.ImageElementSingle, 2, 2, 3)
+
+	for i := range image.Pix {
+		image.Pix[i] = float64(i)
+	}
+
+	if err := WriteFile(path, image, nil, Options{}); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	got, _, err := ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got.Rank != 3 || got.Planes != 3 || got.Pix[11] != 11 {
+		t.Errorf("got %+v, wanted a rank 3 image of 3 planes", got)
+	}
+}
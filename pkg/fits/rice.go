@@ -0,0 +1,171 @@
+package fits
+
+/*
+The Rice compression algorithm of the FITS tiled image compression convention, compatible with the
+RICE_1 compression type of CFITSIO.
+
+@see https://fits.gsfc.nasa.gov/registry/tilecompression/tilecompression2.3.pdf
+*/
+
+// The number of pixels in each block of Rice coded differences:
+const RICE_BLOCK_SIZE = 32
+
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+/*
+write()
+
+Appends the lowest n bits of v, most significant bit first, where n is at most 32.
+*/
+func (w *bitWriter) write(v uint32, n uint) {
+	w.acc = (w.acc << n) | (uint64(v) & (1<<n - 1))
+	w.nbits += n
+
+	for w.nbits >= 8 {
+		w.nbits -= 8
+		w.buf = append(w.buf, byte(w.acc>>w.nbits))
+	}
+}
+
+/*
+flush()
+
+@returns the written bits, with the final byte padded with zeros.
+*/
+func (w *bitWriter) flush() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc<<(8-w.nbits)))
+		w.nbits = 0
+	}
+
+	return w.buf
+}
+
+/*
+riceCompress()
+
+@param pixels []int64 (the integer pixel values of a tile)
+@param bytepix int (the number of bytes per pixel, 1, 2 or 4)
+@returns the Rice coded tile, where the first pixel is stored verbatim and the remaining pixels are coded as
+differences from the preceding pixel, in blocks of RICE_BLOCK_SIZE pixels.
+*/
+func riceCompress(pixels []int64, bytepix int) []byte {
+	var fsbits, fsmax uint
+
+	bbits := uint(bytepix * 8)
+
+	switch bytepix {
+	case 1:
+		fsbits, fsmax = 3, 6
+	case 2:
+		fsbits, fsmax = 4, 14
+	default:
+		fsbits, fsmax = 5, 25
+	}
+
+	w := bitWriter{
+		buf: make([]byte, 0, len(pixels)*bytepix/2+16),
+	}
+
+	if len(pixels) == 0 {
+		return w.flush()
+	}
+
+	w.write(uint32(pixels[0]), bbits)
+
+	last := pixels[0]
+
+	diff := make([]uint32, RICE_BLOCK_SIZE)
+
+	for i := 0; i < len(pixels); i += RICE_BLOCK_SIZE {
+		n := RICE_BLOCK_SIZE
+
+		if len(pixels)-i < n {
+			n = len(pixels) - i
+		}
+
+		var sum uint64
+
+		for j := 0; j < n; j++ {
+			next := pixels[i+j]
+
+			// The difference wraps to the width of the pixel, and is mapped to an unsigned value, interleaving
+			// the positive and negative differences:
+			d := wrapDifference(next-last, bbits)
+
+			if d < 0 {
+				diff[j] = uint32(^(d << 1))
+			} else {
+				diff[j] = uint32(d << 1)
+			}
+
+			sum += uint64(diff[j])
+
+			last = next
+		}
+
+		// Choose the number of split bits from the mean of the differences:
+		mean := (float64(sum) - float64(n/2) - 1) / float64(n)
+
+		if mean < 0 {
+			mean = 0
+		}
+
+		psum := uint64(mean) >> 1
+
+		var fs uint
+
+		for ; psum > 0; fs++ {
+			psum >>= 1
+		}
+
+		switch {
+		case fs >= fsmax:
+			// High entropy, the differences are stored verbatim:
+			w.write(uint32(fsmax+1), fsbits)
+
+			for j := 0; j < n; j++ {
+				w.write(diff[j], bbits)
+			}
+		case fs == 0 && sum == 0:
+			// Low entropy, every difference is zero:
+			w.write(0, fsbits)
+		default:
+			w.write(uint32(fs+1), fsbits)
+
+			mask := uint32(1)<<fs - 1
+
+			for j := 0; j < n; j++ {
+				top := diff[j] >> fs
+
+				// The top bits are unary coded, as zeros terminated by a one:
+				for ; top >= 32; top -= 32 {
+					w.write(0, 32)
+				}
+
+				w.write(1, uint(top)+1)
+
+				if fs > 0 {
+					w.write(diff[j]&mask, fs)
+				}
+			}
+		}
+	}
+
+	return w.flush()
+}
+
+/*
+wrapDifference()
+
+@returns the difference wrapped to a signed integer of the given number of bits.
+*/
+func wrapDifference(d int64, bits uint) int64 {
+	shift := 64 - bits
+
+	return (d << shift) >> shift
+}
@@ -0,0 +1,204 @@
+package guide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// The greatest difference from a right angle between the RA and Dec axes of a calibration, in degrees:
+const MAX_ORTHOGONALITY_ERROR = 20
+
+// ErrCalibrationFailed is returned by Guider.Calibrate() when the guide star does not move as expected.
+var ErrCalibrationFailed = errors.New("guide: calibration failed")
+
+/*
+Calibration
+
+The motion of the guide star under pulses, where RAAngle and DecAngle are the directions in the frame, in radians,
+in which the star moves under west and north pulses, and RARate and DecRate are its speeds, in pixels per second.
+*/
+type Calibration struct {
+	RAAngle  float64
+	RARate   float64
+	DecAngle float64
+	DecRate  float64
+}
+
+/*
+Orthogonality()
+
+@returns the difference from a right angle between the RA and Dec axes, in degrees, which is small for a
+calibration free of backlash and of the effects of polar misalignment.
+*/
+func (c Calibration) Orthogonality() float64 {
+	angle := math.Abs(math.Remainder(c.DecAngle-c.RAAngle, 2*math.Pi)) * 180 / math.Pi
+
+	return math.Abs(angle - 90)
+}
+
+/*
+Resolve()
+
+@returns the offset (dx, dy) in the frame, in pixels, resolved onto the RA and Dec axes, i.e., the distances along
+each axis whose sum is the offset. The axes need not be perpendicular, as in a mirrored or skewed calibration.
+*/
+func (c Calibration) Resolve(dx float64, dy float64) (float64, float64) {
+	rs, rc := math.Sincos(c.RAAngle)
+	ds, dc := math.Sincos(c.DecAngle)
+
+	// Solving (dx, dy) = ra (rc, rs) + dec (dc, ds) by Cramer's rule:
+	det := rc*ds - rs*dc
+
+	return (dx*ds - dy*dc) / det, (rc*dy - rs*dx) / det
+}
+
+/*
+Calibrate()
+
+Measures the direction and rate of the motion of the guide star under west then north pulses, selecting a guide
+star first if there is none. The star is pulsed along each axis until it has moved Options.CalibrationDistance,
+then returned by as many opposite pulses, and its final position becomes the lock position.
+
+@returns the calibration, or ErrCalibrationFailed if the star moves too little along either axis or the axes are
+not perpendicular.
+*/
+func (g *Guider) Calibrate(ctx context.Context) (Calibration, error) {
+	if _, _, selected := g.LockPosition(); !selected {
+		if _, err := g.SelectStar(ctx); err != nil {
+			return Calibration{}, err
+		}
+	}
+
+	var c Calibration
+
+	var err error
+
+	if c.RAAngle, c.RARate, err = g.calibrateAxis(ctx, alpacago.West, alpacago.East); err != nil {
+		return Calibration{}, err
+	}
+
+	if c.DecAngle, c.DecRate, err = g.calibrateAxis(ctx, alpacago.North, alpacago.South); err != nil {
+		return Calibration{}, err
+	}
+
+	if e := c.Orthogonality(); e > MAX_ORTHOGONALITY_ERROR {
+		return c, fmt.Errorf("%w: the axes are %.0f° from perpendicular", ErrCalibrationFailed, e)
+	}
+
+	g.SetCalibration(c)
+
+	g.mu.Lock()
+	star := *g.star
+	g.mu.Unlock()
+
+	g.SetLockPosition(star.X, star.Y)
+
+	return c, nil
+}
+
+/*
+calibrateAxis()
+
+Pulses the guide star in the forward direction until it has moved the calibration distance, then returns it with
+as many pulses in the back direction.
+
+@returns the direction of the motion in the frame, in radians, and its rate, in pixels per second.
+*/
+func (g *Guider) calibrateAxis(ctx context.Context, forward alpacago.Direction, back alpacago.Direction) (float64, float64, error) {
+	g.mu.Lock()
+	origin := *g.star
+	g.mu.Unlock()
+
+	var angle, rate, distance float64
+
+	steps := 0
+
+	for steps < g.opts.CalibrationSteps && distance < g.opts.CalibrationDistance {
+		if err := g.pulse(ctx, forward, g.opts.CalibrationPulse); err != nil {
+			return 0, 0, err
+		}
+
+		steps++
+
+		star, err := g.find(ctx)
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		dx, dy := star.X-origin.X, star.Y-origin.Y
+
+		distance = math.Hypot(dx, dy)
+
+		angle, rate = math.Atan2(dy, dx), distance/(float64(steps)*g.opts.CalibrationPulse.Seconds())
+	}
+
+	for i := 0; i < steps; i++ {
+		if err := g.pulse(ctx, back, g.opts.CalibrationPulse); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// The star is sought where it is expected after its return, as it may have moved too far to be found from
+	// its last position:
+	g.mu.Lock()
+	g.star = &origin
+	g.mu.Unlock()
+
+	if _, err := g.find(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	if distance < g.opts.CalibrationDistance {
+		return 0, 0, fmt.Errorf("%w: the star moved %.1f pixels after %d %s pulses, wanted %.1f", ErrCalibrationFailed, distance, steps, direction(forward), g.opts.CalibrationDistance)
+	}
+
+	return angle, rate, nil
+}
+
+/*
+SetCalibration()
+
+Sets the calibration of the guider, e.g., one stored from an earlier session with the same guide camera and mount.
+*/
+func (g *Guider) SetCalibration(c Calibration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.calibration = &c
+}
+
+/*
+Calibration()
+
+@returns the calibration of the guider, and false if it is not calibrated.
+*/
+func (g *Guider) Calibration() (Calibration, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.calibration == nil {
+		return Calibration{}, false
+	}
+
+	return *g.calibration, true
+}
+
+func direction(d alpacago.Direction) string {
+	switch d {
+	case alpacago.North:
+		return "north"
+	case alpacago.South:
+		return "south"
+	case alpacago.East:
+		return "east"
+	case alpacago.West:
+		return "west"
+	default:
+		return fmt.Sprint(int32(d))
+	}
+}
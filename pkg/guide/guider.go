@@ -0,0 +1,570 @@
+package guide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/stars"
+)
+
+// The time, beyond the duration of a pulse, after which a device still pulse guiding has failed:
+const PULSE_TIMEOUT = 10 * time.Second
+
+// ErrNoGuideStar is returned when no star of a frame is suitable for guiding, or no guide star has been selected.
+var ErrNoGuideStar = errors.New("guide: no guide star")
+
+// ErrStarLost is returned when the guide star is not found within Options.SearchRadius of its last position.
+var ErrStarLost = errors.New("guide: the guide star was lost")
+
+// ErrNotCalibrated is returned by Guider.Step() before the guider is calibrated.
+var ErrNotCalibrated = errors.New("guide: the guider is not calibrated")
+
+type Output int
+
+const (
+	// Pulses are sent to the ST-4 port of the guide camera:
+	CameraST4 Output = iota
+	// Pulses are sent to the mount:
+	Mount
+)
+
+func (o Output) String() string {
+	switch o {
+	case CameraST4:
+		return "camera ST-4"
+	case Mount:
+		return "mount"
+	default:
+		return fmt.Sprintf("Unknown Output value: %d", o)
+	}
+}
+
+/*
+Options
+
+The options of a Guider. A zero value for any option selects its default.
+*/
+type Options struct {
+	// The duration of each guide exposure, in seconds (default 2):
+	Exposure float64
+	// The device to which pulses are sent (default CameraST4):
+	Output Output
+	// The options of the star detection of each frame:
+	Stars stars.Options
+	// The greatest distance the guide star may move between frames and still be found, in pixels (default 10):
+	SearchRadius float64
+	// The least signal to noise ratio of a guide star (default 10):
+	MinSNR float64
+	// The duration of each calibration pulse (default 1 second):
+	CalibrationPulse time.Duration
+	// The distance the guide star must move along each axis during calibration, in pixels (default 10):
+	CalibrationDistance float64
+	// The greatest number of calibration pulses along each axis (default 20):
+	CalibrationSteps int
+	// The fraction of the RA error which is corrected by each pulse (default 0.7):
+	RAAggressiveness float64
+	// The fraction of the Dec error which is corrected by each pulse (default 0.7):
+	DecAggressiveness float64
+	// The weight of the previous correction in each correction, in [0, 1), which damps corrections of seeing, or
+	// zero for none:
+	Hysteresis float64
+	// The least error which is corrected, in pixels (default 0.15):
+	MinMove float64
+	// The longest correction pulse (default 2 seconds):
+	MaxPulse time.Duration
+	// The number of the latest steps from which the RMS error is found (default 50):
+	HistoryLength int
+	// The number of consecutive frames in which the guide star may be lost before Run() fails (default 5):
+	MaxLostFrames int
+	// The interval between polls of the pulse guiding state (default 100 milliseconds):
+	PollInterval time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.Exposure == 0 {
+		o.Exposure = 2
+	}
+
+	if o.SearchRadius == 0 {
+		o.SearchRadius = 10
+	}
+
+	if o.MinSNR == 0 {
+		o.MinSNR = 10
+	}
+
+	if o.CalibrationPulse == 0 {
+		o.CalibrationPulse = time.Second
+	}
+
+	if o.CalibrationDistance == 0 {
+		o.CalibrationDistance = 10
+	}
+
+	if o.CalibrationSteps == 0 {
+		o.CalibrationSteps = 20
+	}
+
+	if o.RAAggressiveness == 0 {
+		o.RAAggressiveness = 0.7
+	}
+
+	if o.DecAggressiveness == 0 {
+		o.DecAggressiveness = 0.7
+	}
+
+	if o.MinMove == 0 {
+		o.MinMove = 0.15
+	}
+
+	if o.MaxPulse == 0 {
+		o.MaxPulse = 2 * time.Second
+	}
+
+	if o.HistoryLength == 0 {
+		o.HistoryLength = 50
+	}
+
+	if o.MaxLostFrames == 0 {
+		o.MaxLostFrames = 5
+	}
+
+	if o.PollInterval == 0 {
+		o.PollInterval = 100 * time.Millisecond
+	}
+
+	return o
+}
+
+/*
+Step
+
+A cycle of guiding. DX and DY are the offset of the guide star from the lock position, in pixels, and RA and Dec
+the offset resolved onto the axes of the mount, in pixels, positive in the direction in which the star moves under
+west and north pulses respectively. A zero pulse is not sent.
+*/
+type Step struct {
+	Time         time.Time
+	Star         stars.Star
+	DX           float64
+	DY           float64
+	RA           float64
+	Dec          float64
+	RAPulse      time.Duration
+	RADirection  alpacago.Direction
+	DecPulse     time.Duration
+	DecDirection alpacago.Direction
+}
+
+/*
+Stats
+
+The root mean square of the RA, Dec and total errors of the latest steps, in pixels.
+*/
+type Stats struct {
+	Steps int
+	RA    float64
+	Dec   float64
+	Total float64
+}
+
+/*
+Guider
+
+Keeps a guide star at its lock position by measuring its centroid in each frame of a guide camera and correcting
+its drift with pulses to the ST-4 port of the camera or to the mount.
+*/
+type Guider struct {
+	mu          sync.Mutex
+	camera      *alpacago.Camera
+	telescope   *alpacago.Telescope
+	opts        Options
+	star        *stars.Star
+	lockX       float64
+	lockY       float64
+	calibration *Calibration
+	lastRA      float64
+	lastDec     float64
+	history     []Step
+}
+
+/*
+NewGuider()
+
+@param camera *alpacago.Camera (the guide camera)
+@param telescope *alpacago.Telescope (the mount, which may be nil if pulses are sent to the camera)
+@param opts Options
+@returns a guider without a guide star or calibration.
+*/
+func NewGuider(camera *alpacago.Camera, telescope *alpacago.Telescope, opts Options) (*Guider, error) {
+	if camera == nil {
+		return nil, fmt.Errorf("guide: a guide camera is required")
+	}
+
+	if opts.Output == Mount && telescope == nil {
+		return nil, fmt.Errorf("guide: pulses to the mount require a telescope")
+	}
+
+	return &Guider{
+		camera:    camera,
+		telescope: telescope,
+		opts:      opts.withDefaults(),
+	}, nil
+}
+
+/*
+frame()
+
+@returns the image of a new guide exposure, and its stars, the brightest first.
+*/
+func (g *Guider) frame(ctx context.Context) (*alpacago.Image, []stars.Star, error) {
+	exposure, err := g.camera.ExposeContext(ctx, g.opts.Exposure, true, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	detected, err := stars.Detect(exposure.Image, g.opts.Stars)
+
+	return exposure.Image, detected, err
+}
+
+/*
+SelectStar()
+
+Takes a frame and selects its brightest star which is unsaturated, has at least the least signal to noise ratio,
+and is further than the search radius from the edges of the frame, so that it cannot drift out of the frame
+unnoticed. The position of the star becomes the lock position.
+
+@returns the guide star, or ErrNoGuideStar.
+*/
+func (g *Guider) SelectStar(ctx context.Context) (stars.Star, error) {
+	image, detected, err := g.frame(ctx)
+
+	if err != nil {
+		return stars.Star{}, err
+	}
+
+	width, height, r := float64(image.Width), float64(image.Height), g.opts.SearchRadius
+
+	for _, s := range detected {
+		if s.Saturated || s.SNR() < g.opts.MinSNR || s.X < r || s.Y < r || s.X > width-r || s.Y > height-r {
+			continue
+		}
+
+		star := s
+
+		g.mu.Lock()
+		g.star = &star
+		g.mu.Unlock()
+
+		g.SetLockPosition(s.X, s.Y)
+
+		return s, nil
+	}
+
+	return stars.Star{}, fmt.Errorf("%w: none of %d stars is suitable", ErrNoGuideStar, len(detected))
+}
+
+/*
+SetLockPosition()
+
+Sets the position, in pixels, at which the guide star is held, e.g., to dither, and clears the history of
+corrections.
+*/
+func (g *Guider) SetLockPosition(x float64, y float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.lockX, g.lockY = x, y
+	g.lastRA, g.lastDec = 0, 0
+	g.history = nil
+}
+
+/*
+LockPosition()
+
+@returns the position at which the guide star is held, and false if no guide star has been selected.
+*/
+func (g *Guider) LockPosition() (float64, float64, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.lockX, g.lockY, g.star != nil
+}
+
+/*
+find()
+
+Takes a frame, and finds the guide star as the star nearest to its last position within the search radius.
+
+@returns the guide star, or ErrStarLost.
+*/
+func (g *Guider) find(ctx context.Context) (stars.Star, error) {
+	g.mu.Lock()
+	last := g.star
+	g.mu.Unlock()
+
+	if last == nil {
+		return stars.Star{}, ErrNoGuideStar
+	}
+
+	_, detected, err := g.frame(ctx)
+
+	if err != nil {
+		return stars.Star{}, err
+	}
+
+	nearest, distance := -1, g.opts.SearchRadius
+
+	for i, s := range detected {
+		if d := math.Hypot(s.X-last.X, s.Y-last.Y); d <= distance {
+			nearest, distance = i, d
+		}
+	}
+
+	if nearest < 0 {
+		return stars.Star{}, fmt.Errorf("%w: no star within %.1f pixels of (%.1f, %.1f)", ErrStarLost, g.opts.SearchRadius, last.X, last.Y)
+	}
+
+	star := detected[nearest]
+
+	g.mu.Lock()
+	g.star = &star
+	g.mu.Unlock()
+
+	return star, nil
+}
+
+/*
+pulse()
+
+Sends a guide pulse to the output, and waits until the device is no longer pulse guiding. The wait is the duration
+of the pulse for a device which cannot report whether it is pulse guiding.
+*/
+func (g *Guider) pulse(ctx context.Context, direction alpacago.Direction, duration time.Duration) error {
+	ms := int32(duration.Milliseconds())
+
+	if ms <= 0 {
+		return nil
+	}
+
+	var err error
+
+	isPulseGuiding := g.camera.IsPulseGuidingContext
+
+	if g.opts.Output == Mount {
+		err = g.telescope.SetPulseGuideContext(ctx, direction, ms)
+		isPulseGuiding = g.telescope.IsPulseGuidingContext
+	} else {
+		err = g.camera.SetPulseGuideContext(ctx, direction, ms)
+	}
+
+	if err != nil {
+		return fmt.Errorf("guide: pulse guiding through the %s: %w", g.opts.Output, err)
+	}
+
+	deadline := time.Now().Add(duration + PULSE_TIMEOUT)
+
+	for {
+		guiding, err := isPulseGuiding(ctx)
+
+		if errors.Is(err, alpacago.ErrNotImplemented) {
+			guiding, err = false, wait(ctx, duration)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !guiding {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("guide: the %s is still pulse guiding after %v", g.opts.Output, duration+PULSE_TIMEOUT)
+		}
+
+		if err := wait(ctx, g.opts.PollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+/*
+correction()
+
+@returns the move which corrects the error, in pixels, as the error weighted against the last move by the
+hysteresis and scaled by the aggressiveness, or zero for an error smaller than the least move. The move is
+recorded as the last move.
+*/
+func (g *Guider) correction(err float64, last *float64, aggressiveness float64) float64 {
+	move := ((1-g.opts.Hysteresis)*err + g.opts.Hysteresis*(*last)) * aggressiveness
+
+	if math.Abs(err) < g.opts.MinMove {
+		move = 0
+	}
+
+	*last = move
+
+	return move
+}
+
+/*
+pulseFor()
+
+@returns the duration of the pulse which moves the star by the move at the rate, in pixels per second, no longer
+than the longest pulse, and the positive direction if the move is positive, otherwise the negative direction.
+*/
+func (g *Guider) pulseFor(move float64, rate float64, positive alpacago.Direction, negative alpacago.Direction) (time.Duration, alpacago.Direction) {
+	direction := positive
+
+	if move < 0 {
+		direction = negative
+	}
+
+	duration := time.Duration(math.Abs(move) / rate * float64(time.Second)).Round(time.Millisecond)
+
+	return min(duration, g.opts.MaxPulse), direction
+}
+
+/*
+Step()
+
+Takes a frame, finds the guide star, and corrects its offset from the lock position with a pulse on each axis.
+
+@returns the step, or ErrNoGuideStar, ErrNotCalibrated or ErrStarLost.
+*/
+func (g *Guider) Step(ctx context.Context) (Step, error) {
+	g.mu.Lock()
+	calibration, selected := g.calibration, g.star != nil
+	g.mu.Unlock()
+
+	if !selected {
+		return Step{}, ErrNoGuideStar
+	}
+
+	if calibration == nil {
+		return Step{}, ErrNotCalibrated
+	}
+
+	star, err := g.find(ctx)
+
+	if err != nil {
+		return Step{}, err
+	}
+
+	g.mu.Lock()
+
+	step := Step{Time: time.Now(), Star: star, DX: star.X - g.lockX, DY: star.Y - g.lockY}
+
+	step.RA, step.Dec = calibration.Resolve(step.DX, step.DY)
+
+	// The star is moved back by the opposite pulses to those which moved it along each axis in calibration:
+	step.RAPulse, step.RADirection = g.pulseFor(g.correction(step.RA, &g.lastRA, g.opts.RAAggressiveness), calibration.RARate, alpacago.East, alpacago.West)
+	step.DecPulse, step.DecDirection = g.pulseFor(g.correction(step.Dec, &g.lastDec, g.opts.DecAggressiveness), calibration.DecRate, alpacago.South, alpacago.North)
+
+	g.history = append(g.history, step)
+
+	if len(g.history) > g.opts.HistoryLength {
+		g.history = g.history[len(g.history)-g.opts.HistoryLength:]
+	}
+
+	g.mu.Unlock()
+
+	if err := g.pulse(ctx, step.RADirection, step.RAPulse); err != nil {
+		return step, err
+	}
+
+	return step, g.pulse(ctx, step.DecDirection, step.DecPulse)
+}
+
+/*
+Run()
+
+Guides until the context is cancelled, selecting a guide star and calibrating first if the guider has neither.
+Frames in which the guide star is lost are skipped, up to Options.MaxLostFrames in a row.
+
+@param ctx context.Context
+@param progress func(Step) (called after each step, may be nil)
+@returns the error which stopped guiding, e.g., the error of the context, or ErrStarLost.
+*/
+func (g *Guider) Run(ctx context.Context, progress func(step Step)) error {
+	if _, _, selected := g.LockPosition(); !selected {
+		if _, err := g.SelectStar(ctx); err != nil {
+			return err
+		}
+	}
+
+	if _, calibrated := g.Calibration(); !calibrated {
+		if _, err := g.Calibrate(ctx); err != nil {
+			return err
+		}
+	}
+
+	lost := 0
+
+	for {
+		step, err := g.Step(ctx)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, ErrStarLost) && lost < g.opts.MaxLostFrames {
+			lost++
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		lost = 0
+
+		if progress != nil {
+			progress(step)
+		}
+	}
+}
+
+/*
+Stats()
+
+@returns the RMS errors of the latest steps, since the lock position was last set.
+*/
+func (g *Guider) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	s := Stats{Steps: len(g.history)}
+
+	if s.Steps == 0 {
+		return s
+	}
+
+	for _, step := range g.history {
+		s.RA += step.RA * step.RA
+		s.Dec += step.Dec * step.Dec
+	}
+
+	s.RA, s.Dec = math.Sqrt(s.RA/float64(s.Steps)), math.Sqrt(s.Dec/float64(s.Steps))
+
+	s.Total = math.Hypot(s.RA, s.Dec)
+
+	return s
+}
@@ -0,0 +1,351 @@
+package guide
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// The size of the frames of the simulated guide camera:
+const size = 64
+
+/*
+sky
+
+A simulated guide camera and mount, whose frames show a single star which drifts by (driftX, driftY) pixels during
+each exposure, and which moves instantly under pulses at raRate and decRate pixels per second along the axes of the
+mount, rotated by angle in the frame. If mirrored, the Dec axis is mirrored in the frame.
+*/
+type sky struct {
+	mu       sync.Mutex
+	x        float64
+	y        float64
+	driftX   float64
+	driftY   float64
+	angle    float64
+	mirrored bool
+	raRate   float64
+	decRate  float64
+	rand     *rand.Rand
+	// The number of pulses received by each device:
+	pulses map[string]int
+}
+
+func (s *sky) respond(device string, name string, r *http.Request) alpacatest.Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value := "null"
+
+	errorNumber := 0
+
+	switch name {
+	case "startexposure":
+		s.x += s.driftX
+		s.y += s.driftY
+	case "camerastate":
+		value = "0"
+	case "imageready":
+		value = "true"
+	case "imagearray":
+		return alpacatest.ImageArray(s.render())
+	case "pulseguide":
+		direction, _ := strconv.Atoi(r.FormValue("Direction"))
+		ms, _ := strconv.Atoi(r.FormValue("Duration"))
+
+		s.pulse(alpacago.Direction(direction), float64(ms)/1000)
+		s.pulses[device]++
+	case "ispulseguiding":
+		value = "false"
+	default:
+		errorNumber = 0x400
+	}
+
+	return alpacatest.Response{Value: value, ErrorNumber: errorNumber}
+}
+
+func (s *sky) pulse(direction alpacago.Direction, seconds float64) {
+	sin, cos := math.Sincos(s.angle)
+
+	// The Dec axis is perpendicular to the RA axis, on either side of it:
+	decX, decY := -sin, cos
+
+	if s.mirrored {
+		decX, decY = sin, -cos
+	}
+
+	switch direction {
+	case alpacago.West:
+		s.x, s.y = s.x+cos*s.raRate*seconds, s.y+sin*s.raRate*seconds
+	case alpacago.East:
+		s.x, s.y = s.x-cos*s.raRate*seconds, s.y-sin*s.raRate*seconds
+	case alpacago.North:
+		s.x, s.y = s.x+decX*s.decRate*seconds, s.y+decY*s.decRate*seconds
+	case alpacago.South:
+		s.x, s.y = s.x-decX*s.decRate*seconds, s.y-decY*s.decRate*seconds
+	}
+}
+
+// render returns the JSON image array, indexed [x][y], of the star on a noisy background:
+func (s *sky) render() string {
+	value := make([][]int, size)
+
+	for x := range value {
+		value[x] = make([]int, size)
+
+		for y := range value[x] {
+			dx, dy := float64(x)-s.x, float64(y)-s.y
+
+			v := 100 + s.rand.NormFloat64()*3 + 3000*math.Exp(-(dx*dx+dy*dy)/(2*1.5*1.5))
+
+			value[x][y] = int(math.Round(v))
+		}
+	}
+
+	b, _ := json.Marshal(value)
+
+	return string(b)
+}
+
+func (s *sky) position() (float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.x, s.y
+}
+
+func newSky(t *testing.T) (*sky, *alpacago.Camera, *alpacago.Telescope) {
+	s := &sky{
+		x:       30.3,
+		y:       33.6,
+		angle:   30 * math.Pi / 180,
+		raRate:  5,
+		decRate: 4,
+		rand:    rand.New(rand.NewSource(1)),
+		pulses:  map[string]int{},
+	}
+
+	ts := alpacatest.NewServer(t, nil)
+
+	for _, device := range []string{"camera", "telescope"} {
+		device := device
+
+		ts.HandleFunc(device+"/0/", func(name string, r *http.Request) alpacatest.Response {
+			return s.respond(device, name, r)
+		})
+	}
+
+	return s, alpacago.NewCameraWithOptions(ts.URL, 0), alpacago.NewTelescopeWithOptions(ts.URL, 0, alpacago.NotTracking)
+}
+
+var testOptions = Options{
+	Exposure:         0.01,
+	CalibrationPulse: 500 * time.Millisecond,
+	PollInterval:     time.Millisecond,
+}
+
+func TestCalibrate(t *testing.T) {
+	for _, mirrored := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Mirrored%t", mirrored), func(t *testing.T) {
+			s, camera, _ := newSky(t)
+
+			s.mirrored = mirrored
+
+			g, err := NewGuider(camera, nil, testOptions)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			got, err := g.Calibrate(context.Background())
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			wantDec := s.angle + math.Pi/2
+
+			if mirrored {
+				wantDec = s.angle - math.Pi/2
+			}
+
+			if math.Abs(got.RAAngle-s.angle) > 0.02 || math.Abs(math.Remainder(got.DecAngle-wantDec, 2*math.Pi)) > 0.02 {
+				t.Errorf("got angles of %v and %v, wanted %v and %v", got.RAAngle, got.DecAngle, s.angle, wantDec)
+			}
+
+			if math.Abs(got.RARate-5) > 0.1 || math.Abs(got.DecRate-4) > 0.1 || got.Orthogonality() > 2 {
+				t.Errorf("got %+v, wanted rates of 5 and 4 pixels per second on perpendicular axes", got)
+			}
+
+			// The star is returned to its starting position, at which it is locked:
+			x, y, _ := g.LockPosition()
+
+			if math.Abs(x-30.3) > 0.1 || math.Abs(y-33.6) > 0.1 {
+				t.Errorf("got a lock position of (%v, %v), wanted about (30.3, 33.6)", x, y)
+			}
+
+			// An offset along each axis is resolved to that axis:
+			sin, cos := math.Sincos(s.angle)
+
+			if ra, dec := got.Resolve(2*cos, 2*sin); math.Abs(ra-2) > 0.05 || math.Abs(dec) > 0.05 {
+				t.Errorf("got (%v, %v), wanted (2, 0)", ra, dec)
+			}
+		})
+	}
+}
+
+func TestCalibrateFailed(t *testing.T) {
+	s, camera, _ := newSky(t)
+
+	// The mount does not move in Dec:
+	s.decRate = 0
+
+	g, _ := NewGuider(camera, nil, testOptions)
+
+	if _, err := g.Calibrate(context.Background()); !errors.Is(err, ErrCalibrationFailed) {
+		t.Errorf("got %v, wanted %v", err, ErrCalibrationFailed)
+	}
+
+	if _, calibrated := g.Calibration(); calibrated {
+		t.Errorf("got a calibration, wanted none after a failure")
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		output Output
+		device string
+	}{
+		{CameraST4, "camera"},
+		{Mount, "telescope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.device, func(t *testing.T) {
+			s, camera, telescope := newSky(t)
+
+			opts := testOptions
+
+			opts.Output = tt.output
+
+			g, err := NewGuider(camera, telescope, opts)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if _, err := g.Step(context.Background()); !errors.Is(err, ErrNoGuideStar) {
+				t.Errorf("got %v, wanted %v before a star is selected", err, ErrNoGuideStar)
+			}
+
+			// The star drifts by 0.5 pixels along RA and 0.2 pixels along Dec during each exposure:
+			s.mu.Lock()
+			s.driftX, s.driftY = 0.5*math.Cos(s.angle)-0.2*math.Sin(s.angle), 0.5*math.Sin(s.angle)+0.2*math.Cos(s.angle)
+			s.mu.Unlock()
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			steps := []Step{}
+
+			err = g.Run(ctx, func(step Step) {
+				steps = append(steps, step)
+
+				if len(steps) == 40 {
+					cancel()
+				}
+			})
+
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("got %v, wanted %v", err, context.Canceled)
+			}
+
+			// Corrections to the east and south hold the star against the drift:
+			last := steps[len(steps)-1]
+
+			if last.RADirection != alpacago.East || last.DecDirection != alpacago.South || last.RAPulse < 50*time.Millisecond {
+				t.Errorf("got %+v, wanted pulses to the east and south", last)
+			}
+
+			if stats := g.Stats(); stats.Steps != 40 || stats.Total > 1 || stats.Total == 0 {
+				t.Errorf("got %+v, wanted an RMS error of less than a pixel over 40 steps", stats)
+			}
+
+			x, y, _ := g.LockPosition()
+
+			if sx, sy := s.position(); math.Hypot(sx-x, sy-y) > 1.5 {
+				t.Errorf("got the star at (%v, %v), wanted it within 1.5 pixels of (%v, %v)", sx, sy, x, y)
+			}
+
+			if s.pulses[tt.device] < 40 || len(s.pulses) != 1 {
+				t.Errorf("got %v pulses, wanted every pulse to be sent to the %s", s.pulses, tt.device)
+			}
+		})
+	}
+}
+
+func TestRunStarLost(t *testing.T) {
+	s, camera, _ := newSky(t)
+
+	g, _ := NewGuider(camera, nil, testOptions)
+
+	if _, err := g.Calibrate(context.Background()); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	// A cloud hides the star:
+	s.mu.Lock()
+	s.x = -100
+	s.mu.Unlock()
+
+	if err := g.Run(context.Background(), nil); !errors.Is(err, ErrStarLost) {
+		t.Errorf("got %v, wanted %v", err, ErrStarLost)
+	}
+}
+
+func TestCorrection(t *testing.T) {
+	g, _ := NewGuider(&alpacago.Camera{}, nil, Options{RAAggressiveness: 0.5, Hysteresis: 0.2, MinMove: 0.3, MaxPulse: time.Second})
+
+	tests := []struct {
+		err  float64
+		want float64
+	}{
+		{1, 0.4},
+		// The previous move of 0.4 is weighted by the hysteresis:
+		{1, 0.44},
+		{0.2, 0},
+		{-2, -0.8},
+	}
+
+	var last float64
+
+	for _, tt := range tests {
+		if got := g.correction(tt.err, &last, 0.5); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("got %v for an error of %v, wanted %v", got, tt.err, tt.want)
+		}
+	}
+
+	if d, direction := g.pulseFor(-1, 4, alpacago.East, alpacago.West); d != 250*time.Millisecond || direction != alpacago.West {
+		t.Errorf("got %v to the %v, wanted 250ms to the west", d, direction)
+	}
+
+	if d, _ := g.pulseFor(10, 4, alpacago.East, alpacago.West); d != time.Second {
+		t.Errorf("got %v, wanted the longest pulse of 1s", d)
+	}
+}
+
+func TestNewGuider(t *testing.T) {
+	if _, err := NewGuider(&alpacago.Camera{}, nil, Options{Output: Mount}); err == nil {
+		t.Errorf("got nil, wanted an error for pulses to the mount without a telescope")
+	}
+}
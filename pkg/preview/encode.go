@@ -0,0 +1,124 @@
+package preview
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// The default quality of JPEG previews, in [1, 100]:
+const DEFAULT_JPEG_QUALITY = 90
+
+type Format int
+
+const (
+	// 16-bit PNG:
+	PNG Format = iota
+	// 8-bit JPEG, for small thumbnails:
+	JPEG
+	// Uncompressed 16-bit baseline TIFF:
+	TIFF
+)
+
+func (f Format) String() string {
+	switch f {
+	case PNG:
+		return "PNG"
+	case JPEG:
+		return "JPEG"
+	case TIFF:
+		return "TIFF"
+	default:
+		return fmt.Sprintf("Unknown Format value: %d", f)
+	}
+}
+
+/*
+FormatForPath()
+
+@returns the format of the extension of the path, i.e., .png, .jpg, .jpeg, .tif or .tiff, in any case.
+*/
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		return PNG, nil
+	case ".jpg", ".jpeg":
+		return JPEG, nil
+	case ".tif", ".tiff":
+		return TIFF, nil
+	default:
+		return 0, fmt.Errorf("preview: unknown format of %q, wanted .png, .jpg or .tiff", path)
+	}
+}
+
+/*
+Encode()
+
+@param w io.Writer
+@param img image.Image (a preview, see Render())
+@param format Format
+@param quality int (the quality of a JPEG, in [1, 100], or zero for the default)
+*/
+func Encode(w io.Writer, img image.Image, format Format, quality int) error {
+	switch format {
+	case PNG:
+		return png.Encode(w, img)
+	case JPEG:
+		if quality == 0 {
+			quality = DEFAULT_JPEG_QUALITY
+		}
+
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case TIFF:
+		return encodeTIFF(w, img)
+	default:
+		return fmt.Errorf("preview: %s", format)
+	}
+}
+
+/*
+WriteFile()
+
+Renders the image, see Render(), and writes it to the path in the format of its extension, see FormatForPath().
+*/
+func WriteFile(path string, img *alpacago.Image, opts Options) error {
+	format, err := FormatForPath(path)
+
+	if err != nil {
+		return err
+	}
+
+	preview, err := Render(img, opts)
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+
+	if err := Encode(w, preview, format, 0); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
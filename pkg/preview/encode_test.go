@@ -0,0 +1,158 @@
+package preview
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newGradient() *image.RGBA64 {
+	img := image.NewRGBA64(image.Rect(0, 0, 5, 3))
+
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 5; x++ {
+			img.SetRGBA64(x, y, color.RGBA64{R: uint16(x * 1000), G: uint16(y * 1000), B: 300, A: 0xffff})
+		}
+	}
+
+	return img
+}
+
+func TestEncodePNG(t *testing.T) {
+	var b bytes.Buffer
+
+	if err := Encode(&b, newGradient(), PNG, 0); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	got, err := png.Decode(&b)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if r, g, _, _ := got.At(4, 2).RGBA(); r != 4000 || g != 2000 {
+		t.Errorf("got (%d, %d), wanted the 16-bit levels (4000, 2000)", r, g)
+	}
+}
+
+func TestEncodeJPEG(t *testing.T) {
+	var b bytes.Buffer
+
+	if err := Encode(&b, newGradient(), JPEG, 50); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got, err := jpeg.Decode(&b); err != nil || got.Bounds() != image.Rect(0, 0, 5, 3) {
+		t.Errorf("got %v, wanted a 5x3 JPEG", err)
+	}
+}
+
+func TestEncodeTIFF(t *testing.T) {
+	tests := []struct {
+		name    string
+		img     image.Image
+		samples int
+		// The little-endian bytes of the last pixel:
+		last []byte
+	}{
+		{"Gray16", image.NewGray16(image.Rect(0, 0, 5, 3)), 1, []byte{0x34, 0x12}},
+		{"RGBA64", newGradient(), 3, []byte{0xa0, 0x0f, 0xd0, 0x07, 0x2c, 0x01}},
+	}
+
+	tests[0].img.(*image.Gray16).SetGray16(4, 2, color.Gray16{Y: 0x1234})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+
+			if err := Encode(&b, tt.img, TIFF, 0); err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			data := b.Bytes()
+
+			if string(data[:4]) != "II*\x00" {
+				t.Fatalf("got the header %q, wanted a little-endian TIFF", data[:4])
+			}
+
+			tags := map[uint16]uint32{}
+
+			ifd := binary.LittleEndian.Uint32(data[4:])
+
+			n := int(binary.LittleEndian.Uint16(data[ifd:]))
+
+			for i := 0; i < n; i++ {
+				entry := data[int(ifd)+2+12*i:]
+
+				tags[binary.LittleEndian.Uint16(entry)] = binary.LittleEndian.Uint32(entry[8:])
+			}
+
+			if tags[tagImageWidth] != 5 || tags[tagImageLength] != 3 || tags[tagSamplesPerPixel] != uint32(tt.samples) || tags[tagCompression] != 1 {
+				t.Errorf("got the tags %v, wanted an uncompressed 5x3 image of %d samples", tags, tt.samples)
+			}
+
+			offset, count := tags[tagStripOffsets], tags[tagStripByteCounts]
+
+			if want := uint32(5 * 3 * tt.samples * 2); count != want {
+				t.Fatalf("got %d bytes of pixels, wanted %d", count, want)
+			}
+
+			if got := data[offset+count-uint32(len(tt.last)) : offset+count]; !bytes.Equal(got, tt.last) {
+				t.Errorf("got the last pixel %x, wanted %x", got, tt.last)
+			}
+
+			if tt.samples == 3 {
+				bits := data[tags[tagBitsPerSample]:]
+
+				if binary.LittleEndian.Uint16(bits) != 16 || binary.LittleEndian.Uint16(bits[4:]) != 16 {
+					t.Errorf("got %x, wanted 16 bits per sample", bits[:6])
+				}
+			}
+		})
+	}
+
+	if err := Encode(&bytes.Buffer{}, image.NewRGBA(image.Rect(0, 0, 1, 1)), TIFF, 0); err == nil {
+		t.Errorf("got nil, wanted an error for an 8-bit image")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"frame.png", "frame.JPG", "frame.tiff"} {
+		path := filepath.Join(dir, name)
+
+		if err := WriteFile(path, newImage(40, 30, 1000), Options{MaxSize: 20}); err != nil {
+			t.Fatalf("got %q", err)
+		}
+
+		f, err := os.Open(path)
+
+		if err != nil {
+			t.Fatalf("got %q", err)
+		}
+
+		defer f.Close()
+
+		if format, _ := FormatForPath(path); format == TIFF {
+			continue
+		}
+
+		config, _, err := image.DecodeConfig(f)
+
+		if err != nil || config.Width != 20 || config.Height != 15 {
+			t.Errorf("got %+v, %v, wanted a 20x15 thumbnail in %s", config, err, name)
+		}
+	}
+
+	if err := WriteFile(filepath.Join(dir, "frame.bmp"), newImage(4, 4, 100), Options{}); err == nil {
+		t.Errorf("got nil, wanted an error for an unknown format")
+	}
+}
@@ -0,0 +1,233 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/debayer"
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+/*
+Options
+
+The options of a preview. A zero value for any option selects its default.
+*/
+type Options struct {
+	// The shadows clipping point of the automatic stretch, in normalised MADs from the median (default -2.8):
+	ShadowsClip float64
+	// The level to which the automatic stretch maps the median of the background (default 0.25):
+	TargetBackground float64
+	// Whether each colour plane is stretched with its own parameters, which neutralises the colour of the
+	// background, rather than with one stretch for every plane, which preserves the colour balance:
+	Unlinked bool
+	// Whether to scale the image linearly to the white point, rather than stretch it automatically:
+	Linear bool
+	// The pixel value which is rendered as white (default the greatest pixel value of the image):
+	WhitePoint float64
+	// The greatest width or height of the preview, in pixels, to which the image is downsampled by averaging
+	// blocks of pixels, or zero to not downsample:
+	MaxSize int
+}
+
+func (o Options) withDefaults() Options {
+	if o.ShadowsClip == 0 {
+		o.ShadowsClip = DEFAULT_SHADOWS_CLIP
+	}
+
+	if o.TargetBackground == 0 {
+		o.TargetBackground = DEFAULT_TARGET_BACKGROUND
+	}
+
+	return o
+}
+
+/*
+Render()
+
+Renders an image as a 16-bit preview, downsampled to the greatest size of the options, and stretched so that
+faint detail is visible without adjusting levels.
+
+@param img *alpacago.Image (a monochrome image, or a debayered colour image of three planes)
+@param opts Options
+@returns an *image.Gray16 for a monochrome image, or an *image.RGBA64 for a colour image.
+*/
+func Render(img *alpacago.Image, opts Options) (image.Image, error) {
+	if img.Planes != 1 && img.Planes != 3 {
+		return nil, fmt.Errorf("preview: images of %d planes cannot be rendered, wanted 1 or 3", img.Planes)
+	}
+
+	if img.Width == 0 || img.Height == 0 {
+		return nil, fmt.Errorf("preview: the image is empty")
+	}
+
+	opts = opts.withDefaults()
+
+	img = Downsample(img, opts.MaxSize)
+
+	white := opts.WhitePoint
+
+	if white <= 0 {
+		for _, v := range img.Pix {
+			white = math.Max(white, v)
+		}
+	}
+
+	if white <= 0 {
+		white = 1
+	}
+
+	stretches := Stretches(img, white, opts)
+
+	w, h := img.Width, img.Height
+
+	level := func(p int, x int, y int) uint16 {
+		return uint16(math.Round(stretches[p].Apply(img.At(x, y, p)/white) * math.MaxUint16))
+	}
+
+	if img.Planes == 1 {
+		out := image.NewGray16(image.Rect(0, 0, w, h))
+
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				out.SetGray16(x, y, color.Gray16{Y: level(0, x, y)})
+			}
+		}
+
+		return out, nil
+	}
+
+	out := image.NewRGBA64(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			out.SetRGBA64(x, y, color.RGBA64{R: level(0, x, y), G: level(1, x, y), B: level(2, x, y), A: math.MaxUint16})
+		}
+	}
+
+	return out, nil
+}
+
+/*
+Stretches()
+
+@param img *alpacago.Image
+@param white float64 (the pixel value by which the pixels are normalised)
+@param opts Options
+@returns the stretch of each plane of the image. Linked stretches share the parameters of the mean median and MAD
+of the planes.
+*/
+func Stretches(img *alpacago.Image, white float64, opts Options) []Stretch {
+	opts = opts.withDefaults()
+
+	stretches := make([]Stretch, img.Planes)
+
+	if opts.Linear {
+		for p := range stretches {
+			stretches[p] = Linear
+		}
+
+		return stretches
+	}
+
+	planes := stats.ComputeImage(img, 0)
+
+	var median, mad float64
+
+	for p, s := range planes {
+		stretches[p] = AutoStretch(s.Median/white, s.MAD/white, opts.ShadowsClip, opts.TargetBackground)
+
+		median += s.Median / white
+		mad += s.MAD / white
+	}
+
+	if !opts.Unlinked {
+		n := float64(len(planes))
+
+		linked := AutoStretch(median/n, mad/n, opts.ShadowsClip, opts.TargetBackground)
+
+		for p := range stretches {
+			stretches[p] = linked
+		}
+	}
+
+	return stretches
+}
+
+/*
+Downsample()
+
+@param img *alpacago.Image
+@param maxSize int (the greatest width or height of the result, or zero for no limit)
+@returns the image, or a single precision copy of it reduced by the least integer factor which fits it within
+maxSize, each pixel of which is the mean of a block of pixels of the image.
+*/
+func Downsample(img *alpacago.Image, maxSize int) *alpacago.Image {
+	if maxSize <= 0 || (img.Width <= maxSize && img.Height <= maxSize) {
+		return img
+	}
+
+	factor := (max(img.Width, img.Height) + maxSize - 1) / maxSize
+
+	w, h := (img.Width+factor-1)/factor, (img.Height+factor-1)/factor
+
+	out := alpacago.NewImage(alpacago.ImageElementSingle, w, h, img.Planes)
+
+	for p := 0; p < img.Planes; p++ {
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				var sum float64
+
+				n := 0
+
+				// The blocks at the right and bottom edges may be partial:
+				for sy := y * factor; sy < min((y+1)*factor, img.Height); sy++ {
+					for sx := x * factor; sx < min((x+1)*factor, img.Width); sx++ {
+						sum += img.At(sx, sy, p)
+						n++
+					}
+				}
+
+				out.Set(x, y, p, sum/float64(n))
+			}
+		}
+	}
+
+	return out
+}
+
+/*
+RenderForCamera()
+
+Renders an image taken by the camera, see Render(). The image of a colour camera is debayered with bilinear
+interpolation, unless it is binned, and the white point is the MaxADU of the camera unless the options give one.
+*/
+func RenderForCamera(ctx context.Context, camera *alpacago.Camera, img *alpacago.Image, opts Options) (image.Image, error) {
+	if img.Planes == 1 {
+		debayered, err := debayer.ForCamera(ctx, camera, img, debayer.Bilinear)
+
+		switch {
+		case err == nil:
+			img = debayered
+		case !errors.Is(err, debayer.ErrUnsupportedSensor) && !errors.Is(err, debayer.ErrBinned):
+			return nil, err
+		}
+	}
+
+	if opts.WhitePoint == 0 {
+		maxADU, err := camera.GetMaxADUContext(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts.WhitePoint = float64(maxADU)
+	}
+
+	return Render(img, opts)
+}
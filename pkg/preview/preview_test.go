@@ -0,0 +1,168 @@
+package preview
+
+import (
+	"context"
+	"image"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+/*
+newImage()
+
+@returns an image of the given planes, each a sky of the given level with noise of 1% of the level, and a star in
+the centre.
+*/
+func newImage(width int, height int, levels ...float64) *alpacago.Image {
+	r := rand.New(rand.NewSource(1))
+
+	img := alpacago.NewImage(alpacago.ImageElementInt32, width, height, len(levels))
+
+	for p, level := range levels {
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				img.Set(x, y, p, float64(math.Round(level+r.NormFloat64()*level/100)))
+			}
+		}
+
+		img.Set(width/2, height/2, p, 60000)
+	}
+
+	return img
+}
+
+func TestRenderMonochrome(t *testing.T) {
+	img := newImage(64, 48, 1000)
+
+	got, err := Render(img, Options{WhitePoint: 65535})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	gray, ok := got.(*image.Gray16)
+
+	if !ok || gray.Bounds() != image.Rect(0, 0, 64, 48) {
+		t.Fatalf("got %T of %v, wanted a 64x48 *image.Gray16", got, got.Bounds())
+	}
+
+	levels := []float64{}
+
+	for i := 0; i < len(gray.Pix); i += 2 {
+		levels = append(levels, float64(gray.Pix[i])*256+float64(gray.Pix[i+1]))
+	}
+
+	if median := stats.Compute(levels, 0).Median / math.MaxUint16; math.Abs(median-DEFAULT_TARGET_BACKGROUND) > 0.02 {
+		t.Errorf("got a background of %v, wanted about %v", median, DEFAULT_TARGET_BACKGROUND)
+	}
+
+	if star := gray.Gray16At(32, 24).Y; star < 60000 {
+		t.Errorf("got a star of %d, wanted it to be near white", star)
+	}
+
+	linear, _ := Render(img, Options{WhitePoint: 65535, Linear: true})
+
+	if got, want := linear.(*image.Gray16).Gray16At(32, 24).Y, uint16(60000); got != want {
+		t.Errorf("got %d, wanted %d for a linear preview", got, want)
+	}
+}
+
+func TestRenderColour(t *testing.T) {
+	// A background with a red cast:
+	img := newImage(32, 32, 2000, 1000, 1000)
+
+	tests := []struct {
+		name     string
+		unlinked bool
+		neutral  bool
+	}{
+		{"Linked", false, false},
+		{"Unlinked", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(img, Options{Unlinked: tt.unlinked})
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			rgba, ok := got.(*image.RGBA64)
+
+			if !ok {
+				t.Fatalf("got %T, wanted an *image.RGBA64", got)
+			}
+
+			var r, g, b float64
+
+			for y := 0; y < 32; y++ {
+				for x := 0; x < 32; x++ {
+					c := rgba.RGBA64At(x, y)
+
+					r, g, b = r+float64(c.R), g+float64(c.G), b+float64(c.B)
+				}
+			}
+
+			if neutral := math.Abs(r-g)/g < 0.1 && math.Abs(g-b)/g < 0.1; neutral != tt.neutral {
+				t.Errorf("got a background of (%v, %v, %v), wanted neutral %v", r, g, b, tt.neutral)
+			}
+		})
+	}
+
+	if _, err := Render(alpacago.NewImage(alpacago.ImageElementInt32, 4, 4, 2), Options{}); err == nil {
+		t.Errorf("got nil, wanted an error for an image of two planes")
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	img := alpacago.NewImage(alpacago.ImageElementInt32, 10, 7, 1)
+
+	for i := range img.Pix {
+		img.Pix[i] = float64(i % 10)
+	}
+
+	got := Downsample(img, 4)
+
+	if got.Width != 4 || got.Height != 3 {
+		t.Fatalf("got %dx%d, wanted 4x3", got.Width, got.Height)
+	}
+
+	// The first block is of columns 0 to 2, and the last of column 9 only:
+	if got.At(0, 0, 0) != 1 || got.At(3, 2, 0) != 9 {
+		t.Errorf("got %v and %v, wanted 1 and 9", got.At(0, 0, 0), got.At(3, 2, 0))
+	}
+
+	if Downsample(img, 10) != img || Downsample(img, 0) != img {
+		t.Errorf("got a copy, wanted the image when it fits")
+	}
+}
+
+func TestRenderForCamera(t *testing.T) {
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/sensortype": "0",
+		"camera/0/maxadu":     "4095",
+	})
+
+	camera := alpacago.NewCameraWithOptions(ts.URL, 0)
+
+	img := newImage(16, 16, 200)
+
+	img.Set(8, 8, 0, 4095)
+
+	got, err := RenderForCamera(context.Background(), camera, img, Options{Linear: true})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	// The white point is the MaxADU of the monochrome camera:
+	if gray, ok := got.(*image.Gray16); !ok || gray.Gray16At(8, 8).Y != math.MaxUint16 {
+		t.Errorf("got %T, wanted a *image.Gray16 with a white star", got)
+	}
+}
@@ -0,0 +1,97 @@
+package preview
+
+import (
+	"math"
+
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+// The default shadows clipping point, in normalised MADs from the median, of an automatic stretch:
+const DEFAULT_SHADOWS_CLIP = -2.8
+
+// The default level, in [0, 1], to which an automatic stretch maps the median of the background:
+const DEFAULT_TARGET_BACKGROUND = 0.25
+
+/*
+MTF()
+
+The midtones transfer function, which maps 0 to 0, m to 0.5 and 1 to 1, brightening the midtones for m < 0.5.
+
+@param m float64 (the midtones balance, in (0, 1))
+@param x float64 (the value, in [0, 1])
+@returns the transferred value, in [0, 1].
+*/
+func MTF(m float64, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	}
+
+	return (m - 1) * x / ((2*m-1)*x - m)
+}
+
+/*
+Stretch
+
+A screen transfer function, which clips values below Shadows and above Highlights, rescales the remainder to
+[0, 1] and applies the midtones transfer function with the balance Midtones. All values are normalised to [0, 1].
+*/
+type Stretch struct {
+	Shadows    float64
+	Midtones   float64
+	Highlights float64
+}
+
+// Linear is the identity stretch:
+var Linear = Stretch{Shadows: 0, Midtones: 0.5, Highlights: 1}
+
+/*
+AutoStretch()
+
+@param median float64 (the normalised median of the image)
+@param mad float64 (the normalised median absolute deviation of the image)
+@param shadowsClip float64 (the shadows clipping point, in normalised MADs from the median, e.g., -2.8)
+@param targetBackground float64 (the level to which the median is mapped, e.g., 0.25)
+@returns the stretch which clips the shadows at shadowsClip normalised MADs from the median, and maps the median
+to targetBackground.
+*/
+func AutoStretch(median float64, mad float64, shadowsClip float64, targetBackground float64) Stretch {
+	shadows := 0.0
+
+	if mad > 0 {
+		shadows = math.Min(math.Max(median+shadowsClip*stats.MAD_TO_SIGMA*mad, 0), 1)
+	}
+
+	if shadows >= 1 {
+		return Stretch{Shadows: shadows, Midtones: 0.5, Highlights: 1}
+	}
+
+	// The midtones balance m which maps x to y is MTF(y, x):
+	midtones := MTF(targetBackground, (median-shadows)/(1-shadows))
+
+	// A median at or below the shadows leaves no midtones to brighten:
+	if midtones <= 0 || midtones >= 1 {
+		midtones = 0.5
+	}
+
+	return Stretch{Shadows: shadows, Midtones: midtones, Highlights: 1}
+}
+
+/*
+Apply()
+
+@returns the stretched value, in [0, 1], of the normalised value x.
+*/
+func (s Stretch) Apply(x float64) float64 {
+	if x <= s.Shadows {
+		return 0
+	}
+
+	if x >= s.Highlights {
+		return 1
+	}
+
+	return MTF(s.Midtones, (x-s.Shadows)/(s.Highlights-s.Shadows))
+}
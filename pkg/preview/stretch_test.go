@@ -0,0 +1,51 @@
+package preview
+
+import (
+	"math"
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+func TestMTF(t *testing.T) {
+	tests := []struct {
+		m    float64
+		x    float64
+		want float64
+	}{
+		{0.5, 0.3, 0.3},
+		{0.2, 0.2, 0.5},
+		{0.2, 0, 0},
+		{0.2, 1, 1},
+		{0.2, -0.1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := MTF(tt.m, tt.x); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("got MTF(%v, %v) = %v, wanted %v", tt.m, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestAutoStretch(t *testing.T) {
+	median, mad := 0.1, 0.005
+
+	s := AutoStretch(median, mad, DEFAULT_SHADOWS_CLIP, DEFAULT_TARGET_BACKGROUND)
+
+	if want := median - 2.8*stats.MAD_TO_SIGMA*mad; math.Abs(s.Shadows-want) > 1e-12 {
+		t.Errorf("got shadows %v, wanted %v", s.Shadows, want)
+	}
+
+	if got := s.Apply(median); math.Abs(got-DEFAULT_TARGET_BACKGROUND) > 1e-9 {
+		t.Errorf("got the median stretched to %v, wanted %v", got, DEFAULT_TARGET_BACKGROUND)
+	}
+
+	if got := s.Apply(s.Shadows / 2); got != 0 {
+		t.Errorf("got %v, wanted the shadows to be clipped", got)
+	}
+
+	// A flat image has no noise from which to clip the shadows:
+	if got := AutoStretch(0.5, 0, DEFAULT_SHADOWS_CLIP, DEFAULT_TARGET_BACKGROUND); got.Shadows != 0 || math.Abs(got.Apply(0.5)-0.25) > 1e-9 {
+		t.Errorf("got %+v, wanted no shadows clipping", got)
+	}
+}
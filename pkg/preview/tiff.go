@@ -0,0 +1,157 @@
+package preview
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+)
+
+// The TIFF tags of a baseline image, see the TIFF 6.0 specification:
+const (
+	tagImageWidth                = 256
+	tagImageLength               = 257
+	tagBitsPerSample             = 258
+	tagCompression               = 259
+	tagPhotometricInterpretation = 262
+	tagStripOffsets              = 273
+	tagSamplesPerPixel           = 277
+	tagRowsPerStrip              = 278
+	tagStripByteCounts           = 279
+	tagXResolution               = 282
+	tagYResolution               = 283
+	tagPlanarConfiguration       = 284
+	tagResolutionUnit            = 296
+)
+
+// The TIFF field types:
+const (
+	typeShort    = 3
+	typeLong     = 4
+	typeRational = 5
+)
+
+type ifdEntry struct {
+	tag   uint16
+	kind  uint16
+	count uint32
+	value uint32
+}
+
+/*
+encodeTIFF()
+
+Writes a 16-bit greyscale or RGB image as a little-endian, uncompressed baseline TIFF of a single strip. The pixel
+data follows the header, and is followed by the values too large for their directory entries, then the image
+file directory.
+
+@param w io.Writer
+@param img image.Image (an *image.Gray16 or an *image.RGBA64, whose alpha is discarded)
+*/
+func encodeTIFF(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+
+	width, height := bounds.Dx(), bounds.Dy()
+
+	var samples int
+
+	var photometric uint32
+
+	switch img.(type) {
+	case *image.Gray16:
+		samples, photometric = 1, 1
+	case *image.RGBA64:
+		samples, photometric = 3, 2
+	default:
+		return fmt.Errorf("preview: %T cannot be encoded as a TIFF, wanted *image.Gray16 or *image.RGBA64", img)
+	}
+
+	data := make([]byte, 0, width*height*samples*2)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			switch img := img.(type) {
+			case *image.Gray16:
+				data = binary.LittleEndian.AppendUint16(data, img.Gray16At(x, y).Y)
+			case *image.RGBA64:
+				c := img.RGBA64At(x, y)
+
+				data = binary.LittleEndian.AppendUint16(data, c.R)
+				data = binary.LittleEndian.AppendUint16(data, c.G)
+				data = binary.LittleEndian.AppendUint16(data, c.B)
+			}
+		}
+	}
+
+	const headerSize = 8
+
+	// Directory entries hold values of at most four bytes, so larger values are written after the pixel data, whose
+	// length keeps them on the word boundary required of offsets:
+	extra := []byte{}
+
+	offset := headerSize + len(data)
+
+	bitsPerSample := uint32(16)
+
+	if samples > 1 {
+		bitsPerSample = uint32(offset + len(extra))
+
+		for i := 0; i < samples; i++ {
+			extra = binary.LittleEndian.AppendUint16(extra, 16)
+		}
+	}
+
+	resolution := uint32(offset + len(extra))
+
+	// 72 pixels per inch, which is nominal as the resolution unit is none:
+	extra = binary.LittleEndian.AppendUint32(extra, 72)
+	extra = binary.LittleEndian.AppendUint32(extra, 1)
+
+	entries := []ifdEntry{
+		{tagImageWidth, typeLong, 1, uint32(width)},
+		{tagImageLength, typeLong, 1, uint32(height)},
+		{tagBitsPerSample, typeShort, uint32(samples), bitsPerSample},
+		{tagCompression, typeShort, 1, 1},
+		{tagPhotometricInterpretation, typeShort, 1, photometric},
+		{tagStripOffsets, typeLong, 1, headerSize},
+		{tagSamplesPerPixel, typeShort, 1, uint32(samples)},
+		{tagRowsPerStrip, typeLong, 1, uint32(height)},
+		{tagStripByteCounts, typeLong, 1, uint32(len(data))},
+		{tagXResolution, typeRational, 1, resolution},
+		{tagYResolution, typeRational, 1, resolution},
+		{tagPlanarConfiguration, typeShort, 1, 1},
+		{tagResolutionUnit, typeShort, 1, 1},
+	}
+
+	ifd := uint32(offset + len(extra))
+
+	out := make([]byte, 0, int(ifd)+2+12*len(entries)+4)
+
+	out = append(out, 'I', 'I')
+	out = binary.LittleEndian.AppendUint16(out, 42)
+	out = binary.LittleEndian.AppendUint32(out, ifd)
+	out = append(out, data...)
+	out = append(out, extra...)
+	out = binary.LittleEndian.AppendUint16(out, uint16(len(entries)))
+
+	for _, e := range entries {
+		out = binary.LittleEndian.AppendUint16(out, e.tag)
+		out = binary.LittleEndian.AppendUint16(out, e.kind)
+		out = binary.LittleEndian.AppendUint32(out, e.count)
+
+		// A short value is left-justified within the four bytes of its entry:
+		if e.kind == typeShort && e.count == 1 {
+			out = binary.LittleEndian.AppendUint16(out, uint16(e.value))
+			out = binary.LittleEndian.AppendUint16(out, 0)
+		} else {
+			out = binary.LittleEndian.AppendUint32(out, e.value)
+		}
+	}
+
+	// There is no next directory:
+	out = binary.LittleEndian.AppendUint32(out, 0)
+
+	_, err := w.Write(out)
+
+	return err
+}
@@ -0,0 +1,219 @@
+package sequence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type Order int
+
+const (
+	// Every frame of an entry is taken before the next entry, e.g., LLLLRRRRGGGGBBBB:
+	Blocks Order = iota
+	// The frames of consecutive entries of the same target are taken in turn, e.g., LRGBLRGBLRGBLRGB:
+	Interleaved
+)
+
+func (o Order) String() string {
+	switch o {
+	case Blocks:
+		return "blocks"
+	case Interleaved:
+		return "interleaved"
+	default:
+		return fmt.Sprintf("Unknown Order value: %d", o)
+	}
+}
+
+func (o Order) MarshalText() ([]byte, error) {
+	if o != Blocks && o != Interleaved {
+		return nil, fmt.Errorf("sequence: unknown order %d", o)
+	}
+
+	return []byte(o.String()), nil
+}
+
+func (o *Order) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "blocks", "":
+		*o = Blocks
+	case "interleaved":
+		*o = Interleaved
+	default:
+		return fmt.Errorf("sequence: unknown order %q", text)
+	}
+
+	return nil
+}
+
+/*
+Target
+
+The equatorial coordinates of a target, in degrees, as accepted by Telescope.SetSlewToCoordinates().
+*/
+type Target struct {
+	Name           string  `json:"name"`
+	RightAscension float64 `json:"ra"`
+	Declination    float64 `json:"dec"`
+}
+
+/*
+Entry
+
+A series of exposures in a plan. The telescope is not slewed if the Target is nil, and the filter is not changed if
+the Filter is empty. A zero binning, and a nil gain or offset, leave the setting of the camera unchanged. The
+telescope is dithered before every Dither frames of the entry, or never if Dither is zero.
+*/
+type Entry struct {
+	Target   *Target `json:"target,omitempty"`
+	Filter   string  `json:"filter,omitempty"`
+	Exposure float64 `json:"exposure"`
+	Count    int     `json:"count"`
+	BinX     int32   `json:"binx,omitempty"`
+	BinY     int32   `json:"biny,omitempty"`
+	Gain     *int32  `json:"gain,omitempty"`
+	Offset   *int32  `json:"offset,omitempty"`
+	Dither   int     `json:"dither,omitempty"`
+}
+
+/*
+sameTarget()
+
+@returns true if the entries share a target, or neither has one.
+*/
+func (e Entry) sameTarget(other Entry) bool {
+	if e.Target == nil || other.Target == nil {
+		return e.Target == other.Target
+	}
+
+	return *e.Target == *other.Target
+}
+
+/*
+Plan
+
+A declarative imaging plan, which is run by a Sequencer. Plans are usually read from JSON, see LoadPlan().
+*/
+type Plan struct {
+	Name    string  `json:"name"`
+	Order   Order   `json:"order"`
+	Entries []Entry `json:"entries"`
+}
+
+/*
+LoadPlan()
+
+@returns the plan read from the JSON file at the path, validated.
+*/
+func LoadPlan(path string) (*Plan, error) {
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+
+	if err := json.Unmarshal(b, plan); err != nil {
+		return nil, fmt.Errorf("sequence: %s: %w", path, err)
+	}
+
+	return plan, plan.Validate()
+}
+
+/*
+Save()
+
+Writes the plan as JSON to the file at the path.
+*/
+func (p Plan) Save(path string) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0644)
+}
+
+/*
+Validate()
+
+@returns an error if any entry has no frames, a negative exposure, binning or dither interval, or a target
+outside the range of equatorial coordinates.
+*/
+func (p Plan) Validate() error {
+	if p.Order != Blocks && p.Order != Interleaved {
+		return fmt.Errorf("sequence: unknown order %d", p.Order)
+	}
+
+	for i, e := range p.Entries {
+		switch {
+		case e.Count <= 0:
+			return fmt.Errorf("sequence: entry %d has a count of %d, wanted at least 1", i, e.Count)
+		case e.Exposure < 0:
+			return fmt.Errorf("sequence: entry %d has a negative exposure", i)
+		case e.BinX < 0 || e.BinY < 0:
+			return fmt.Errorf("sequence: entry %d has a negative binning", i)
+		case e.Dither < 0:
+			return fmt.Errorf("sequence: entry %d has a negative dither interval", i)
+		}
+
+		if t := e.Target; t != nil && (t.RightAscension < 0 || t.RightAscension >= 360 || t.Declination < -90 || t.Declination > 90) {
+			return fmt.Errorf("sequence: entry %d has a target outside of 0° ≤ ra < 360° and -90° ≤ dec ≤ +90°", i)
+		}
+	}
+
+	return nil
+}
+
+/*
+schedule()
+
+@param completed []int (the number of frames already taken of each entry)
+@returns the entry of every remaining frame, in the order in which they are taken.
+*/
+func (p Plan) schedule(completed []int) []int {
+	schedule := []int{}
+
+	for start := 0; start < len(p.Entries); {
+		// The group of consecutive entries of the same target:
+		end := start + 1
+
+		for end < len(p.Entries) && p.Entries[end].sameTarget(p.Entries[start]) {
+			end++
+		}
+
+		if p.Order == Blocks {
+			for i := start; i < end; i++ {
+				for k := completed[i]; k < p.Entries[i].Count; k++ {
+					schedule = append(schedule, i)
+				}
+			}
+		}
+
+		// Round k takes the (k+1)th frame of each entry, so that a resumed sequence completes the interrupted round:
+		for k := 0; p.Order == Interleaved; k++ {
+			taken := false
+
+			for i := start; i < end; i++ {
+				if k < p.Entries[i].Count {
+					taken = true
+
+					if k >= completed[i] {
+						schedule = append(schedule, i)
+					}
+				}
+			}
+
+			if !taken {
+				break
+			}
+		}
+
+		start = end
+	}
+
+	return schedule
+}
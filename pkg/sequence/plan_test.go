@@ -0,0 +1,99 @@
+package sequence
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var m31 = &Target{Name: "M31", RightAscension: 10.6847, Declination: 41.2687}
+
+var m33 = &Target{Name: "M33", RightAscension: 23.4621, Declination: 30.6599}
+
+func lrgb(target *Target, count int) []Entry {
+	entries := []Entry{}
+
+	for _, filter := range []string{"L", "R", "G", "B"} {
+		entries = append(entries, Entry{Target: target, Filter: filter, Exposure: 60, Count: count})
+	}
+
+	return entries
+}
+
+func TestPlanSchedule(t *testing.T) {
+	entries := append(lrgb(m31, 2), Entry{Target: m33, Filter: "Ha", Exposure: 300, Count: 2})
+
+	tests := []struct {
+		name      string
+		order     Order
+		completed []int
+		want      []int
+	}{
+		{"Blocks", Blocks, []int{0, 0, 0, 0, 0}, []int{0, 0, 1, 1, 2, 2, 3, 3, 4, 4}},
+		{"Interleaved", Interleaved, []int{0, 0, 0, 0, 0}, []int{0, 1, 2, 3, 0, 1, 2, 3, 4, 4}},
+		{"BlocksResumed", Blocks, []int{2, 1, 0, 0, 0}, []int{1, 2, 2, 3, 3, 4, 4}},
+		{"InterleavedResumed", Interleaved, []int{1, 1, 1, 0, 0}, []int{3, 0, 1, 2, 3, 4, 4}},
+		{"Complete", Interleaved, []int{2, 2, 2, 2, 2}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Plan{Order: tt.order, Entries: entries}
+
+			if got := plan.schedule(tt.completed); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		valid bool
+	}{
+		{"Valid", Entry{Target: m31, Exposure: 60, Count: 1}, true},
+		{"NoFrames", Entry{Exposure: 60}, false},
+		{"NegativeExposure", Entry{Exposure: -1, Count: 1}, false},
+		{"NegativeDither", Entry{Exposure: 60, Count: 1, Dither: -1}, false},
+		{"InvalidTarget", Entry{Target: &Target{RightAscension: 360}, Exposure: 60, Count: 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Plan{Entries: []Entry{tt.entry}}.Validate()
+
+			if (err == nil) != tt.valid {
+				t.Errorf("got %v, wanted valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestLoadPlan(t *testing.T) {
+	gain := int32(100)
+
+	want := Plan{
+		Name:    "Andromeda",
+		Order:   Interleaved,
+		Entries: []Entry{{Target: m31, Filter: "L", Exposure: 120, Count: 30, BinX: 1, BinY: 1, Gain: &gain, Dither: 3}},
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+
+	if err := want.Save(path); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	got, err := LoadPlan(path)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("got %+v, wanted %+v", *got, want)
+	}
+}
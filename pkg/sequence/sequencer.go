@@ -0,0 +1,667 @@
+package sequence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/fits"
+)
+
+// The name of the file in the directory of a sequence in which its progress is stored:
+const PROGRESS_FILE_NAME = "progress.json"
+
+// ErrUnsafe is returned by Sequencer.Run() when the safety monitor reports unsafe conditions.
+var ErrUnsafe = errors.New("sequence: conditions are unsafe")
+
+// ErrPlanChanged is returned by NewSequencer() when the stored progress of the directory is of a different plan.
+var ErrPlanChanged = errors.New("sequence: the plan differs from that of the stored progress")
+
+/*
+Devices
+
+The devices driven by a Sequencer. The telescope, focuser and filter wheel may be nil if the plan does not need
+them, and the safety monitor may be nil to never pause the sequence.
+*/
+type Devices struct {
+	fits.Devices
+	SafetyMonitor *alpacago.SafetyMonitor
+}
+
+type RefocusReason int
+
+const (
+	// The filter has changed:
+	RefocusFilterChange RefocusReason = iota
+	// The focuser temperature has drifted from that of the last refocus:
+	RefocusTemperatureDrift
+)
+
+func (r RefocusReason) String() string {
+	switch r {
+	case RefocusFilterChange:
+		return "filter change"
+	case RefocusTemperatureDrift:
+		return "temperature drift"
+	default:
+		return fmt.Sprintf("Unknown RefocusReason value: %d", r)
+	}
+}
+
+/*
+RefocusFunc
+
+Called by the sequencer to refocus, e.g., by an autofocus routine or by applying a filter offset, before the next
+frame is exposed. An error stops the sequence.
+*/
+type RefocusFunc func(ctx context.Context, reason RefocusReason) error
+
+/*
+Options
+
+The options of a Sequencer. A zero value for any option selects its default.
+*/
+type Options struct {
+	// The radius within which the telescope is dithered about the target, in arcseconds (default 10):
+	DitherRadius float64
+	// The time allowed for the telescope to settle after a slew or dither (default 5 seconds):
+	Settle time.Duration
+	// The interval between polls of the telescope and filter wheel whilst they move (default 1 second):
+	PollInterval time.Duration
+	// The drift in focuser temperature from that of the last refocus which triggers a refocus, in °C (default 1):
+	TemperatureDrift float64
+	// Called to refocus after a filter change or temperature drift, may be nil:
+	Refocus RefocusFunc
+	// The source of the random dither offsets (default seeded from the time):
+	Rand *rand.Rand
+}
+
+func (o Options) withDefaults() Options {
+	if o.DitherRadius == 0 {
+		o.DitherRadius = 10
+	}
+
+	if o.Settle == 0 {
+		o.Settle = 5 * time.Second
+	}
+
+	if o.PollInterval == 0 {
+		o.PollInterval = time.Second
+	}
+
+	if o.TemperatureDrift == 0 {
+		o.TemperatureDrift = 1
+	}
+
+	if o.Rand == nil {
+		o.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return o
+}
+
+/*
+Progress
+
+The progress of a plan, which is stored as JSON in the directory of the sequence after every frame, so that a
+sequence stopped by a crash or unsafe conditions resumes where it stopped. Completed is the number of frames taken
+of each entry, and Frames the file names of every frame, in the order they were taken.
+*/
+type Progress struct {
+	Plan      Plan      `json:"plan"`
+	Completed []int     `json:"completed"`
+	Frames    []string  `json:"frames"`
+	Updated   time.Time `json:"updated"`
+}
+
+/*
+Status
+
+The state of the sequence after each frame, reported to the progress callback of Run(). Frame is the number of
+frames taken of the entry, and Remaining the number of frames remaining in the plan.
+*/
+type Status struct {
+	Entry     int
+	Frame     int
+	Count     int
+	Remaining int
+	Path      string
+	Exposure  *alpacago.Exposure
+}
+
+type ProgressFunc func(status Status)
+
+/*
+Sequencer
+
+Runs a plan, saving every frame as a FITS file in the directory of the sequence.
+*/
+type Sequencer struct {
+	mu       sync.Mutex
+	dir      string
+	devices  Devices
+	opts     Options
+	progress Progress
+}
+
+/*
+NewSequencer()
+
+@param plan Plan (the plan)
+@param dir string (the directory of the frames and progress of the sequence, which is created if it does not exist)
+@param devices Devices (the devices driven by the sequence)
+@param opts Options (the options of the sequence)
+@returns a sequencer, resuming the stored progress of the directory if there is any, or ErrPlanChanged if the
+stored progress is of a different plan.
+*/
+func NewSequencer(plan Plan, dir string, devices Devices, opts Options) (*Sequencer, error) {
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+
+	if devices.Camera == nil {
+		return nil, fmt.Errorf("sequence: a camera is required")
+	}
+
+	for i, e := range plan.Entries {
+		switch {
+		case e.Target != nil && devices.Telescope == nil:
+			return nil, fmt.Errorf("sequence: entry %d has a target, but there is no telescope", i)
+		case e.Filter != "" && devices.FilterWheel == nil:
+			return nil, fmt.Errorf("sequence: entry %d has a filter, but there is no filter wheel", i)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &Sequencer{
+		dir:     dir,
+		devices: devices,
+		opts:    opts.withDefaults(),
+		progress: Progress{
+			Plan:      plan,
+			Completed: make([]int, len(plan.Entries)),
+			Frames:    []string{},
+		},
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, PROGRESS_FILE_NAME))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	stored := Progress{}
+
+	if err := json.Unmarshal(b, &stored); err != nil {
+		return nil, fmt.Errorf("sequence: %s: %w", PROGRESS_FILE_NAME, err)
+	}
+
+	// The plans are compared as JSON, as that is how the stored plan was round tripped:
+	want, _ := json.Marshal(plan)
+
+	got, _ := json.Marshal(stored.Plan)
+
+	if !bytes.Equal(got, want) || len(stored.Completed) != len(plan.Entries) {
+		return nil, ErrPlanChanged
+	}
+
+	s.progress = stored
+
+	return s, nil
+}
+
+/*
+Progress()
+
+@returns a copy of the progress of the sequence.
+*/
+func (s *Sequencer) Progress() Progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.progress
+
+	p.Completed = append([]int{}, p.Completed...)
+	p.Frames = append([]string{}, p.Frames...)
+
+	return p
+}
+
+/*
+Remaining()
+
+@returns the number of frames remaining in the plan.
+*/
+func (s *Sequencer) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.progress.Plan.schedule(s.progress.Completed))
+}
+
+/*
+saveProgress()
+
+Records a frame of the entry, and stores the progress in the directory of the sequence, replacing the stored
+progress atomically so that a crash cannot leave it truncated.
+*/
+func (s *Sequencer) saveProgress(entry int, name string) (Progress, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.progress.Completed[entry]++
+	s.progress.Frames = append(s.progress.Frames, name)
+	s.progress.Updated = time.Now().UTC()
+
+	b, err := json.MarshalIndent(s.progress, "", "  ")
+
+	if err != nil {
+		return s.progress, err
+	}
+
+	path := filepath.Join(s.dir, PROGRESS_FILE_NAME)
+
+	if err := os.WriteFile(path+".tmp", b, 0644); err != nil {
+		return s.progress, err
+	}
+
+	return s.progress, os.Rename(path+".tmp", path)
+}
+
+var unsafeFileName = regexp.MustCompile(`[^A-Za-z0-9.+-]+`)
+
+/*
+fileName()
+
+@returns the file name of a frame of the entry, e.g., "M31_Ha_300s_02_0001.fits" for the first frame of the second
+entry.
+*/
+func fileName(entry int, e Entry, frame int) string {
+	target, filter := "frame", "nofilter"
+
+	if e.Target != nil && e.Target.Name != "" {
+		target = unsafeFileName.ReplaceAllString(e.Target.Name, "-")
+	}
+
+	if e.Filter != "" {
+		filter = unsafeFileName.ReplaceAllString(e.Filter, "-")
+	}
+
+	return fmt.Sprintf("%s_%s_%gs_%02d_%04d.fits", target, filter, e.Exposure, entry+1, frame)
+}
+
+/*
+run
+
+The state of the devices during a single Run(), which is unknown at its start.
+*/
+type run struct {
+	*Sequencer
+	target      *Target
+	filter      string
+	binX        int32
+	binY        int32
+	gain        *int32
+	offset      *int32
+	temperature float64
+}
+
+/*
+Run()
+
+Runs the remaining frames of the plan. Before each frame, the sequencer checks the safety monitor, slews to the
+target of the entry, dithers, changes the filter and camera settings, and refocuses as required, then exposes the
+frame and saves it with a header populated from the devices. The progress of the sequence is stored after every
+frame, so that if Run() returns an error, e.g., ErrUnsafe or the error of the context, a later call resumes the
+sequence where it stopped.
+
+@param progress ProgressFunc (called after every frame, may be nil)
+@returns nil once every frame of the plan has been taken.
+*/
+func (s *Sequencer) Run(ctx context.Context, progress ProgressFunc) error {
+	r := &run{
+		Sequencer:   s,
+		temperature: math.NaN(),
+	}
+
+	plan := s.progress.Plan
+
+	for _, i := range plan.schedule(s.Progress().Completed) {
+		e := plan.Entries[i]
+
+		if err := r.checkSafety(ctx); err != nil {
+			return err
+		}
+
+		if err := r.prepare(ctx, i, e); err != nil {
+			return err
+		}
+
+		exposure, err := s.devices.Camera.ExposeContext(ctx, e.Exposure, true, nil)
+
+		if err != nil {
+			return err
+		}
+
+		header, err := fits.NewHeaderFromDevices(ctx, exposure, s.devices.Devices)
+
+		// A device which cannot be read only omits its keywords, so the frame is saved regardless:
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if e.Target != nil && e.Target.Name != "" {
+			header.Set("OBJECT", e.Target.Name, "name of the target")
+		}
+
+		frame := s.Progress().Completed[i] + 1
+
+		name := fileName(i, e, frame)
+
+		if err := fits.WriteFile(filepath.Join(s.dir, name), exposure.Image, header, fits.Options{}); err != nil {
+			return err
+		}
+
+		p, err := s.saveProgress(i, name)
+
+		if err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(Status{
+				Entry:     i,
+				Frame:     frame,
+				Count:     e.Count,
+				Remaining: len(plan.schedule(p.Completed)),
+				Path:      filepath.Join(s.dir, name),
+				Exposure:  exposure,
+			})
+		}
+	}
+
+	return nil
+}
+
+/*
+checkSafety()
+
+@returns ErrUnsafe if the safety monitor reports unsafe conditions.
+*/
+func (r *run) checkSafety(ctx context.Context) error {
+	if r.devices.SafetyMonitor == nil {
+		return nil
+	}
+
+	safe, err := r.devices.SafetyMonitor.IsSafeContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if !safe {
+		return ErrUnsafe
+	}
+
+	return nil
+}
+
+/*
+prepare()
+
+Slews to the target of the entry, if it differs from the current target, or dithers if the entry is due, then sets
+the filter and camera settings of the entry, and refocuses if the filter has changed or the temperature drifted.
+*/
+func (r *run) prepare(ctx context.Context, i int, e Entry) error {
+	completed := r.Progress().Completed[i]
+
+	switch {
+	case e.Target != nil && (r.target == nil || *r.target != *e.Target):
+		if err := r.slew(ctx, e.Target.RightAscension, e.Target.Declination); err != nil {
+			return err
+		}
+
+		r.target = e.Target
+	case e.Target != nil && e.Dither > 0 && completed > 0 && completed%e.Dither == 0:
+		if err := r.dither(ctx, *e.Target); err != nil {
+			return err
+		}
+	}
+
+	refocus := false
+
+	if e.Filter != "" && e.Filter != r.filter {
+		refocus = r.filter != ""
+
+		if err := r.setFilter(ctx, e.Filter); err != nil {
+			return err
+		}
+
+		r.filter = e.Filter
+	}
+
+	if err := r.setCamera(ctx, e); err != nil {
+		return err
+	}
+
+	if refocus {
+		return r.refocus(ctx, RefocusFilterChange)
+	}
+
+	if r.devices.Focuser == nil || r.opts.Refocus == nil {
+		return nil
+	}
+
+	temperature, err := r.devices.Focuser.GetTemperatureContext(ctx)
+
+	if errors.Is(err, alpacago.ErrNotImplemented) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if math.IsNaN(r.temperature) {
+		r.temperature = temperature
+	}
+
+	if math.Abs(temperature-r.temperature) >= r.opts.TemperatureDrift {
+		return r.refocus(ctx, RefocusTemperatureDrift)
+	}
+
+	return nil
+}
+
+/*
+refocus()
+
+Calls the refocus hook, then records the focuser temperature from which drift is measured.
+*/
+func (r *run) refocus(ctx context.Context, reason RefocusReason) error {
+	if r.opts.Refocus == nil {
+		return nil
+	}
+
+	if err := r.opts.Refocus(ctx, reason); err != nil {
+		return fmt.Errorf("sequence: refocus after %s: %w", reason, err)
+	}
+
+	r.temperature = math.NaN()
+
+	if r.devices.Focuser == nil {
+		return nil
+	}
+
+	temperature, err := r.devices.Focuser.GetTemperatureContext(ctx)
+
+	if err == nil {
+		r.temperature = temperature
+	} else if !errors.Is(err, alpacago.ErrNotImplemented) {
+		return err
+	}
+
+	return nil
+}
+
+/*
+slew()
+
+Slews the telescope to the coordinates, in degrees, and waits for it to settle.
+*/
+func (r *run) slew(ctx context.Context, ra float64, dec float64) error {
+	telescope := r.devices.Telescope
+
+	if err := telescope.SetSlewToCoordinatesAsyncContext(ctx, ra, dec); err != nil {
+		return err
+	}
+
+	if err := r.waitFor(ctx, func() (bool, error) {
+		slewing, err := telescope.IsSlewingContext(ctx)
+		return !slewing, err
+	}); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(r.opts.Settle):
+		return nil
+	}
+}
+
+/*
+dither()
+
+Slews the telescope to a random position within the dither radius of the target.
+*/
+func (r *run) dither(ctx context.Context, target Target) error {
+	radius := r.opts.DitherRadius / 3600 * math.Sqrt(r.opts.Rand.Float64())
+
+	angle := 2 * math.Pi * r.opts.Rand.Float64()
+
+	dec := math.Max(math.Min(target.Declination+radius*math.Sin(angle), 90), -90)
+
+	// The offset in right ascension is widened by the convergence of the hour circles, up to a limit at the poles:
+	ra := math.Mod(target.RightAscension+radius*math.Cos(angle)/math.Max(math.Cos(dec*math.Pi/180), 0.01)+360, 360)
+
+	return r.slew(ctx, ra, dec)
+}
+
+/*
+setFilter()
+
+Moves the filter wheel to the position of the named filter, and waits for it to arrive.
+*/
+func (r *run) setFilter(ctx context.Context, filter string) error {
+	filterWheel := r.devices.FilterWheel
+
+	names, err := filterWheel.GetNamesContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	position := int32(-1)
+
+	for i, name := range names {
+		if name == filter {
+			position = int32(i)
+		}
+	}
+
+	if position < 0 {
+		return fmt.Errorf("sequence: the filter wheel has no %q filter, only %q", filter, names)
+	}
+
+	if err := filterWheel.SetPositionContext(ctx, position); err != nil {
+		return err
+	}
+
+	// The position is -1 whilst the wheel is moving:
+	return r.waitFor(ctx, func() (bool, error) {
+		p, err := filterWheel.GetPositionContext(ctx)
+		return p == position, err
+	})
+}
+
+/*
+setCamera()
+
+Sets the binning, gain and offset of the entry, where they differ from those set by the last entry.
+*/
+func (r *run) setCamera(ctx context.Context, e Entry) error {
+	camera := r.devices.Camera
+
+	if e.BinX > 0 && e.BinX != r.binX {
+		if err := camera.SetBinXContext(ctx, e.BinX); err != nil {
+			return err
+		}
+
+		r.binX = e.BinX
+	}
+
+	if e.BinY > 0 && e.BinY != r.binY {
+		if err := camera.SetBinYContext(ctx, e.BinY); err != nil {
+			return err
+		}
+
+		r.binY = e.BinY
+	}
+
+	if e.Gain != nil && (r.gain == nil || *e.Gain != *r.gain) {
+		if err := camera.SetGainContext(ctx, *e.Gain); err != nil {
+			return err
+		}
+
+		r.gain = e.Gain
+	}
+
+	if e.Offset != nil && (r.offset == nil || *e.Offset != *r.offset) {
+		if err := camera.SetOffsetContext(ctx, *e.Offset); err != nil {
+			return err
+		}
+
+		r.offset = e.Offset
+	}
+
+	return nil
+}
+
+func (r *run) waitFor(ctx context.Context, done func() (bool, error)) error {
+	for {
+		ok, err := done()
+
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.opts.PollInterval):
+		}
+	}
+}
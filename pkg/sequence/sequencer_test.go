@@ -0,0 +1,338 @@
+package sequence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/fits"
+)
+
+/*
+observatory
+
+A simulated camera, telescope, filter wheel, focuser and safety monitor, which records the commands sent to them.
+Any property it does not simulate is not implemented.
+*/
+type observatory struct {
+	mu          sync.Mutex
+	position    int32
+	moving      int32
+	slewing     bool
+	temperature float64
+	// The number of frames after which conditions become unsafe, or zero for always safe:
+	unsafeAfter int
+	frames      int
+	events      []string
+}
+
+func (o *observatory) respond(device string, name string, r *http.Request) alpacatest.Response {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	value := "null"
+
+	errorNumber := 0
+
+	put := r.Method == http.MethodPut
+
+	switch device + "/" + name {
+	case "camera/startexposure":
+		o.frames++
+		o.events = append(o.events, "expose "+r.FormValue("Duration"))
+	case "camera/camerastate":
+		value = "0"
+	case "camera/imageready":
+		value = "true"
+	case "camera/imagearray":
+		return alpacatest.ImageArray(fmt.Sprintf("[[%d,1],[2,3]]", o.frames))
+	case "camera/binx", "camera/biny", "camera/gain", "camera/offset":
+		if !put {
+			errorNumber = 0x400
+			break
+		}
+
+		for _, key := range []string{"BinX", "BinY", "Gain", "Offset"} {
+			if v := r.FormValue(key); v != "" {
+				o.events = append(o.events, name+" "+v)
+			}
+		}
+	case "telescope/tracking":
+		if !put {
+			value = "true"
+		}
+	case "telescope/slewtocoordinatesasync":
+		o.slewing = true
+		o.events = append(o.events, "slew "+r.FormValue("RightAscension")+" "+r.FormValue("Declination"))
+	case "telescope/slewing":
+		// The slew completes once polled:
+		value = strconv.FormatBool(o.slewing)
+		o.slewing = false
+	case "filterwheel/names":
+		value = `["L","R","G","B"]`
+	case "filterwheel/position":
+		if put {
+			n, _ := strconv.Atoi(r.FormValue("Position"))
+			o.position, o.moving = -1, int32(n)
+			o.events = append(o.events, "filter "+r.FormValue("Position"))
+			break
+		}
+
+		value = fmt.Sprint(o.position)
+		o.position = o.moving
+	case "focuser/temperature":
+		value = fmt.Sprint(o.temperature)
+	case "safetymonitor/issafe":
+		value = strconv.FormatBool(o.unsafeAfter == 0 || o.frames < o.unsafeAfter)
+	default:
+		errorNumber = 0x400
+	}
+
+	return alpacatest.Response{Value: value, ErrorNumber: errorNumber}
+}
+
+func (o *observatory) takeEvents() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events := o.events
+
+	o.events = nil
+
+	return events
+}
+
+func newObservatory(t *testing.T) (*observatory, Devices) {
+	o := &observatory{}
+
+	ts := alpacatest.NewServer(t, nil)
+
+	for _, device := range []string{"camera", "telescope", "filterwheel", "focuser", "safetymonitor"} {
+		device := device
+
+		ts.HandleFunc(device+"/0/", func(name string, r *http.Request) alpacatest.Response {
+			return o.respond(device, name, r)
+		})
+	}
+
+	devices := Devices{
+		Devices: fits.Devices{
+			Camera:      alpacago.NewCameraWithOptions(ts.URL, 0),
+			Telescope:   alpacago.NewTelescopeWithOptions(ts.URL, 0, alpacago.NotTracking),
+			FilterWheel: alpacago.NewFilterWheelWithOptions(ts.URL, 0),
+			Focuser:     alpacago.NewFocuserWithOptions(ts.URL, 0),
+		},
+		SafetyMonitor: alpacago.NewSafetyMonitorWithOptions(ts.URL, 0),
+	}
+
+	return o, devices
+}
+
+func TestSequencerRunAndResume(t *testing.T) {
+	o, devices := newObservatory(t)
+
+	o.unsafeAfter = 3
+
+	gain := int32(100)
+
+	plan := Plan{
+		Name:  "Andromeda",
+		Order: Interleaved,
+		Entries: []Entry{
+			{Target: m31, Filter: "L", Exposure: 60, Count: 3, BinX: 1, BinY: 1, Gain: &gain, Dither: 2},
+			{Target: m31, Filter: "R", Exposure: 120, Count: 2, BinX: 2, BinY: 2, Gain: &gain},
+		},
+	}
+
+	dir := t.TempDir()
+
+	refocused := []RefocusReason{}
+
+	opts := Options{
+		Settle:       time.Millisecond,
+		PollInterval: time.Millisecond,
+		Rand:         rand.New(rand.NewSource(1)),
+		Refocus: func(ctx context.Context, reason RefocusReason) error {
+			refocused = append(refocused, reason)
+			return nil
+		},
+	}
+
+	s, err := NewSequencer(plan, dir, devices, opts)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	statuses := []Status{}
+
+	err = s.Run(context.Background(), func(status Status) {
+		statuses = append(statuses, status)
+	})
+
+	if !errors.Is(err, ErrUnsafe) {
+		t.Fatalf("got %v, wanted %v", err, ErrUnsafe)
+	}
+
+	want := []string{
+		"slew 0.712313 41.268700", "filter 0", "binx 1", "biny 1", "gain 100", "expose 60.000000",
+		"filter 1", "binx 2", "biny 2", "expose 120.000000",
+		"filter 0", "binx 1", "biny 1", "expose 60.000000",
+	}
+
+	if got := o.takeEvents(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+
+	if len(statuses) != 3 || statuses[2].Entry != 0 || statuses[2].Frame != 2 || statuses[2].Remaining != 2 {
+		t.Errorf("got %+v, wanted the second L frame to be the last before the unsafe conditions", statuses)
+	}
+
+	if fmt.Sprint(refocused) != "[filter change filter change]" {
+		t.Errorf("got %v, wanted a refocus after each filter change", refocused)
+	}
+
+	// The sequence is resumed by a new sequencer, as after a crash, once conditions are safe and the temperature drifts:
+	o.unsafeAfter, o.temperature, refocused = 0, -2, nil
+
+	s, err = NewSequencer(plan, dir, devices, opts)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if got := s.Remaining(); got != 2 {
+		t.Errorf("got %d frames remaining, wanted 2", got)
+	}
+
+	if err := s.Run(context.Background(), nil); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	events := o.takeEvents()
+
+	// The interrupted round is completed by the R frame, then the third L frame is dithered about the target:
+	if len(events) != 11 || events[0] != want[0] || events[5] != "expose 120.000000" || !strings.HasPrefix(events[6], "slew 0.71") || events[6] == want[0] || events[10] != "expose 60.000000" {
+		t.Errorf("got %v, wanted an R frame then a dithered L frame", events)
+	}
+
+	if fmt.Sprint(refocused) != "[filter change]" {
+		t.Errorf("got %v, wanted a refocus after the filter change", refocused)
+	}
+
+	p := s.Progress()
+
+	if fmt.Sprint(p.Completed) != "[3 2]" {
+		t.Errorf("got %v, wanted every frame to be completed", p.Completed)
+	}
+
+	wantFrames := "[M31_L_60s_01_0001.fits M31_R_120s_02_0001.fits M31_L_60s_01_0002.fits M31_R_120s_02_0002.fits M31_L_60s_01_0003.fits]"
+
+	if fmt.Sprint(p.Frames) != wantFrames {
+		t.Errorf("got %v, wanted %v", p.Frames, wantFrames)
+	}
+
+	for _, name := range p.Frames {
+		_, header, err := fits.ReadFile(filepath.Join(dir, name))
+
+		if err != nil {
+			t.Fatalf("got %q", err)
+		}
+
+		if object, _ := header.GetString("OBJECT"); object != "M31" {
+			t.Errorf("got %q, wanted M31 in the header of %s", object, name)
+		}
+	}
+
+	if err := s.Run(context.Background(), nil); err != nil || len(o.takeEvents()) != 0 {
+		t.Errorf("got %v, wanted a completed sequence to take no frames", err)
+	}
+}
+
+func TestSequencerTemperatureDrift(t *testing.T) {
+	o, devices := newObservatory(t)
+
+	plan := Plan{Entries: []Entry{{Exposure: 1, Count: 3}}}
+
+	refocused := 0
+
+	s, err := NewSequencer(plan, t.TempDir(), devices, Options{
+		Refocus: func(ctx context.Context, reason RefocusReason) error {
+			if reason != RefocusTemperatureDrift {
+				t.Errorf("got %v, wanted %v", reason, RefocusTemperatureDrift)
+			}
+
+			refocused++
+
+			return nil
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	// The focuser cools by 0.6°C during each frame:
+	err = s.Run(context.Background(), func(status Status) {
+		o.mu.Lock()
+		o.temperature -= 0.6
+		o.mu.Unlock()
+	})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if refocused != 1 {
+		t.Errorf("got %d refocuses, wanted 1 before the third frame", refocused)
+	}
+}
+
+func TestNewSequencerPlanChanged(t *testing.T) {
+	o, devices := newObservatory(t)
+
+	dir := t.TempDir()
+
+	plan := Plan{Entries: []Entry{{Exposure: 1, Count: 2}}}
+
+	s, _ := NewSequencer(plan, dir, devices, Options{})
+
+	o.unsafeAfter = 1
+
+	if err := s.Run(context.Background(), nil); !errors.Is(err, ErrUnsafe) {
+		t.Fatalf("got %v, wanted %v", err, ErrUnsafe)
+	}
+
+	plan.Entries[0].Count = 3
+
+	if _, err := NewSequencer(plan, dir, devices, Options{}); !errors.Is(err, ErrPlanChanged) {
+		t.Errorf("got %v, wanted %v", err, ErrPlanChanged)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, PROGRESS_FILE_NAME)); err != nil {
+		t.Errorf("got %q, wanted the progress to be stored", err)
+	}
+}
+
+func TestNewSequencerMissingDevices(t *testing.T) {
+	_, devices := newObservatory(t)
+
+	devices.FilterWheel = nil
+
+	plan := Plan{Entries: []Entry{{Filter: "L", Exposure: 1, Count: 1}}}
+
+	if _, err := NewSequencer(plan, t.TempDir(), devices, Options{}); err == nil {
+		t.Errorf("got nil, wanted an error for a filter without a filter wheel")
+	}
+}
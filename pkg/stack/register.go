@@ -0,0 +1,214 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/observerly/alpacago/pkg/stars"
+)
+
+// ErrRegistrationFailed is returned by Register() when too few stars of the frame match those of the reference.
+var ErrRegistrationFailed = errors.New("stack: the frame could not be registered")
+
+/*
+Transform
+
+A rotation by Angle, in radians, followed by a translation by (DX, DY), in pixels, which maps a position in the
+reference to the same position in a frame.
+*/
+type Transform struct {
+	Angle float64
+	DX    float64
+	DY    float64
+}
+
+/*
+Apply()
+
+@returns the position in the frame of the position (x, y) in the reference.
+*/
+func (t Transform) Apply(x float64, y float64) (float64, float64) {
+	sin, cos := math.Sincos(t.Angle)
+
+	return cos*x - sin*y + t.DX, sin*x + cos*y + t.DY
+}
+
+/*
+Registration
+
+The result of Register(), where Matches is the number of reference stars matched in the frame, and Residual the
+root mean square distance in pixels between the matched stars of the frame and the transformed reference stars.
+*/
+type Registration struct {
+	Transform Transform
+	Matches   int
+	Residual  float64
+}
+
+/*
+Register()
+
+Finds the rotation and translation which maps the stars of the reference onto those of the frame. Every pair of
+reference stars is tried against each pair of frame stars separated by the same distance, so that the transform
+is found regardless of the rotation or translation, and the transform matching the most stars is refined by least
+squares. Scale is not fitted, as the frames of a live stack share the optics of the reference.
+
+@param reference []stars.Star (the stars of the reference, the brightest first, as returned by stars.Detect())
+@param frame []stars.Star (the stars of the frame, the brightest first)
+@param tolerance float64 (the greatest distance between a transformed reference star and its match, in pixels)
+@param minMatches int (the least number of matched stars, which must be at least 3)
+@returns the registration, or ErrRegistrationFailed if fewer than minMatches stars match.
+*/
+func Register(reference []stars.Star, frame []stars.Star, tolerance float64, minMatches int) (Registration, error) {
+	minMatches = max(minMatches, 3)
+
+	if len(reference) < minMatches || len(frame) < minMatches {
+		return Registration{}, fmt.Errorf("%w: %d reference and %d frame stars, wanted at least %d", ErrRegistrationFailed, len(reference), len(frame), minMatches)
+	}
+
+	best, bestMatches := Transform{}, 0
+
+	// Pairs closer than a few tolerances give a poorly constrained angle:
+	minSeparation := 5 * tolerance
+
+	for i := 0; i < len(reference) && bestMatches < len(reference); i++ {
+		for j := i + 1; j < len(reference); j++ {
+			rx, ry := reference[j].X-reference[i].X, reference[j].Y-reference[i].Y
+
+			d := math.Hypot(rx, ry)
+
+			if d < minSeparation {
+				continue
+			}
+
+			for k := range frame {
+				for l := range frame {
+					if k == l {
+						continue
+					}
+
+					fx, fy := frame[l].X-frame[k].X, frame[l].Y-frame[k].Y
+
+					if math.Abs(math.Hypot(fx, fy)-d) > tolerance {
+						continue
+					}
+
+					angle := math.Atan2(fy, fx) - math.Atan2(ry, rx)
+
+					sin, cos := math.Sincos(angle)
+
+					t := Transform{
+						Angle: angle,
+						DX:    frame[k].X - (cos*reference[i].X - sin*reference[i].Y),
+						DY:    frame[k].Y - (sin*reference[i].X + cos*reference[i].Y),
+					}
+
+					if matches := len(match(reference, frame, t, tolerance)); matches > bestMatches {
+						best, bestMatches = t, matches
+					}
+				}
+			}
+		}
+	}
+
+	if bestMatches < minMatches {
+		return Registration{}, fmt.Errorf("%w: %d stars matched, wanted at least %d", ErrRegistrationFailed, bestMatches, minMatches)
+	}
+
+	pairs := match(reference, frame, best, tolerance)
+
+	t := fit(pairs)
+
+	// The refined transform may match stars at the edge of the tolerance differently:
+	if refined := match(reference, frame, t, tolerance); len(refined) >= len(pairs) {
+		pairs = refined
+		t = fit(pairs)
+	}
+
+	var sum float64
+
+	for _, p := range pairs {
+		x, y := t.Apply(p.reference.X, p.reference.Y)
+
+		sum += (x-p.frame.X)*(x-p.frame.X) + (y-p.frame.Y)*(y-p.frame.Y)
+	}
+
+	return Registration{
+		Transform: t,
+		Matches:   len(pairs),
+		Residual:  math.Sqrt(sum / float64(len(pairs))),
+	}, nil
+}
+
+type pair struct {
+	reference stars.Star
+	frame     stars.Star
+}
+
+/*
+match()
+
+@returns each reference star paired with the nearest frame star within the tolerance of its transformed position.
+*/
+func match(reference []stars.Star, frame []stars.Star, t Transform, tolerance float64) []pair {
+	pairs := []pair{}
+
+	for _, r := range reference {
+		x, y := t.Apply(r.X, r.Y)
+
+		nearest, distance := -1, tolerance
+
+		for k, f := range frame {
+			if d := math.Hypot(f.X-x, f.Y-y); d <= distance {
+				nearest, distance = k, d
+			}
+		}
+
+		if nearest >= 0 {
+			pairs = append(pairs, pair{reference: r, frame: frame[nearest]})
+		}
+	}
+
+	return pairs
+}
+
+/*
+fit()
+
+@returns the least squares rotation and translation of the reference stars of the pairs onto their frame stars.
+*/
+func fit(pairs []pair) Transform {
+	var rx, ry, fx, fy float64
+
+	for _, p := range pairs {
+		rx += p.reference.X
+		ry += p.reference.Y
+		fx += p.frame.X
+		fy += p.frame.Y
+	}
+
+	n := float64(len(pairs))
+
+	rx, ry, fx, fy = rx/n, ry/n, fx/n, fy/n
+
+	var dot, cross float64
+
+	for _, p := range pairs {
+		ax, ay := p.reference.X-rx, p.reference.Y-ry
+		bx, by := p.frame.X-fx, p.frame.Y-fy
+
+		dot += ax*bx + ay*by
+		cross += ax*by - ay*bx
+	}
+
+	angle := math.Atan2(cross, dot)
+
+	sin, cos := math.Sincos(angle)
+
+	return Transform{
+		Angle: angle,
+		DX:    fx - (cos*rx - sin*ry),
+		DY:    fy - (sin*rx + cos*ry),
+	}
+}
@@ -0,0 +1,72 @@
+package stack
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/stars"
+)
+
+// newStars returns n stars scattered over a width by height field, the brightest first:
+func newStars(n int, width float64, height float64, seed int64) []stars.Star {
+	r := rand.New(rand.NewSource(seed))
+
+	s := make([]stars.Star, n)
+
+	for i := range s {
+		s[i] = stars.Star{X: r.Float64() * width, Y: r.Float64() * height, Flux: float64(n - i)}
+	}
+
+	return s
+}
+
+func TestTransformApply(t *testing.T) {
+	x, y := Transform{Angle: math.Pi / 2, DX: 10, DY: 20}.Apply(1, 0)
+
+	if math.Abs(x-10) > 1e-9 || math.Abs(y-21) > 1e-9 {
+		t.Errorf("got (%v, %v), wanted (10, 21)", x, y)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	reference := newStars(30, 500, 500, 1)
+
+	want := Transform{Angle: 0.05, DX: 12.5, DY: -7.25}
+
+	r := rand.New(rand.NewSource(2))
+
+	frame := []stars.Star{}
+
+	// The frame loses five reference stars, and gains five that are not in the reference:
+	for _, s := range reference[5:] {
+		x, y := want.Apply(s.X, s.Y)
+
+		frame = append(frame, stars.Star{X: x + r.NormFloat64()*0.1, Y: y + r.NormFloat64()*0.1, Flux: s.Flux})
+	}
+
+	frame = append(frame, newStars(5, 500, 500, 3)...)
+
+	got, err := Register(reference, frame, 2, 6)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if math.Abs(got.Transform.Angle-want.Angle) > 1e-3 || math.Abs(got.Transform.DX-want.DX) > 0.2 || math.Abs(got.Transform.DY-want.DY) > 0.2 {
+		t.Errorf("got %+v, wanted %+v", got.Transform, want)
+	}
+
+	if got.Matches != 25 || got.Residual > 0.3 {
+		t.Errorf("got %d matches with a residual of %v, wanted 25 matches with a residual of about 0.14", got.Matches, got.Residual)
+	}
+}
+
+func TestRegisterFailed(t *testing.T) {
+	_, err := Register(newStars(30, 500, 500, 1), newStars(30, 500, 500, 4), 1, 6)
+
+	if !errors.Is(err, ErrRegistrationFailed) {
+		t.Errorf("got %v, wanted %v", err, ErrRegistrationFailed)
+	}
+}
@@ -0,0 +1,417 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/calibration"
+	"github.com/observerly/alpacago/pkg/debayer"
+	"github.com/observerly/alpacago/pkg/stars"
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+// ErrTooFewStars is the reason a frame is rejected when it has fewer stars than Options.MinStars, e.g., due to cloud.
+var ErrTooFewStars = errors.New("stack: too few stars")
+
+// ErrPoorQuality is the reason a frame is rejected when its stars exceed Options.MaxHFR or Options.MaxEccentricity.
+var ErrPoorQuality = errors.New("stack: poor star quality")
+
+type Method int
+
+const (
+	// The running mean of every frame:
+	Average Method = iota
+	// The running mean, excluding pixels more than Kappa standard deviations from the mean of the previous frames,
+	// where the standard deviation is at least the noise of the frame:
+	SigmaClip
+)
+
+func (m Method) String() string {
+	switch m {
+	case Average:
+		return "average"
+	case SigmaClip:
+		return "sigma clip"
+	default:
+		return fmt.Sprintf("Unknown Method value: %d", m)
+	}
+}
+
+/*
+Options
+
+The options of a Stacker. A zero value for any option selects its default.
+*/
+type Options struct {
+	// The method of stacking (default Average):
+	Method Method
+	// The number of standard deviations beyond which a pixel is clipped (default 3):
+	Kappa float64
+	// The number of frames stacked before pixels are clipped (default 3):
+	MinClipFrames int
+	// The options of the star detection of each frame:
+	Stars stars.Options
+	// The number of the brightest stars used to register each frame (default 25):
+	RegistrationStars int
+	// The greatest distance between a star of the frame and its reference star, in pixels (default 2):
+	Tolerance float64
+	// The least number of stars of a frame which must match the reference (default 6):
+	MinMatches int
+	// The least number of stars of a frame (default 10):
+	MinStars int
+	// The greatest median HFR of the stars of a frame, in pixels, or zero for no limit:
+	MaxHFR float64
+	// The greatest median eccentricity of the stars of a frame, e.g., 0.6 to reject trailed frames, or zero for no limit:
+	MaxEccentricity float64
+	// The library with which frames are calibrated, see Stacker.Add(), may be nil:
+	Library *calibration.Library
+}
+
+func (o Options) withDefaults() Options {
+	if o.Kappa == 0 {
+		o.Kappa = 3
+	}
+
+	if o.MinClipFrames == 0 {
+		o.MinClipFrames = 3
+	}
+
+	if o.RegistrationStars == 0 {
+		o.RegistrationStars = 25
+	}
+
+	if o.Tolerance == 0 {
+		o.Tolerance = 2
+	}
+
+	if o.MinMatches == 0 {
+		o.MinMatches = 6
+	}
+
+	if o.MinStars == 0 {
+		o.MinStars = 10
+	}
+
+	return o
+}
+
+/*
+Frame
+
+The result of adding a frame to a Stacker. A rejected frame has the Reason for its rejection, i.e., ErrTooFewStars,
+ErrPoorQuality or ErrRegistrationFailed, and is not stacked. The first accepted frame is the reference, with an
+identity transform.
+*/
+type Frame struct {
+	Accepted     bool
+	Reason       error
+	Stars        int
+	HFR          float64
+	Eccentricity float64
+	Registration Registration
+	Applied      calibration.Applied
+}
+
+/*
+Stacker
+
+A live stack of frames, each of which is calibrated, checked for quality, registered to the first accepted frame
+and added to a running mean, so that the stacked image is available after every frame.
+*/
+type Stacker struct {
+	mu        sync.Mutex
+	opts      Options
+	width     int
+	height    int
+	planes    int
+	reference []stars.Star
+	mean      []float64
+	m2        []float64
+	count     []int32
+	accepted  int
+	rejected  int
+}
+
+/*
+NewStacker()
+
+@returns an empty stack, whose dimensions are those of the first frame.
+*/
+func NewStacker(opts Options) *Stacker {
+	return &Stacker{
+		opts: opts.withDefaults(),
+	}
+}
+
+/*
+Add()
+
+Calibrates the frame with the masters of the library of the options, if both it and the key are non-nil, detects
+its stars, and rejects it if there are too few or they are of poor quality. The first accepted frame becomes the
+reference, and later frames are registered to it and resampled onto it with bilinear interpolation.
+
+A single plane frame is stacked as a monochrome image, so the mosaic of a colour camera must be debayered first,
+as resampling it would mix the colours of its filter array. AddExposure() debayers the mosaic after calibrating
+it, with the pattern of the camera, see debayer.ForCamera().
+
+@param image *alpacago.Image (the frame, which must have the dimensions of the first frame)
+@param key *calibration.Key (the key of the frame, or nil to not calibrate it)
+@returns the result of the frame, or an error if the frame cannot be stacked regardless of its quality.
+*/
+func (s *Stacker) Add(image *alpacago.Image, key *calibration.Key) (*Frame, error) {
+	return s.addFrame(image, key, nil)
+}
+
+/*
+addFrame()
+
+Adds the frame, see Add(), debayering it with the function, if it is non-nil, after it is calibrated, so that
+the masters of the library, which are mosaics, are applied before the colours are interpolated.
+*/
+func (s *Stacker) addFrame(image *alpacago.Image, key *calibration.Key, debayerFrame func(*alpacago.Image) (*alpacago.Image, error)) (*Frame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frame := &Frame{}
+
+	if s.opts.Library != nil && key != nil {
+		calibrated, applied, err := s.opts.Library.Calibrate(image, *key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		image, frame.Applied = calibrated, applied
+	}
+
+	if debayerFrame != nil {
+		debayered, err := debayerFrame(image)
+
+		if err != nil {
+			return nil, err
+		}
+
+		image = debayered
+	}
+
+	if s.mean != nil && (image.Width != s.width || image.Height != s.height || image.Planes != s.planes) {
+		return nil, fmt.Errorf("stack: the %dx%dx%d frame does not match the %dx%dx%d stack", image.Width, image.Height, image.Planes, s.width, s.height, s.planes)
+	}
+
+	detected, err := stars.Detect(image, s.opts.Stars)
+
+	if err != nil {
+		return nil, err
+	}
+
+	summary := stars.Summarise(detected)
+
+	frame.Stars, frame.HFR, frame.Eccentricity = len(detected), summary.HFR, summary.Eccentricity
+
+	switch {
+	case len(detected) < s.opts.MinStars:
+		frame.Reason = fmt.Errorf("%w: %d stars, wanted at least %d", ErrTooFewStars, len(detected), s.opts.MinStars)
+	case s.opts.MaxHFR > 0 && !(summary.HFR <= s.opts.MaxHFR):
+		frame.Reason = fmt.Errorf("%w: HFR of %.2f pixels, wanted at most %.2f", ErrPoorQuality, summary.HFR, s.opts.MaxHFR)
+	case s.opts.MaxEccentricity > 0 && !(summary.Eccentricity <= s.opts.MaxEccentricity):
+		frame.Reason = fmt.Errorf("%w: eccentricity of %.2f, wanted at most %.2f", ErrPoorQuality, summary.Eccentricity, s.opts.MaxEccentricity)
+	}
+
+	if frame.Reason != nil {
+		s.rejected++
+		return frame, nil
+	}
+
+	if len(detected) > s.opts.RegistrationStars {
+		detected = detected[:s.opts.RegistrationStars]
+	}
+
+	if s.mean == nil {
+		s.start(image, detected)
+	} else {
+		frame.Registration, frame.Reason = Register(s.reference, detected, s.opts.Tolerance, s.opts.MinMatches)
+
+		if frame.Reason != nil {
+			s.rejected++
+			return frame, nil
+		}
+	}
+
+	s.accumulate(image, frame.Registration.Transform)
+
+	s.accepted++
+
+	frame.Accepted = true
+
+	return frame, nil
+}
+
+/*
+AddExposure()
+
+Adds the image of the exposure, calibrated with the masters selected from the settings of the camera and filter
+wheel read immediately after the exposure if the options have a library, see Add(). The mosaic of a colour camera
+is debayered with bilinear interpolation after it is calibrated, unless it is binned, in which case it is stacked
+as a monochrome image.
+*/
+func (s *Stacker) AddExposure(ctx context.Context, exposure *alpacago.Exposure, camera *alpacago.Camera, filterWheel *alpacago.FilterWheel) (*Frame, error) {
+	var debayerFrame func(*alpacago.Image) (*alpacago.Image, error)
+
+	if exposure.Image.Planes == 1 {
+		debayerFrame = func(image *alpacago.Image) (*alpacago.Image, error) {
+			debayered, err := debayer.ForCamera(ctx, camera, image, debayer.Bilinear)
+
+			if errors.Is(err, debayer.ErrUnsupportedSensor) || errors.Is(err, debayer.ErrBinned) {
+				return image, nil
+			}
+
+			return debayered, err
+		}
+	}
+
+	if s.opts.Library == nil {
+		return s.addFrame(exposure.Image, nil, debayerFrame)
+	}
+
+	key, err := calibration.NewKeyForDevices(ctx, calibration.Light, exposure.Duration, camera, filterWheel)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.addFrame(exposure.Image, &key, debayerFrame)
+}
+
+func (s *Stacker) start(image *alpacago.Image, reference []stars.Star) {
+	s.width, s.height, s.planes = image.Width, image.Height, image.Planes
+
+	s.reference = reference
+
+	n := len(image.Pix)
+
+	s.mean = make([]float64, n)
+	s.count = make([]int32, n)
+
+	if s.opts.Method == SigmaClip {
+		s.m2 = make([]float64, n)
+	}
+}
+
+/*
+accumulate()
+
+Adds each pixel of the stack, resampled from the frame at its transformed position, to the running mean, using
+Welford's method so that the variance is also available for sigma clipping. Pixels which fall outside of the
+frame are not added.
+
+The standard deviation of each pixel is floored at the noise of its plane of the frame, estimated from the MAD
+of the plane, so that a pixel whose first few frames agree closely, or are saturated, does not clip every later
+frame.
+*/
+func (s *Stacker) accumulate(image *alpacago.Image, t Transform) {
+	w, h := s.width, s.height
+
+	noise := make([]float64, s.planes)
+
+	if s.opts.Method == SigmaClip {
+		for p := range noise {
+			noise[p] = stats.MAD_TO_SIGMA * stats.Compute(image.Plane(p), 0).MAD
+		}
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			fx, fy := t.Apply(float64(x), float64(y))
+
+			if fx < 0 || fy < 0 || fx > float64(w-1) || fy > float64(h-1) {
+				continue
+			}
+
+			x0, y0 := min(int(fx), w-2), min(int(fy), h-2)
+
+			ax, ay := fx-float64(x0), fy-float64(y0)
+
+			for p := 0; p < s.planes; p++ {
+				i := (p*h+y0)*w + x0
+
+				v := (1-ay)*((1-ax)*image.Pix[i]+ax*image.Pix[i+1]) + ay*((1-ax)*image.Pix[i+w]+ax*image.Pix[i+w+1])
+
+				s.add((p*h+y)*w+x, v, noise[p])
+			}
+		}
+	}
+}
+
+func (s *Stacker) add(i int, v float64, noise float64) {
+	n := s.count[i]
+
+	if s.opts.Method == SigmaClip && int(n) >= s.opts.MinClipFrames {
+		sigma := max(math.Sqrt(s.m2[i]/float64(n)), noise)
+
+		if math.Abs(v-s.mean[i]) > s.opts.Kappa*sigma {
+			return
+		}
+	}
+
+	n++
+
+	delta := v - s.mean[i]
+
+	s.mean[i] += delta / float64(n)
+
+	if s.m2 != nil {
+		s.m2[i] += delta * (v - s.mean[i])
+	}
+
+	s.count[i] = n
+}
+
+/*
+Image()
+
+@returns a copy of the current stack, as a single precision image in the orientation of the reference, or nil if
+no frame has been accepted. Pixels to which no frame has contributed are zero.
+*/
+func (s *Stacker) Image() *alpacago.Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mean == nil {
+		return nil
+	}
+
+	image := alpacago.NewImage(alpacago.ImageElementSingle, s.width, s.height, s.planes)
+
+	copy(image.Pix, s.mean)
+
+	return image
+}
+
+/*
+Count()
+
+@returns the number of frames accepted into, and rejected from, the stack.
+*/
+func (s *Stacker) Count() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.accepted, s.rejected
+}
+
+/*
+Reset()
+
+Empties the stack, so that the next accepted frame becomes the reference.
+*/
+func (s *Stacker) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reference, s.mean, s.m2, s.count = nil, nil, nil, nil
+	s.accepted, s.rejected = 0, 0
+}
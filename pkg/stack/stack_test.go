@@ -0,0 +1,293 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/calibration"
+	"github.com/observerly/alpacago/pkg/stars"
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+// The stars of the test field, in the coordinates of the reference:
+var field = newStars(25, 140, 140, 5)
+
+/*
+newFrame()
+
+@returns a 160x160 frame of the stars of the field, placed by the transform from the reference, with Gaussian
+profiles of standard deviations sx and sy, on a sky of 1000 ADU with noise of 5 ADU.
+*/
+func newFrame(t Transform, sx float64, sy float64, seed int64) *alpacago.Image {
+	r := rand.New(rand.NewSource(seed))
+
+	image := alpacago.NewImage(alpacago.ImageElementInt32, 160, 160, 1)
+
+	for i := range image.Pix {
+		image.Pix[i] = float64(1000 + r.NormFloat64()*5)
+	}
+
+	for _, s := range field {
+		cx, cy := t.Apply(s.X+10, s.Y+10)
+
+		flux := 20000 + 2000*s.Flux
+
+		for y := max(int(cy)-12, 0); y < min(int(cy)+12, 160); y++ {
+			for x := max(int(cx)-12, 0); x < min(int(cx)+12, 160); x++ {
+				dx, dy := float64(x)-cx, float64(y)-cy
+
+				v := flux / (2 * math.Pi * sx * sy) * math.Exp(-(dx*dx/(2*sx*sx) + dy*dy/(2*sy*sy)))
+
+				image.Set(x, y, 0, image.At(x, y, 0)+float64(v))
+			}
+		}
+	}
+
+	return image
+}
+
+func TestStacker(t *testing.T) {
+	s := NewStacker(Options{MaxEccentricity: 0.6})
+
+	if s.Image() != nil {
+		t.Errorf("got an image, wanted nil before any frame is stacked")
+	}
+
+	tests := []struct {
+		name   string
+		image  *alpacago.Image
+		reason error
+	}{
+		{"Reference", newFrame(Transform{}, 1.5, 1.5, 1), nil},
+		{"Shifted", newFrame(Transform{DX: 4.2, DY: -3.7}, 1.5, 1.5, 2), nil},
+		{"Rotated", newFrame(Transform{Angle: 0.02, DX: -2.5, DY: 1.5}, 1.5, 1.5, 3), nil},
+		{"Cloudy", newFrame(Transform{DX: 200}, 1.5, 1.5, 4), ErrTooFewStars},
+		{"Trailed", newFrame(Transform{}, 4, 1.2, 5), ErrPoorQuality},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Add(tt.image, nil)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if got.Accepted != (tt.reason == nil) || !errors.Is(got.Reason, tt.reason) {
+				t.Errorf("got accepted %v for %v, wanted %v", got.Accepted, got.Reason, tt.reason)
+			}
+		})
+	}
+
+	if accepted, rejected := s.Count(); accepted != 3 || rejected != 2 {
+		t.Errorf("got %d accepted and %d rejected, wanted 3 and 2", accepted, rejected)
+	}
+
+	stack := s.Image()
+
+	detected, err := stars.Detect(stack, stars.Options{})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	// The stars of the stack are at their positions in the reference:
+	registration, err := Register(field, detected, 0.5, 15)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if tr := registration.Transform; math.Abs(tr.Angle) > 1e-3 || math.Abs(tr.DX-10) > 0.1 || math.Abs(tr.DY-10) > 0.1 {
+		t.Errorf("got %+v, wanted the stars of the stack to be offset by (10, 10) from the field", tr)
+	}
+
+	// The noise of the background is reduced by averaging:
+	corner := []float64{}
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			corner = append(corner, stack.At(x, y, 0))
+		}
+	}
+
+	if got := stats.Compute(corner, 0); got.StdDev > 4 || math.Abs(got.Mean-1000) > 2 {
+		t.Errorf("got a background of %v ± %v, wanted 1000 ± about 3", got.Mean, got.StdDev)
+	}
+}
+
+func TestStackerSigmaClip(t *testing.T) {
+	tests := []struct {
+		method Method
+		clean  bool
+	}{
+		{Average, false},
+		{SigmaClip, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method.String(), func(t *testing.T) {
+			s := NewStacker(Options{Method: tt.method})
+
+			for i := int64(0); i < 6; i++ {
+				frame := newFrame(Transform{}, 1.5, 1.5, i)
+
+				// A satellite crosses the last frame:
+				if i == 5 {
+					for x := 0; x < 160; x++ {
+						frame.Set(x, 3, 0, 30000)
+					}
+				}
+
+				if _, err := s.Add(frame, nil); err != nil {
+					t.Fatalf("got %q", err)
+				}
+			}
+
+			got := s.Image().At(80, 3, 0)
+
+			if clean := math.Abs(float64(got)-1000) < 10; clean != tt.clean {
+				t.Errorf("got %v, wanted clean %v", got, tt.clean)
+			}
+		})
+	}
+}
+
+func TestStackerSigmaClipNoiseFloor(t *testing.T) {
+	s := NewStacker(Options{Method: SigmaClip})
+
+	// The first frames are identical, so that the standard deviation of every pixel is zero when clipping starts:
+	for i := 0; i < 3; i++ {
+		if _, err := s.Add(newFrame(Transform{}, 1.5, 1.5, 1), nil); err != nil {
+			t.Fatalf("got %q", err)
+		}
+	}
+
+	for seed := int64(2); seed < 8; seed++ {
+		if _, err := s.Add(newFrame(Transform{}, 1.5, 1.5, seed), nil); err != nil {
+			t.Fatalf("got %q", err)
+		}
+	}
+
+	// Almost every pixel of the later, noisy frames is within the noise of the frame, and so is stacked:
+	total := 0
+
+	for _, n := range s.count {
+		total += int(n)
+	}
+
+	if got := float64(total) / float64(len(s.count)); got < 8.5 {
+		t.Errorf("got %.2f frames per pixel, wanted about 9", got)
+	}
+}
+
+func TestStackerCalibrate(t *testing.T) {
+	library, _ := calibration.NewLibrary("")
+
+	key := calibration.Key{Type: calibration.Light, Exposure: 10, BinX: 1, BinY: 1, Temperature: -10}
+
+	dark := alpacago.NewImage(alpacago.ImageElementSingle, 160, 160, 1)
+
+	for i := range dark.Pix {
+		dark.Pix[i] = 500
+	}
+
+	darkKey := key
+
+	darkKey.Type = calibration.Dark
+
+	library.Add(&calibration.Master{Key: darkKey, Image: dark})
+
+	s := NewStacker(Options{Library: library})
+
+	frame := newFrame(Transform{}, 1.5, 1.5, 1)
+
+	for i := range frame.Pix {
+		frame.Pix[i] += 500
+	}
+
+	got, err := s.Add(frame, &key)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if !got.Accepted || got.Applied.Dark == nil {
+		t.Errorf("got %+v, wanted an accepted frame with the dark applied", got)
+	}
+
+	if v := s.Image().At(0, 0, 0); math.Abs(float64(v)-1000) > 20 {
+		t.Errorf("got %v, wanted the dark to be subtracted", v)
+	}
+
+	if _, err := s.Add(alpacago.NewImage(alpacago.ImageElementInt32, 80, 80, 1), nil); err == nil {
+		t.Errorf("got nil, wanted an error for a frame of different dimensions")
+	}
+}
+
+func TestStackerAddExposureMosaic(t *testing.T) {
+	tests := []struct {
+		name   string
+		sensor alpacago.SensorType
+		planes int
+	}{
+		{"Monochrome", alpacago.Monochrome, 1},
+		{"RGGB", alpacago.RGGBBayerEncoding, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := alpacatest.NewServer(t, map[string]string{
+				"camera/0/sensortype":   fmt.Sprint(int(tt.sensor)),
+				"camera/0/binx":         "1",
+				"camera/0/biny":         "1",
+				"camera/0/bayeroffsetx": "0",
+				"camera/0/bayeroffsety": "0",
+				"camera/0/startx":       "0",
+				"camera/0/starty":       "0",
+			}).Strict()
+
+			camera := alpacago.NewCameraWithOptions(ts.URL, 0)
+
+			image := newFrame(Transform{}, 1.5, 1.5, 1)
+
+			// The red pixels of the RGGB mosaic receive half of the light of the green and blue pixels:
+			for y := 0; y < image.Height; y += 2 {
+				for x := 0; x < image.Width; x += 2 {
+					image.Set(x, y, 0, image.At(x, y, 0)/2)
+				}
+			}
+
+			s := NewStacker(Options{})
+
+			got, err := s.AddExposure(context.Background(), &alpacago.Exposure{Image: image, Light: true, Duration: 10}, camera, nil)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if !got.Accepted {
+				t.Fatalf("got %v, wanted the frame to be accepted", got.Reason)
+			}
+
+			stack := s.Image()
+
+			if stack.Planes != tt.planes {
+				t.Fatalf("got %d planes, wanted %d", stack.Planes, tt.planes)
+			}
+
+			// The colours of the mosaic are separated, rather than mixed, by debayering:
+			if tt.planes == 3 {
+				if r, g := stack.At(41, 41, 0), stack.At(41, 41, 1); math.Abs(float64(r)-500) > 20 || math.Abs(float64(g)-1000) > 20 {
+					t.Errorf("got red %v and green %v, wanted 500 and 1000", r, g)
+				}
+			}
+		})
+	}
+}
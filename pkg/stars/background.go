@@ -0,0 +1,98 @@
+package stars
+
+import (
+	"math"
+
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+/*
+Background
+
+The sky background of an image, as the median level and noise of square tiles of the image. The level and noise
+at any pixel are bilinearly interpolated between the centres of the tiles.
+*/
+type Background struct {
+	Width    int
+	Height   int
+	TileSize int
+	Columns  int
+	Rows     int
+	Level    []float64
+	Noise    []float64
+}
+
+/*
+EstimateBackground()
+
+@param pixels []float64 (the pixels of a single plane, in rows of width)
+@param width, height int (the dimensions of the plane)
+@param tileSize int (the size of the square tiles, which should be several times the size of the largest star)
+@returns the background of the plane, estimated from the median and median absolute deviation of each tile,
+which are robust to the stars within the tile.
+*/
+func EstimateBackground(pixels []float64, width int, height int, tileSize int) *Background {
+	b := &Background{
+		Width:    width,
+		Height:   height,
+		TileSize: tileSize,
+		Columns:  (width + tileSize - 1) / tileSize,
+		Rows:     (height + tileSize - 1) / tileSize,
+	}
+
+	b.Level = make([]float64, b.Columns*b.Rows)
+	b.Noise = make([]float64, b.Columns*b.Rows)
+
+	values := make([]float64, 0, tileSize*tileSize)
+
+	for row := 0; row < b.Rows; row++ {
+		for column := 0; column < b.Columns; column++ {
+			values = values[:0]
+
+			for y := row * tileSize; y < height && y < (row+1)*tileSize; y++ {
+				for _, v := range pixels[y*width+column*tileSize : y*width+min(width, (column+1)*tileSize)] {
+					values = append(values, v)
+				}
+			}
+
+			median := stats.Median(values)
+
+			for i, v := range values {
+				values[i] = math.Abs(v - median)
+			}
+
+			b.Level[row*b.Columns+column] = median
+			b.Noise[row*b.Columns+column] = stats.MAD_TO_SIGMA * stats.Median(values)
+		}
+	}
+
+	return b
+}
+
+/*
+At()
+
+@returns the background level and noise at the pixel (x, y).
+*/
+func (b *Background) At(x int, y int) (float64, float64) {
+	// The position of the pixel relative to the centres of the tiles:
+	tx := (float64(x)+0.5)/float64(b.TileSize) - 0.5
+	ty := (float64(y)+0.5)/float64(b.TileSize) - 0.5
+
+	tx = math.Min(math.Max(tx, 0), float64(b.Columns-1))
+	ty = math.Min(math.Max(ty, 0), float64(b.Rows-1))
+
+	x0, y0 := int(tx), int(ty)
+	x1, y1 := min(x0+1, b.Columns-1), min(y0+1, b.Rows-1)
+
+	fx, fy := tx-float64(x0), ty-float64(y0)
+
+	interpolate := func(values []float64) float64 {
+		top := values[y0*b.Columns+x0]*(1-fx) + values[y0*b.Columns+x1]*fx
+		bottom := values[y1*b.Columns+x0]*(1-fx) + values[y1*b.Columns+x1]*fx
+
+		return top*(1-fy) + bottom*fy
+	}
+
+	return interpolate(b.Level), interpolate(b.Noise)
+}
@@ -0,0 +1,36 @@
+package stars
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateBackground(t *testing.T) {
+	image := newField(100, 80, 10, 1)
+
+	// A gradient across the image, of 2 ADU per pixel:
+	for y := 0; y < image.Height; y++ {
+		for x := 0; x < image.Width; x++ {
+			image.Set(x, y, 0, image.At(x, y, 0)+float64(2*x))
+		}
+	}
+
+	got := EstimateBackground(image.Pix, image.Width, image.Height, 20)
+
+	if got.Columns != 5 || got.Rows != 4 {
+		t.Fatalf("got %dx%d tiles, wanted 5x4", got.Columns, got.Rows)
+	}
+
+	for _, x := range []int{10, 25, 50, 89} {
+		level, noise := got.At(x, 40)
+
+		if want := 1000 + 2*float64(x); math.Abs(level-want) > 5 {
+			t.Errorf("got a level of %v at x = %d, wanted %v", level, x, want)
+		}
+
+		// The gradient within each tile adds to the noise:
+		if noise < 8 || noise > 30 {
+			t.Errorf("got a noise of %v at x = %d, wanted about %v", noise, x, 10)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package stars
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/stats"
+)
+
+// The ratio of the full width at half maximum to the standard deviation of a Gaussian profile:
+const SIGMA_TO_FWHM = 2.3548200450309493
+
+/*
+Star
+
+A star detected in an image. X and Y are the centroid of the star in pixels, where the centre of the first pixel is
+(0, 0). Flux is the sum of the background subtracted pixels within the measurement aperture, and Peak is the
+largest pixel value of the star before background subtraction. HFR, the half flux radius, and FWHM are in pixels.
+Eccentricity is zero for a round star, and approaches one as the star is elongated, e.g., by trailing.
+*/
+type Star struct {
+	X            float64
+	Y            float64
+	Flux         float64
+	Peak         float64
+	Background   float64
+	Noise        float64
+	HFR          float64
+	FWHM         float64
+	Eccentricity float64
+	Pixels       int
+	Saturated    bool
+}
+
+/*
+SNR()
+
+@returns the signal to noise ratio of the star, from the noise of the background only.
+*/
+func (s Star) SNR() float64 {
+	if s.Noise == 0 {
+		return math.Inf(1)
+	}
+
+	return s.Flux / (s.Noise * math.Sqrt(float64(s.Pixels)))
+}
+
+/*
+Options
+
+The options of Detect(). A zero value for any option selects its default.
+*/
+type Options struct {
+	// The detection threshold, in standard deviations of the background noise (default 5):
+	Sigma float64
+	// The least number of connected pixels above the threshold for a star, which rejects hot pixels and noise (default 5):
+	MinPixels int
+	// The greatest number of connected pixels above the threshold for a star, which rejects nebulae and galaxies (default 2500):
+	MaxPixels int
+	// The size of the tiles of the background estimate (default 64):
+	TileSize int
+	// The saturation level, e.g., the MaxADU of the camera, or zero to not flag saturated stars:
+	Saturation float64
+	// The greatest number of stars to return, the brightest first, or zero to return every star:
+	MaxStars int
+}
+
+func (o Options) withDefaults() Options {
+	if o.Sigma == 0 {
+		o.Sigma = 5
+	}
+
+	if o.MinPixels == 0 {
+		o.MinPixels = 5
+	}
+
+	if o.MaxPixels == 0 {
+		o.MaxPixels = 2500
+	}
+
+	if o.TileSize == 0 {
+		o.TileSize = 64
+	}
+
+	return o
+}
+
+/*
+Detect()
+
+@param image *alpacago.Image (the image, where the planes of a colour image are averaged)
+@param opts Options (the detection options)
+@returns the stars of the image, ordered from the brightest. Stars are found as connected pixels above the
+background by more than opts.Sigma times the background noise, excluding those touching the edge of the image.
+*/
+func Detect(image *alpacago.Image, opts Options) ([]Star, error) {
+	if image.Width == 0 || image.Height == 0 {
+		return nil, fmt.Errorf("stars: image has no pixels")
+	}
+
+	opts = opts.withDefaults()
+
+	pixels := luminance(image)
+
+	background := EstimateBackground(pixels, image.Width, image.Height, opts.TileSize)
+
+	d := detector{
+		pixels:     pixels,
+		width:      image.Width,
+		height:     image.Height,
+		background: background,
+		opts:       opts,
+		above:      make([]bool, len(pixels)),
+	}
+
+	for y := 0; y < d.height; y++ {
+		for x := 0; x < d.width; x++ {
+			level, noise := background.At(x, y)
+
+			d.above[y*d.width+x] = pixels[y*d.width+x] > level+opts.Sigma*noise
+		}
+	}
+
+	stars := []Star{}
+
+	for i, above := range d.above {
+		if !above {
+			continue
+		}
+
+		component, edge := d.fill(i)
+
+		if edge || len(component) < opts.MinPixels || len(component) > opts.MaxPixels {
+			continue
+		}
+
+		if star, ok := d.measure(component); ok {
+			stars = append(stars, star)
+		}
+	}
+
+	sort.Slice(stars, func(i, j int) bool {
+		return stars[i].Flux > stars[j].Flux
+	})
+
+	if opts.MaxStars > 0 && len(stars) > opts.MaxStars {
+		stars = stars[:opts.MaxStars]
+	}
+
+	return stars, nil
+}
+
+/*
+DetectForCamera()
+
+@returns the stars of an image taken by the camera, see Detect(), flagging stars at or above the MaxADU of the
+camera as saturated unless opts.Saturation is given.
+*/
+func DetectForCamera(ctx context.Context, camera *alpacago.Camera, image *alpacago.Image, opts Options) ([]Star, error) {
+	if opts.Saturation == 0 {
+		maxADU, err := camera.GetMaxADUContext(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Saturation = float64(maxADU)
+	}
+
+	return Detect(image, opts)
+}
+
+/*
+luminance()
+
+@returns the pixels of a single plane image, or the mean of the planes of a colour image.
+*/
+func luminance(image *alpacago.Image) []float64 {
+	if image.Planes <= 1 {
+		return image.Pix
+	}
+
+	n := image.Width * image.Height
+
+	pixels := make([]float64, n)
+
+	for p := 0; p < image.Planes; p++ {
+		for i, v := range image.Pix[p*n : (p+1)*n] {
+			pixels[i] += v
+		}
+	}
+
+	for i := range pixels {
+		pixels[i] /= float64(image.Planes)
+	}
+
+	return pixels
+}
+
+type detector struct {
+	pixels     []float64
+	width      int
+	height     int
+	background *Background
+	opts       Options
+	above      []bool
+}
+
+/*
+fill()
+
+@returns the indices of the 8-connected pixels above the threshold that include the pixel i, clearing them from
+the threshold mask, and whether any of them touch the edge of the image.
+*/
+func (d *detector) fill(i int) ([]int, bool) {
+	component := []int{}
+
+	stack := []int{i}
+
+	d.above[i] = false
+
+	edge := false
+
+	for len(stack) > 0 {
+		j := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		component = append(component, j)
+
+		x, y := j%d.width, j/d.width
+
+		if x == 0 || y == 0 || x == d.width-1 || y == d.height-1 {
+			edge = true
+		}
+
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				nx, ny := x+dx, y+dy
+
+				if nx < 0 || ny < 0 || nx >= d.width || ny >= d.height {
+					continue
+				}
+
+				k := ny*d.width + nx
+
+				if d.above[k] {
+					d.above[k] = false
+					stack = append(stack, k)
+				}
+			}
+		}
+	}
+
+	return component, edge
+}
+
+/*
+measure()
+
+Measures the star whose pixels above the threshold are the component. The centroid is first found from the
+component, and then refined within a circular aperture that extends beyond the component into the wings of the
+star. The HFR is the flux weighted mean distance of the aperture pixels from the centroid, and the FWHM and
+eccentricity are found from the flux weighted second moments of the aperture, as for a Gaussian profile.
+
+@returns the star, and false if the star has no flux above the background.
+*/
+func (d *detector) measure(component []int) (Star, bool) {
+	var sum, sumX, sumY float64
+
+	star := Star{}
+
+	for _, i := range component {
+		x, y := i%d.width, i/d.width
+
+		level, _ := d.background.At(x, y)
+
+		f := d.pixels[i] - level
+
+		sum += f
+		sumX += f * float64(x)
+		sumY += f * float64(y)
+
+		if d.pixels[i] > star.Peak {
+			star.Peak = d.pixels[i]
+		}
+	}
+
+	if sum <= 0 {
+		return star, false
+	}
+
+	cx, cy := sumX/sum, sumY/sum
+
+	// The aperture extends to twice the furthest pixel of the component, and at least three pixels, to include the
+	// wings of the star below the threshold:
+	var extent float64
+
+	for _, i := range component {
+		extent = math.Max(extent, math.Hypot(float64(i%d.width)-cx, float64(i/d.width)-cy))
+	}
+
+	radius := math.Max(2*extent, 3)
+
+	star.Background, star.Noise = d.background.At(int(math.Round(cx)), int(math.Round(cy)))
+
+	// Refine the centroid within the aperture, then measure about the refined centroid:
+	for iteration := 0; iteration < 2; iteration++ {
+		x0, x1 := max(int(cx-radius), 0), min(int(cx+radius)+1, d.width-1)
+		y0, y1 := max(int(cy-radius), 0), min(int(cy+radius)+1, d.height-1)
+
+		var flux, mx, my, mxx, myy, mxy, mr float64
+
+		pixels := 0
+
+		for y := y0; y <= y1; y++ {
+			for x := x0; x <= x1; x++ {
+				dx, dy := float64(x)-cx, float64(y)-cy
+
+				r := math.Hypot(dx, dy)
+
+				if r > radius {
+					continue
+				}
+
+				f := d.pixels[y*d.width+x] - star.Background
+
+				pixels++
+
+				// Negative values of the background noise are excluded from the weights:
+				if f <= 0 {
+					continue
+				}
+
+				flux += f
+				mx += f * dx
+				my += f * dy
+				mxx += f * dx * dx
+				myy += f * dy * dy
+				mxy += f * dx * dy
+				mr += f * r
+			}
+		}
+
+		if flux <= 0 {
+			return star, false
+		}
+
+		if iteration == 0 {
+			cx, cy = cx+mx/flux, cy+my/flux
+			continue
+		}
+
+		mxx, myy, mxy = mxx/flux, myy/flux, mxy/flux
+
+		// The eigenvalues of the second moments are the variances along the major and minor axes:
+		trace, det := mxx+myy, mxx*myy-mxy*mxy
+
+		root := math.Sqrt(math.Max(trace*trace/4-det, 0))
+
+		major, minor := trace/2+root, math.Max(trace/2-root, 0)
+
+		star.Flux = flux
+		star.Pixels = pixels
+		star.HFR = mr / flux
+		star.FWHM = SIGMA_TO_FWHM * math.Sqrt(trace/2)
+
+		if major > 0 {
+			star.Eccentricity = math.Sqrt(1 - minor/major)
+		}
+	}
+
+	star.X, star.Y = cx, cy
+
+	star.Saturated = d.opts.Saturation > 0 && star.Peak >= d.opts.Saturation
+
+	return star, true
+}
+
+/*
+Summary
+
+The median measurements of the unsaturated stars of an image, with which to judge the focus, guiding and seeing
+of the image.
+*/
+type Summary struct {
+	Count        int
+	HFR          float64
+	FWHM         float64
+	Eccentricity float64
+}
+
+/*
+Summarise()
+
+@returns the median HFR, FWHM and eccentricity of the unsaturated stars, whose profiles are not clipped, or NaN
+if there are none.
+*/
+func Summarise(stars []Star) Summary {
+	var hfr, fwhm, eccentricity []float64
+
+	for _, s := range stars {
+		if s.Saturated {
+			continue
+		}
+
+		hfr = append(hfr, s.HFR)
+		fwhm = append(fwhm, s.FWHM)
+		eccentricity = append(eccentricity, s.Eccentricity)
+	}
+
+	return Summary{
+		Count:        len(hfr),
+		HFR:          stats.Median(hfr),
+		FWHM:         stats.Median(fwhm),
+		Eccentricity: stats.Median(eccentricity),
+	}
+}
+
+/*
+PixelScale()
+
+@param pixelSize float64 (the size of the pixels of the camera, in µm)
+@param binning int32 (the binning of the camera)
+@param focalLength float64 (the focal length of the telescope, in mm)
+@returns the scale of the image, in arcseconds per pixel, with which to compare the FWHM of the stars to the
+seeing reported by ObservingConditions.GetSeeingStarFWHM().
+*/
+func PixelScale(pixelSize float64, binning int32, focalLength float64) float64 {
+	return 206.264806 * pixelSize * float64(binning) / focalLength
+}
+
+/*
+PixelScaleForDevices()
+
+@returns the scale of images taken by the camera through the telescope, in arcseconds per pixel, see PixelScale().
+*/
+func PixelScaleForDevices(ctx context.Context, camera *alpacago.Camera, telescope *alpacago.Telescope) (float64, error) {
+	pixelSize, err := camera.GetPixelSizeXContext(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	binning, err := camera.GetBinXContext(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	focalLength, err := telescope.GetFocalLengthContext(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// The focal length of the telescope is in metres:
+	return PixelScale(pixelSize, binning, focalLength*1000), nil
+}
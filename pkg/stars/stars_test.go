@@ -0,0 +1,177 @@
+package stars
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// newField returns an image of a noisy sky background of 1000 ADU:
+func newField(width int, height int, noise float64, seed int64) *alpacago.Image {
+	r := rand.New(rand.NewSource(seed))
+
+	image := alpacago.NewImage(alpacago.ImageElementInt32, width, height, 1)
+
+	for i := range image.Pix {
+		image.Pix[i] = float64(1000 + r.NormFloat64()*noise)
+	}
+
+	return image
+}
+
+// addStar adds an elliptical Gaussian star, with standard deviations sx and sy along the x and y axes:
+func addStar(image *alpacago.Image, cx float64, cy float64, flux float64, sx float64, sy float64) {
+	for y := 0; y < image.Height; y++ {
+		for x := 0; x < image.Width; x++ {
+			dx, dy := float64(x)-cx, float64(y)-cy
+
+			v := flux / (2 * math.Pi * sx * sy) * math.Exp(-(dx*dx/(2*sx*sx) + dy*dy/(2*sy*sy)))
+
+			image.Set(x, y, 0, image.At(x, y, 0)+float64(v))
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	image := newField(128, 128, 5, 42)
+
+	addStar(image, 40.3, 50.6, 200000, 2, 2)
+	addStar(image, 90.5, 30.25, 100000, 1.5, 1.5)
+
+	got, err := Detect(image, Options{})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d stars, wanted 2", len(got))
+	}
+
+	tests := []struct {
+		x, y, flux, sigma float64
+	}{
+		{40.3, 50.6, 200000, 2},
+		{90.5, 30.25, 100000, 1.5},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			s := got[i]
+
+			if math.Abs(s.X-tt.x) > 0.05 || math.Abs(s.Y-tt.y) > 0.05 {
+				t.Errorf("got a centroid of (%v, %v), wanted (%v, %v)", s.X, s.Y, tt.x, tt.y)
+			}
+
+			if math.Abs(s.Flux-tt.flux)/tt.flux > 0.05 {
+				t.Errorf("got a flux of %v, wanted %v", s.Flux, tt.flux)
+			}
+
+			if want := SIGMA_TO_FWHM * tt.sigma; math.Abs(s.FWHM-want)/want > 0.1 {
+				t.Errorf("got a FWHM of %v, wanted %v", s.FWHM, want)
+			}
+
+			// The mean radius of a circular Gaussian profile is σ√(π/2):
+			if want := tt.sigma * math.Sqrt(math.Pi/2); math.Abs(s.HFR-want)/want > 0.1 {
+				t.Errorf("got a HFR of %v, wanted %v", s.HFR, want)
+			}
+
+			if s.Eccentricity > 0.3 {
+				t.Errorf("got an eccentricity of %v, wanted a round star", s.Eccentricity)
+			}
+
+			if math.Abs(s.Background-1000) > 2 {
+				t.Errorf("got a background of %v, wanted %v", s.Background, 1000)
+			}
+		})
+	}
+}
+
+func TestDetectEccentricity(t *testing.T) {
+	image := newField(96, 96, 2, 7)
+
+	addStar(image, 48, 48, 200000, 3, 1.8)
+
+	got, err := Detect(image, Options{})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("got %d stars, wanted 1", len(got))
+	}
+
+	// For axes of 3 and 1.8, e = √(1 - 1.8²/3²) = 0.8:
+	if math.Abs(got[0].Eccentricity-0.8) > 0.05 {
+		t.Errorf("got an eccentricity of %v, wanted %v", got[0].Eccentricity, 0.8)
+	}
+}
+
+func TestDetectRejects(t *testing.T) {
+	image := newField(128, 128, 5, 3)
+
+	// A hot pixel, a star on the edge of the image and a saturated star:
+	image.Set(20, 20, 0, 60000)
+
+	addStar(image, 0, 64, 100000, 2, 2)
+	addStar(image, 64, 64, 4000000, 2, 2)
+
+	for i, v := range image.Pix {
+		if v > 20000 && i != 20*128+20 {
+			image.Pix[i] = 20000
+		}
+	}
+
+	got, err := Detect(image, Options{Saturation: 20000})
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(got) != 1 || math.Abs(got[0].X-64) > 0.1 || !got[0].Saturated {
+		t.Errorf("got %+v, wanted the saturated star only", got)
+	}
+
+	if got := Summarise(got); got.Count != 0 || !math.IsNaN(got.HFR) {
+		t.Errorf("got %+v, wanted no unsaturated stars", got)
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	got := Summarise([]Star{
+		{HFR: 1, FWHM: 2, Eccentricity: 0.1},
+		{HFR: 3, FWHM: 6, Eccentricity: 0.3},
+		{HFR: 2, FWHM: 4, Eccentricity: 0.2},
+		{HFR: 10, FWHM: 20, Eccentricity: 0.9, Saturated: true},
+	})
+
+	want := Summary{Count: 3, HFR: 2, FWHM: 4, Eccentricity: 0.2}
+
+	if got != want {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+}
+
+func TestPixelScaleForDevices(t *testing.T) {
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/pixelsizex":     "3.76",
+		"camera/0/binx":           "2",
+		"telescope/0/focallength": "1.0",
+	}).Strict()
+
+	got, err := PixelScaleForDevices(context.Background(), alpacago.NewCameraWithOptions(ts.URL, 0), alpacago.NewTelescopeWithOptions(ts.URL, 0, alpacago.NotTracking))
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if want := 206.264806 * 3.76 * 2 / 1000; got != want {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
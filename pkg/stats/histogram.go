@@ -0,0 +1,90 @@
+package stats
+
+/*
+Histogram
+
+The counts of pixel values in equal width bins spanning [Min, Max]. A value of Max is counted in the last bin,
+and values outside of the range are counted in Below and Above.
+*/
+type Histogram struct {
+	Min   float64
+	Max   float64
+	Bins  []int
+	Below int
+	Above int
+}
+
+/*
+NewHistogram()
+
+@param pixels []float64 (the pixel values)
+@param bins int (the number of bins)
+@param min float64 (the lower bound of the first bin)
+@param max float64 (the upper bound of the last bin)
+@returns the histogram of the pixel values.
+*/
+func NewHistogram(pixels []float64, bins int, min float64, max float64) *Histogram {
+	h := Histogram{
+		Min:  min,
+		Max:  max,
+		Bins: make([]int, bins),
+	}
+
+	if bins == 0 {
+		return &h
+	}
+
+	scale := float64(bins) / float64(max-min)
+
+	for _, v := range pixels {
+		switch {
+		case v < min:
+			h.Below++
+		case v > max:
+			h.Above++
+		case v == max || max == min:
+			h.Bins[bins-1]++
+		default:
+			b := int((v - min) * scale)
+
+			// Guard against rounding carrying a value just below max into a bin beyond the last:
+			if b >= bins {
+				b = bins - 1
+			}
+
+			h.Bins[b]++
+		}
+	}
+
+	return &h
+}
+
+/*
+BinWidth()
+
+@returns the width of each bin.
+*/
+func (h *Histogram) BinWidth() float64 {
+	if len(h.Bins) == 0 {
+		return 0
+	}
+
+	return float64(h.Max-h.Min) / float64(len(h.Bins))
+}
+
+/*
+Mode()
+
+@returns the centre of the bin with the greatest count, i.e., the most frequent pixel value.
+*/
+func (h *Histogram) Mode() float64 {
+	mode := 0
+
+	for i, c := range h.Bins {
+		if c > h.Bins[mode] {
+			mode = i
+		}
+	}
+
+	return float64(h.Min) + (float64(mode)+0.5)*h.BinWidth()
+}
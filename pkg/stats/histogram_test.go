@@ -0,0 +1,32 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewHistogram(t *testing.T) {
+	got := NewHistogram([]float64{-1, 0, 0.5, 1, 2.5, 3.9, 4, 5}, 4, 0, 4)
+
+	want := []int{2, 1, 1, 2}
+
+	if fmt.Sprint(got.Bins) != fmt.Sprint(want) {
+		t.Errorf("got %v, wanted %v", got.Bins, want)
+	}
+
+	if got.Below != 1 || got.Above != 1 {
+		t.Errorf("got %d below and %d above, wanted 1 and 1", got.Below, got.Above)
+	}
+
+	if got.BinWidth() != 1 {
+		t.Errorf("got %v, wanted %v", got.BinWidth(), 1)
+	}
+}
+
+func TestHistogramMode(t *testing.T) {
+	got := NewHistogram([]float64{100, 101, 102, 102, 102, 900}, 10, 0, 1000).Mode()
+
+	if got != 150 {
+		t.Errorf("got %v, wanted %v", got, 150)
+	}
+}
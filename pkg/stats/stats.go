@@ -0,0 +1,348 @@
+package stats
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+// The largest pixel value for which the median is found by counting, rather than by selection:
+const COUNTING_MAX = math.MaxUint16
+
+// The scale of the median absolute deviation to the standard deviation of normally distributed noise:
+const MAD_TO_SIGMA = 1.4826
+
+/*
+Statistics
+
+The statistics of the pixels of a single image plane. StdDev is the population standard deviation, and MAD is
+the median absolute deviation from the median. Saturated is the number of pixels at or above the saturation
+level, and is zero if no saturation level was given.
+*/
+type Statistics struct {
+	Count     int
+	Min       float64
+	Max       float64
+	Mean      float64
+	StdDev    float64
+	Median    float64
+	MAD       float64
+	Saturated int
+}
+
+/*
+Compute()
+
+@param pixels []float64 (the pixel values)
+@param saturation float64 (the saturation level, e.g., the MaxADU of the camera, or zero to not count saturated pixels)
+@returns the statistics of the pixels. For integer pixel values of at most 65535, as produced by most cameras,
+the median and MAD are found by counting in linear time, otherwise by selection from a copy of the pixels.
+*/
+func Compute(pixels []float64, saturation float64) Statistics {
+	s := Statistics{
+		Count: len(pixels),
+	}
+
+	if len(pixels) == 0 {
+		return s
+	}
+
+	s.Min, s.Max = pixels[0], pixels[0]
+
+	integral := true
+
+	var sum, sumSquares float64
+
+	for _, v := range pixels {
+		if v < s.Min {
+			s.Min = v
+		}
+
+		if v > s.Max {
+			s.Max = v
+		}
+
+		if saturation > 0 && v >= saturation {
+			s.Saturated++
+		}
+
+		if integral && (v < 0 || v > COUNTING_MAX || v != math.Trunc(v)) {
+			integral = false
+		}
+
+		sum += v
+		sumSquares += v * v
+	}
+
+	n := float64(len(pixels))
+
+	s.Mean = sum / n
+
+	// Guard against a small negative variance from rounding error:
+	s.StdDev = math.Sqrt(math.Max(sumSquares/n-s.Mean*s.Mean, 0))
+
+	if integral {
+		s.Median, s.MAD = countingMedian(pixels)
+	} else {
+		s.Median, s.MAD = selectionMedian(pixels)
+	}
+
+	return s
+}
+
+/*
+countingMedian()
+
+@returns the median and the median absolute deviation of integer pixel values in [0, COUNTING_MAX], found from the
+counts of each value.
+*/
+func countingMedian(pixels []float64) (float64, float64) {
+	counts := make([]int, COUNTING_MAX+1)
+
+	for _, v := range pixels {
+		counts[int(v)]++
+	}
+
+	median := medianOfCounts(counts, len(pixels), 1)
+
+	// The deviations are counted at twice their value, as the median may be a half integer:
+	deviations := make([]int, 2*COUNTING_MAX+2)
+
+	m2 := int(math.Round(2 * median))
+
+	for v, c := range counts {
+		if c == 0 {
+			continue
+		}
+
+		d := 2*v - m2
+
+		if d < 0 {
+			d = -d
+		}
+
+		deviations[d] += c
+	}
+
+	return median, medianOfCounts(deviations, len(pixels), 2)
+}
+
+/*
+medianOfCounts()
+
+@returns the median of n values, given the count of each value, where the value of index i is i/scale.
+*/
+func medianOfCounts(counts []int, n int, scale float64) float64 {
+	// The zero based ranks of the lower and upper middle values, which are equal for an odd n:
+	lo, hi := (n-1)/2, n/2
+
+	loValue, hiValue := -1, -1
+
+	seen := 0
+
+	for v, c := range counts {
+		seen += c
+
+		if loValue < 0 && seen > lo {
+			loValue = v
+		}
+
+		if seen > hi {
+			hiValue = v
+			break
+		}
+	}
+
+	return float64(loValue+hiValue) / 2 / scale
+}
+
+/*
+selectionMedian()
+
+@returns the median and the median absolute deviation of the pixels, selected from a copy of the pixels.
+*/
+func selectionMedian(pixels []float64) (float64, float64) {
+	values := make([]float64, len(pixels))
+
+	copy(values, pixels)
+
+	median := Median(values)
+
+	for i, v := range pixels {
+		values[i] = math.Abs(v - median)
+	}
+
+	return median, Median(values)
+}
+
+/*
+Median()
+
+@returns the median of the values, which are partially reordered in place, in linear expected time.
+*/
+func Median(values []float64) float64 {
+	n := len(values)
+
+	if n == 0 {
+		return math.NaN()
+	}
+
+	hi := quickselect(values, n/2)
+
+	if n%2 == 1 {
+		return hi
+	}
+
+	// After selection, the lower middle value is the largest value of the lower partition:
+	lo := values[0]
+
+	for _, v := range values[1 : n/2] {
+		if v > lo {
+			lo = v
+		}
+	}
+
+	return (lo + hi) / 2
+}
+
+/*
+quickselect()
+
+@returns the k-th smallest value, partially reordering the values so that every value before index k is at most
+the k-th smallest value, and every value after it is at least the k-th smallest value.
+*/
+func quickselect(values []float64, k int) float64 {
+	lo, hi := 0, len(values)-1
+
+	for lo < hi {
+		// The median of three pivot avoids the quadratic worst case on sorted data:
+		mid := lo + (hi-lo)/2
+
+		if values[mid] < values[lo] {
+			values[mid], values[lo] = values[lo], values[mid]
+		}
+
+		if values[hi] < values[lo] {
+			values[hi], values[lo] = values[lo], values[hi]
+		}
+
+		if values[hi] < values[mid] {
+			values[hi], values[mid] = values[mid], values[hi]
+		}
+
+		pivot := values[mid]
+
+		i, j := lo, hi
+
+		for i <= j {
+			for values[i] < pivot {
+				i++
+			}
+
+			for values[j] > pivot {
+				j--
+			}
+
+			if i <= j {
+				values[i], values[j] = values[j], values[i]
+				i++
+				j--
+			}
+		}
+
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return values[k]
+		}
+	}
+
+	return values[k]
+}
+
+/*
+ComputeImage()
+
+@returns the statistics of each plane of the image, see Compute().
+*/
+func ComputeImage(image *alpacago.Image, saturation float64) []Statistics {
+	planes := make([]Statistics, image.Planes)
+
+	for p := range planes {
+		planes[p] = Compute(image.Plane(p), saturation)
+	}
+
+	return planes
+}
+
+/*
+ComputeArray()
+
+@returns the statistics of a rank 2 image array, as returned by Camera.GetExposure(), see Compute().
+*/
+func ComputeArray(array [][]uint32, saturation float64) Statistics {
+	n := 0
+
+	for _, column := range array {
+		n += len(column)
+	}
+
+	pixels := make([]float64, 0, n)
+
+	for _, column := range array {
+		for _, v := range column {
+			pixels = append(pixels, float64(v))
+		}
+	}
+
+	return Compute(pixels, saturation)
+}
+
+/*
+ComputeForCamera()
+
+@returns the statistics of each plane of an image taken by the camera, counting the pixels at or above the
+MaxADU of the camera as saturated.
+*/
+func ComputeForCamera(ctx context.Context, camera *alpacago.Camera, image *alpacago.Image) ([]Statistics, error) {
+	maxADU, err := camera.GetMaxADUContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeImage(image, float64(maxADU)), nil
+}
+
+/*
+Percentile()
+
+@returns the value below which the given fraction, in [0, 1], of the pixels fall, interpolating linearly between
+the nearest ranks.
+*/
+func Percentile(pixels []float64, fraction float64) float64 {
+	if len(pixels) == 0 {
+		return math.NaN()
+	}
+
+	values := make([]float64, len(pixels))
+
+	copy(values, pixels)
+
+	sort.Float64s(values)
+
+	rank := math.Min(math.Max(fraction, 0), 1) * float64(len(values)-1)
+
+	i := int(rank)
+
+	if i == len(values)-1 {
+		return values[i]
+	}
+
+	return values[i] + (rank-float64(i))*(values[i+1]-values[i])
+}
@@ -0,0 +1,157 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/observerly/alpacago/internal/alpacatest"
+	"github.com/observerly/alpacago/pkg/alpacago"
+)
+
+func naiveMedian(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func naiveMAD(values []float64) float64 {
+	median := naiveMedian(values)
+
+	deviations := make([]float64, len(values))
+
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+
+	return naiveMedian(deviations)
+}
+
+func TestCompute(t *testing.T) {
+	got := Compute([]float64{1, 2, 3, 4, 100}, 100)
+
+	want := Statistics{
+		Count:     5,
+		Min:       1,
+		Max:       100,
+		Mean:      22,
+		StdDev:    math.Sqrt((21*21 + 20*20 + 19*19 + 18*18 + 78*78) / 5.0),
+		Median:    3,
+		MAD:       1,
+		Saturated: 1,
+	}
+
+	if got != want {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+}
+
+func TestComputeMedian(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	tests := []struct {
+		name  string
+		value func() float64
+	}{
+		{"Integral", func() float64 { return float64(r.Intn(65536)) }},
+		{"Fractional", func() float64 { return float64(r.NormFloat64()*100 + 1000) }},
+		{"Negative", func() float64 { return float64(r.Intn(200) - 100) }},
+	}
+
+	for _, tt := range tests {
+		for _, n := range []int{1, 2, 7, 1000, 1001} {
+			t.Run(fmt.Sprintf("%s/%d", tt.name, n), func(t *testing.T) {
+				pixels := make([]float64, n)
+
+				values := make([]float64, n)
+
+				for i := range pixels {
+					pixels[i] = tt.value()
+					values[i] = float64(pixels[i])
+				}
+
+				got := Compute(pixels, 0)
+
+				if got.Median != naiveMedian(values) {
+					t.Errorf("got median %v, wanted %v", got.Median, naiveMedian(values))
+				}
+
+				if got.MAD != naiveMAD(values) {
+					t.Errorf("got MAD %v, wanted %v", got.MAD, naiveMAD(values))
+				}
+			})
+		}
+	}
+}
+
+func TestComputeArray(t *testing.T) {
+	got := ComputeArray([][]uint32{{1, 2}, {3, 4}}, 0)
+
+	if got.Count != 4 || got.Median != 2.5 || got.Mean != 2.5 || got.Min != 1 || got.Max != 4 {
+		t.Errorf("got %+v, wanted four pixels with a median and mean of 2.5", got)
+	}
+}
+
+func TestComputeForCamera(t *testing.T) {
+	ts := alpacatest.NewServer(t, map[string]string{
+		"camera/0/maxadu": "4095",
+	}).Strict()
+
+	image := alpacago.NewImage(alpacago.ImageElementInt32, 2, 2, 1)
+
+	copy(image.Pix, []float64{10, 4095, 4095, 20})
+
+	got, err := ComputeForCamera(context.Background(), alpacago.NewCameraWithOptions(ts.URL, 0), image)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if len(got) != 1 || got[0].Saturated != 2 {
+		t.Errorf("got %+v, wanted two saturated pixels", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	pixels := []float64{4, 1, 3, 2, 5}
+
+	if got := Percentile(pixels, 0.5); got != 3 {
+		t.Errorf("got %v, wanted %v", got, 3)
+	}
+
+	if got := Percentile(pixels, 0.125); got != 1.5 {
+		t.Errorf("got %v, wanted %v", got, 1.5)
+	}
+
+	if got := Percentile(pixels, 1); got != 5 {
+		t.Errorf("got %v, wanted %v", got, 5)
+	}
+}
+
+func BenchmarkCompute(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+
+	// A 24 megapixel, 16 bit frame:
+	pixels := make([]float64, 6000*4000)
+
+	for i := range pixels {
+		pixels[i] = float64(r.Intn(65536))
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		Compute(pixels, 65535)
+	}
+}
@@ -0,0 +1,105 @@
+package xisf
+
+import (
+	"encoding/binary"
+)
+
+// The least length of an LZ4 match:
+const lz4MinMatch = 4
+
+// The number of bytes at the end of an LZ4 block which must be literals:
+const lz4LastLiterals = 5
+
+// The number of bytes at the end of an LZ4 block within which no match may start:
+const lz4MatchLimit = 12
+
+// The greatest distance back to an LZ4 match:
+const lz4MaxOffset = 65535
+
+/*
+lz4Compress()
+
+Compresses the data as a single LZ4 block, without the frame format, as required by the lz4 codec of XISF. Matches
+are found greedily from a hash table of the positions of 4 byte sequences, which favours speed over ratio.
+
+@returns the LZ4 block.
+*/
+func lz4Compress(src []byte) []byte {
+	dst := make([]byte, 0, len(src)+len(src)/255+16)
+
+	// The position, plus one, of the last occurrence of each hashed sequence:
+	table := make([]int32, 1<<16)
+
+	anchor := 0
+
+	for i := 0; i < len(src)-lz4MatchLimit; {
+		sequence := binary.LittleEndian.Uint32(src[i:])
+
+		h := (sequence * 2654435761) >> 16
+
+		candidate := int(table[h]) - 1
+
+		table[h] = int32(i + 1)
+
+		if candidate < 0 || i-candidate > lz4MaxOffset || binary.LittleEndian.Uint32(src[candidate:]) != sequence {
+			i++
+			continue
+		}
+
+		length := lz4MinMatch
+
+		for i+length < len(src)-lz4LastLiterals && src[candidate+length] == src[i+length] {
+			length++
+		}
+
+		dst = lz4AppendSequence(dst, src[anchor:i], i-candidate, length)
+
+		i += length
+
+		anchor = i
+	}
+
+	// The last sequence has only literals:
+	return lz4AppendSequence(dst, src[anchor:], 0, 0)
+}
+
+/*
+lz4AppendSequence()
+
+Appends a sequence of the literals followed by a match of the length at the offset, or the literals alone if the
+length is zero.
+*/
+func lz4AppendSequence(dst []byte, literals []byte, offset int, length int) []byte {
+	matchLength := max(length-lz4MinMatch, 0)
+
+	token := byte(min(len(literals), 15)<<4) | byte(min(matchLength, 15))
+
+	dst = append(dst, token)
+
+	if len(literals) >= 15 {
+		dst = lz4AppendLength(dst, len(literals)-15)
+	}
+
+	dst = append(dst, literals...)
+
+	if length == 0 {
+		return dst
+	}
+
+	dst = binary.LittleEndian.AppendUint16(dst, uint16(offset))
+
+	if matchLength >= 15 {
+		dst = lz4AppendLength(dst, matchLength-15)
+	}
+
+	return dst
+}
+
+// lz4AppendLength appends the remainder of a length which overflows its token as bytes of 255 and a final byte:
+func lz4AppendLength(dst []byte, n int) []byte {
+	for ; n >= 255; n -= 255 {
+		dst = append(dst, 255)
+	}
+
+	return append(dst, byte(n))
+}
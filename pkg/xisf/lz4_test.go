@@ -0,0 +1,126 @@
+package xisf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+/*
+lz4Decompress()
+
+@returns the data of a single LZ4 block, or an error if the block is malformed.
+*/
+func lz4Decompress(src []byte) ([]byte, error) {
+	dst := []byte{}
+
+	i := 0
+
+	// length returns the length of the token nibble n, with any bytes which extend it:
+	length := func(n int) (int, error) {
+		for extended := n == 15; extended; {
+			if i >= len(src) {
+				return 0, fmt.Errorf("truncated length")
+			}
+
+			n += int(src[i])
+			extended = src[i] == 255
+			i++
+		}
+
+		return n, nil
+	}
+
+	for i < len(src) {
+		token := src[i]
+		i++
+
+		literals, err := length(int(token >> 4))
+
+		if err != nil || i+literals > len(src) {
+			return nil, fmt.Errorf("truncated literals")
+		}
+
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+
+		// The last sequence has no match:
+		if i == len(src) {
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, fmt.Errorf("truncated offset")
+		}
+
+		offset := int(binary.LittleEndian.Uint16(src[i:]))
+		i += 2
+
+		matchLength, err := length(int(token & 15))
+
+		if err != nil || offset == 0 || offset > len(dst) {
+			return nil, fmt.Errorf("invalid match")
+		}
+
+		// Matches may overlap the bytes they produce:
+		for j := 0; j < matchLength+lz4MinMatch; j++ {
+			dst = append(dst, dst[len(dst)-offset])
+		}
+	}
+
+	return dst, nil
+}
+
+func TestLZ4Compress(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	random := make([]byte, 5000)
+
+	r.Read(random)
+
+	sky := make([]byte, 0, 20000)
+
+	for i := 0; i < 10000; i++ {
+		sky = binary.LittleEndian.AppendUint16(sky, uint16(1000+r.Intn(4)))
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		// The greatest compressed size, as a fraction of the data:
+		ratio float64
+	}{
+		{"Empty", []byte{}, 1},
+		{"Short", []byte("alpaca"), 2},
+		{"Random", random, 1.01},
+		{"Zeros", make([]byte, 70000), 0.01},
+		{"Sky", sky, 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compressed := lz4Compress(tt.data)
+
+			if ratio := float64(len(compressed)) / float64(max(len(tt.data), 1)); ratio > tt.ratio {
+				t.Errorf("got a ratio of %v, wanted at most %v", ratio, tt.ratio)
+			}
+
+			got, err := lz4Decompress(compressed)
+
+			if err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			if !bytes.Equal(got, tt.data) {
+				t.Errorf("got %d bytes which differ from the %d bytes of data", len(got), len(tt.data))
+			}
+
+			// The last five bytes of a block must be literals:
+			if n := len(tt.data); n >= lz4LastLiterals && !bytes.Equal(compressed[len(compressed)-lz4LastLiterals:], tt.data[n-lz4LastLiterals:]) {
+				t.Errorf("got %x, wanted the block to end with literals", compressed[len(compressed)-lz4LastLiterals:])
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package xisf
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/observerly/alpacago/pkg/fits"
+)
+
+/*
+Property
+
+An XISF property of an image, whose Value is formatted for its Type, i.e., String, TimePoint, Int32, Float32 or
+Float64.
+*/
+type Property struct {
+	ID    string
+	Type  string
+	Value string
+}
+
+/*
+keywordProperties
+
+The XISF properties recorded from the FITS keywords of the header, see the XISF 1.0 specification, section 11.5.
+The scale converts the unit of the keyword to that of the property, e.g., millimetres to metres.
+*/
+var keywordProperties = []struct {
+	keyword string
+	id      string
+	kind    string
+	scale   float64
+}{
+	{"OBJECT", "Observation:Object:Name", "String", 0},
+	{"DATE-OBS", "Observation:Time:Start", "TimePoint", 0},
+	{"RA", "Observation:Center:RA", "Float64", 1},
+	{"DEC", "Observation:Center:Dec", "Float64", 1},
+	{"SITELAT", "Observation:Location:Latitude", "Float64", 1},
+	{"SITELONG", "Observation:Location:Longitude", "Float64", 1},
+	{"SITEELEV", "Observation:Location:Elevation", "Float64", 1},
+	{"EXPTIME", "Instrument:ExposureTime", "Float32", 1},
+	{"INSTRUME", "Instrument:Camera:Name", "String", 0},
+	{"XBINNING", "Instrument:Camera:XBinning", "Int32", 1},
+	{"YBINNING", "Instrument:Camera:YBinning", "Int32", 1},
+	// The gain of the property is in electrons per data number, so it is that of EGAIN rather than the gain setting
+	// of GAIN, which remains a FITS keyword only:
+	{"EGAIN", "Instrument:Camera:Gain", "Float32", 1},
+	{"CCD-TEMP", "Instrument:Sensor:Temperature", "Float32", 1},
+	{"SET-TEMP", "Instrument:Sensor:TargetTemperature", "Float32", 1},
+	{"XPIXSZ", "Instrument:Sensor:XPixelSize", "Float32", 1},
+	{"YPIXSZ", "Instrument:Sensor:YPixelSize", "Float32", 1},
+	{"TELESCOP", "Instrument:Telescope:Name", "String", 0},
+	{"FOCALLEN", "Instrument:Telescope:FocalLength", "Float32", 0.001},
+	{"APTDIA", "Instrument:Telescope:Aperture", "Float32", 0.001},
+	{"FILTER", "Instrument:Filter:Name", "String", 0},
+}
+
+/*
+Properties()
+
+@param header *fits.Header (e.g., as returned by fits.NewHeaderFromDevices())
+@returns the XISF properties of the FITS keywords of the header which have an equivalent property, skipping any
+keyword whose value is not of the type of its property.
+*/
+func Properties(header *fits.Header) []Property {
+	properties := []Property{}
+
+	if header == nil {
+		return properties
+	}
+
+	for _, p := range keywordProperties {
+		var s string
+
+		var ok bool
+
+		switch p.kind {
+		case "String":
+			s, ok = header.GetString(p.keyword)
+		case "TimePoint":
+			s, ok = timePoint(header, p.keyword)
+		case "Int32":
+			var v int64
+
+			if v, ok = header.GetInt(p.keyword); ok {
+				s = strconv.FormatInt(v, 10)
+			}
+		default:
+			var v float64
+
+			if v, ok = header.GetFloat(p.keyword); ok {
+				s = strconv.FormatFloat(v*p.scale, 'g', -1, 64)
+			}
+		}
+
+		if ok {
+			properties = append(properties, Property{ID: p.id, Type: p.kind, Value: s})
+		}
+	}
+
+	return properties
+}
+
+// timePoint returns a UTC date keyword, such as DATE-OBS, as an ISO 8601 time point with an explicit time zone:
+func timePoint(header *fits.Header, keyword string) (string, bool) {
+	s, ok := header.GetString(keyword)
+
+	if !ok {
+		return "", false
+	}
+
+	// The fraction of the seconds is optional, as DATE-OBS written by other software may omit it:
+	t, err := time.Parse("2006-01-02T15:04:05", strings.TrimSuffix(s, "Z"))
+
+	if err != nil {
+		return "", false
+	}
+
+	return t.UTC().Format("2006-01-02T15:04:05.000Z"), true
+}
+
+/*
+colorFilterArray()
+
+@returns the 2x2 colour filter array pattern of the image at its origin, from the BAYERPAT, XBAYROFF and YBAYROFF
+keywords of the header, e.g., "GRBG" for an RGGB sensor offset by one pixel in x, or false for a sensor which is
+not RGGB.
+*/
+func colorFilterArray(header *fits.Header) (string, bool) {
+	if header == nil {
+		return "", false
+	}
+
+	if pattern, _ := header.GetString("BAYERPAT"); pattern != "RGGB" {
+		return "", false
+	}
+
+	x, _ := header.GetInt("XBAYROFF")
+	y, _ := header.GetInt("YBAYROFF")
+
+	rggb := [2][2]byte{{'R', 'G'}, {'G', 'B'}}
+
+	var b strings.Builder
+
+	for j := int64(0); j < 2; j++ {
+		for i := int64(0); i < 2; i++ {
+			b.WriteByte(rggb[(j+y)%2][(i+x)%2])
+		}
+	}
+
+	return b.String(), true
+}
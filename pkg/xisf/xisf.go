@@ -0,0 +1,437 @@
+package xisf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/fits"
+)
+
+// The signature with which a monolithic XISF 1.0 file begins:
+const SIGNATURE = "XISF0100"
+
+// The alignment, in bytes, of the position of the data block of the image within the file:
+const BLOCK_ALIGNMENT = 4096
+
+// The application recorded as the creator of each file:
+const CREATOR_APPLICATION = "alpacago"
+
+type Compression int
+
+const (
+	// The data block is not compressed:
+	None Compression = iota
+	// The data block is compressed with zlib, which gives the smallest files:
+	Zlib
+	// The data block is compressed as a single LZ4 block, which is the fastest to read and write:
+	LZ4
+)
+
+func (c Compression) String() string {
+	switch c {
+	case None:
+		return "none"
+	case Zlib:
+		return "zlib"
+	case LZ4:
+		return "lz4"
+	default:
+		return fmt.Sprintf("Unknown Compression value: %d", c)
+	}
+}
+
+/*
+Options
+
+Configures the XISF file written by Write(). Shuffling the bytes of the pixels, so that the most significant bytes
+of every pixel are adjacent, usually improves the compression of 16 and 32 bit images, and is ignored without
+compression.
+*/
+type Options struct {
+	Compression Compression
+	Shuffle     bool
+	// The zlib compression level, see compress/zlib, or zero for the default:
+	Level int
+}
+
+// The FITS keywords which describe the structure of a FITS file, and not the image:
+var structuralKeywords = map[string]bool{
+	"SIMPLE": true, "XTENSION": true, "BITPIX": true, "NAXIS": true, "NAXIS1": true, "NAXIS2": true, "NAXIS3": true,
+	"PCOUNT": true, "GCOUNT": true, "EXTEND": true, "BZERO": true, "BSCALE": true, "END": true,
+}
+
+// The XISF image type of each IMAGETYP, see calibration.FrameType:
+var imageTypes = map[string]string{
+	"light frame": "Light",
+	"bias frame":  "Bias",
+	"dark frame":  "Dark",
+	"flat frame":  "Flat",
+	"flat field":  "Flat",
+	"dark flat":   "Dark",
+}
+
+/*
+SampleFormat()
+
+@returns the XISF sample format of the image, and the size of a sample in bytes. Integer images whose values all
+fit within 16 bits, as is usual for cameras which transmit 16 bit data as 32 bit integers, are narrowed to UInt16,
+and those with negative values are stored as Float32, or as Float64 if any value is too large for Float32 to hold
+it exactly, as XISF images have no signed integer formats.
+*/
+func SampleFormat(image *alpacago.Image) (string, int) {
+	switch image.ElementType {
+	case alpacago.ImageElementByte:
+		return "UInt8", 1
+	case alpacago.ImageElementSingle:
+		return "Float32", 4
+	case alpacago.ImageElementDouble:
+		return "Float64", 8
+	}
+
+	format, size := "UInt16", 2
+
+	signed := false
+
+	for _, v := range image.Pix {
+		switch {
+		case v < 0 || v > math.MaxUint32:
+			signed = true
+		case v > math.MaxUint16:
+			format, size = "UInt32", 4
+		}
+	}
+
+	if !signed {
+		return format, size
+	}
+
+	// Float32 holds every integer of up to 24 bits exactly:
+	for _, v := range image.Pix {
+		if math.Abs(v) > 1<<24 {
+			return "Float64", 8
+		}
+	}
+
+	return "Float32", 4
+}
+
+/*
+encodePixels()
+
+@returns the pixels of the image in the sample format, little-endian, with each plane following the last.
+*/
+func encodePixels(image *alpacago.Image, format string, size int) []byte {
+	data := make([]byte, 0, len(image.Pix)*size)
+
+	for _, v := range image.Pix {
+		switch format {
+		case "UInt8":
+			data = append(data, uint8(math.Round(math.Min(math.Max(v, 0), math.MaxUint8))))
+		case "UInt16":
+			data = binary.LittleEndian.AppendUint16(data, uint16(math.Round(v)))
+		case "UInt32":
+			data = binary.LittleEndian.AppendUint32(data, uint32(math.Round(v)))
+		case "Float32":
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(v)))
+		case "Float64":
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(v))
+		}
+	}
+
+	return data
+}
+
+/*
+shuffle()
+
+@returns the data with the bytes of its items of the given size regrouped by significance, i.e., the first byte of
+every item, then the second byte of every item, and so on, followed by any bytes which do not form a whole item.
+*/
+func shuffle(data []byte, size int) []byte {
+	n := len(data) / size
+
+	shuffled := make([]byte, len(data))
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < size; j++ {
+			shuffled[j*n+i] = data[i*size+j]
+		}
+	}
+
+	copy(shuffled[n*size:], data[n*size:])
+
+	return shuffled
+}
+
+/*
+compress()
+
+@returns the data block, compressed as given by the options, and the value of its compression attribute, which is
+empty if the data block is not compressed.
+*/
+func compress(data []byte, size int, opts Options) ([]byte, string, error) {
+	if opts.Compression == None {
+		return data, "", nil
+	}
+
+	codec := opts.Compression.String()
+
+	attribute := strconv.Itoa(len(data))
+
+	if opts.Shuffle && size > 1 {
+		data = shuffle(data, size)
+
+		codec += "+sh"
+
+		attribute += ":" + strconv.Itoa(size)
+	}
+
+	switch opts.Compression {
+	case Zlib:
+		level := opts.Level
+
+		if level == 0 {
+			level = zlib.DefaultCompression
+		}
+
+		var b bytes.Buffer
+
+		zw, err := zlib.NewWriterLevel(&b, level)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, err := zw.Write(data); err != nil {
+			return nil, "", err
+		}
+
+		if err := zw.Close(); err != nil {
+			return nil, "", err
+		}
+
+		data = b.Bytes()
+	case LZ4:
+		if len(data) > math.MaxInt32 {
+			return nil, "", fmt.Errorf("xisf: %d bytes cannot be compressed as a single lz4 block", len(data))
+		}
+
+		data = lz4Compress(data)
+	default:
+		return nil, "", fmt.Errorf("xisf: %s", opts.Compression)
+	}
+
+	return data, codec + ":" + attribute, nil
+}
+
+// escape returns the text escaped for an XML attribute or element:
+func escape(s string) string {
+	var b strings.Builder
+
+	xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
+
+func writeProperty(b *strings.Builder, p Property) {
+	// String properties hold their value as the content of the element, rather than as an attribute:
+	if p.Type == "String" {
+		fmt.Fprintf(b, "<Property id=\"%s\" type=\"String\">%s</Property>\n", escape(p.ID), escape(p.Value))
+		return
+	}
+
+	fmt.Fprintf(b, "<Property id=\"%s\" type=\"%s\" value=\"%s\"/>\n", escape(p.ID), escape(p.Type), escape(p.Value))
+}
+
+/*
+xmlHeader()
+
+@returns the XML header of a file of the image, whose data block is at the given position, with the attributes of
+the image, its properties and FITS keywords, and the metadata of the file.
+*/
+func xmlHeader(image *alpacago.Image, header *fits.Header, attributes [][2]string, position int, size int, created time.Time) string {
+	var b strings.Builder
+
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	b.WriteString("<xisf version=\"1.0\" xmlns=\"http://www.pixinsight.com/xisf\" xmlns:xsi=\"http://www.w3.org/2001/XMLSchema-instance\" xsi:schemaLocation=\"http://www.pixinsight.com/xisf http://pixinsight.com/xisf/xisf-1.0.xsd\">\n")
+
+	b.WriteString("<Image")
+
+	for _, a := range attributes {
+		fmt.Fprintf(&b, " %s=\"%s\"", a[0], escape(a[1]))
+	}
+
+	fmt.Fprintf(&b, " location=\"attachment:%d:%d\">\n", position, size)
+
+	if pattern, ok := colorFilterArray(header); ok && image.Planes == 1 {
+		fmt.Fprintf(&b, "<ColorFilterArray pattern=\"%s\" width=\"2\" height=\"2\"/>\n", pattern)
+	}
+
+	for _, p := range Properties(header) {
+		writeProperty(&b, p)
+	}
+
+	if header != nil {
+		for _, c := range header.Cards {
+			if structuralKeywords[c.Keyword] {
+				continue
+			}
+
+			fmt.Fprintf(&b, "<FITSKeyword name=\"%s\" value=\"%s\" comment=\"%s\"/>\n", escape(c.Keyword), escape(c.ValueString()), escape(c.Comment))
+		}
+	}
+
+	b.WriteString("</Image>\n<Metadata>\n")
+
+	writeProperty(&b, Property{ID: "XISF:CreationTime", Type: "TimePoint", Value: created.UTC().Format("2006-01-02T15:04:05.000Z")})
+	writeProperty(&b, Property{ID: "XISF:CreatorApplication", Type: "String", Value: CREATOR_APPLICATION})
+
+	b.WriteString("</Metadata>\n</xisf>")
+
+	return b.String()
+}
+
+/*
+Write()
+
+Writes the image as a monolithic XISF file, with the XML header followed by a single data block of the pixels,
+optionally compressed. The cards of the header are recorded as FITS keywords of the image, and those with an
+equivalent XISF property, such as EXPTIME or FILTER, as properties too, see Properties(). The header of an
+exposure is populated from its devices by fits.NewHeaderFromDevices().
+
+@param w io.Writer
+@param image *alpacago.Image (a monochrome image, or a colour image of three planes)
+@param header *fits.Header (the keywords of the image, or nil for none)
+@param opts Options
+*/
+func Write(w io.Writer, image *alpacago.Image, header *fits.Header, opts Options) error {
+	if image.Rank != 2 && image.Rank != 3 {
+		return fmt.Errorf("xisf: unsupported image rank %d", image.Rank)
+	}
+
+	format, size := SampleFormat(image)
+
+	data, compression, err := compress(encodePixels(image, format, size), size, opts)
+
+	if err != nil {
+		return err
+	}
+
+	colorSpace := "Gray"
+
+	if image.Planes == 3 {
+		colorSpace = "RGB"
+	}
+
+	attributes := [][2]string{
+		{"geometry", fmt.Sprintf("%d:%d:%d", image.Width, image.Height, image.Planes)},
+		{"sampleFormat", format},
+		{"colorSpace", colorSpace},
+	}
+
+	// The bounds of floating point samples are required, and are those of the pixels:
+	if strings.HasPrefix(format, "Float") && len(image.Pix) > 0 {
+		lo, hi := image.Pix[0], image.Pix[0]
+
+		for _, v := range image.Pix {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+
+		if hi <= lo {
+			hi = lo + 1
+		}
+
+		attributes = append(attributes, [2]string{"bounds", fmt.Sprintf("%v:%v", lo, hi)})
+	}
+
+	if header != nil {
+		if imageType, ok := header.GetString("IMAGETYP"); ok && imageTypes[strings.ToLower(imageType)] != "" {
+			attributes = append(attributes, [2]string{"imageType", imageTypes[strings.ToLower(imageType)]})
+		}
+	}
+
+	if compression != "" {
+		attributes = append(attributes, [2]string{"compression", compression})
+	}
+
+	created := time.Now()
+
+	// The position of the data block is within the header, so the header is formatted until its length is stable:
+	var x string
+
+	position := 0
+
+	for {
+		x = xmlHeader(image, header, attributes, position, len(data), created)
+
+		aligned := (16 + len(x) + BLOCK_ALIGNMENT - 1) / BLOCK_ALIGNMENT * BLOCK_ALIGNMENT
+
+		if aligned == position {
+			break
+		}
+
+		position = aligned
+	}
+
+	preamble := make([]byte, 0, 16)
+
+	preamble = append(preamble, SIGNATURE...)
+	preamble = binary.LittleEndian.AppendUint32(preamble, uint32(len(x)))
+	// The reserved field:
+	preamble = binary.LittleEndian.AppendUint32(preamble, 0)
+
+	if _, err := w.Write(preamble); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, x); err != nil {
+		return err
+	}
+
+	// The header is padded to the data block with zeros:
+	if _, err := w.Write(make([]byte, position-16-len(x))); err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+/*
+WriteFile()
+
+Writes the image, with the given header cards, as an XISF file at the given path, see Write().
+*/
+func WriteFile(path string, image *alpacago.Image, header *fits.Header, opts Options) error {
+	f, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+
+	if err := Write(w, image, header, opts); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
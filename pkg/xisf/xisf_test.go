@@ -0,0 +1,338 @@
+package xisf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/xml"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/observerly/alpacago/pkg/alpacago"
+	"github.com/observerly/alpacago/pkg/fits"
+)
+
+type xmlProperty struct {
+	ID    string `xml:"id,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+type xmlFile struct {
+	Image struct {
+		Geometry         string `xml:"geometry,attr"`
+		SampleFormat     string `xml:"sampleFormat,attr"`
+		ColorSpace       string `xml:"colorSpace,attr"`
+		Bounds           string `xml:"bounds,attr"`
+		ImageType        string `xml:"imageType,attr"`
+		Location         string `xml:"location,attr"`
+		Compression      string `xml:"compression,attr"`
+		ColorFilterArray struct {
+			Pattern string `xml:"pattern,attr"`
+		} `xml:"ColorFilterArray"`
+		Properties []xmlProperty `xml:"Property"`
+		Keywords   []struct {
+			Name    string `xml:"name,attr"`
+			Value   string `xml:"value,attr"`
+			Comment string `xml:"comment,attr"`
+		} `xml:"FITSKeyword"`
+	} `xml:"Image"`
+	Metadata struct {
+		Properties []xmlProperty `xml:"Property"`
+	} `xml:"Metadata"`
+}
+
+/*
+readXISF()
+
+@returns the XML header of a monolithic XISF file, and its data block, decompressed and unshuffled.
+*/
+func readXISF(t *testing.T, b []byte) (xmlFile, []byte) {
+	t.Helper()
+
+	var f xmlFile
+
+	if string(b[:8]) != SIGNATURE {
+		t.Fatalf("got the signature %q, wanted %q", b[:8], SIGNATURE)
+	}
+
+	n := binary.LittleEndian.Uint32(b[8:])
+
+	if err := xml.Unmarshal(b[16:16+n], &f); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	location := strings.Split(f.Image.Location, ":")
+
+	position, _ := strconv.Atoi(location[1])
+	size, _ := strconv.Atoi(location[2])
+
+	if position%BLOCK_ALIGNMENT != 0 || position+size != len(b) {
+		t.Fatalf("got the data block at %d of %d bytes, wanted an aligned block ending the file of %d bytes", position, size, len(b))
+	}
+
+	data := b[position : position+size]
+
+	if f.Image.Compression == "" {
+		return f, data
+	}
+
+	compression := strings.Split(f.Image.Compression, ":")
+
+	var err error
+
+	switch strings.TrimSuffix(compression[0], "+sh") {
+	case "zlib":
+		var r io.ReadCloser
+
+		if r, err = zlib.NewReader(bytes.NewReader(data)); err == nil {
+			data, err = io.ReadAll(r)
+		}
+	case "lz4":
+		data, err = lz4Decompress(data)
+	}
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if want, _ := strconv.Atoi(compression[1]); len(data) != want {
+		t.Fatalf("got %d bytes, wanted the uncompressed size of %d", len(data), want)
+	}
+
+	if strings.HasSuffix(compression[0], "+sh") {
+		itemSize, _ := strconv.Atoi(compression[2])
+
+		unshuffled := make([]byte, len(data))
+
+		n := len(data) / itemSize
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < itemSize; j++ {
+				unshuffled[i*itemSize+j] = data[j*n+i]
+			}
+		}
+
+		data = unshuffled
+	}
+
+	return f, data
+}
+
+func newHeader() *fits.Header {
+	h := fits.NewHeader()
+
+	h.Set("SIMPLE", true, "")
+	h.Set("EXPTIME", 60.0, "[s] exposure duration")
+	h.Set("DATE-OBS", "2024-03-01T21:04:05.250", "[UTC] start of exposure")
+	h.Set("IMAGETYP", "Light Frame", "type of image")
+	h.Set("OBJECT", "M42 & <Trapezium>", "")
+	h.Set("XBINNING", int32(2), "binning factor in width")
+	h.Set("GAIN", int32(100), "sensor gain")
+	h.Set("EGAIN", 1.5, "[e-/ADU] electrons per ADU")
+	h.Set("FOCALLEN", 1000.0, "[mm] focal length of telescope")
+	h.Set("FILTER", "Ha", "name of filter")
+	h.Set("BAYERPAT", "RGGB", "")
+	h.Set("XBAYROFF", int32(1), "")
+	h.Set("YBAYROFF", int32(0), "")
+
+	return h
+}
+
+func TestProperties(t *testing.T) {
+	got := map[string]Property{}
+
+	for _, p := range Properties(newHeader()) {
+		got[p.ID] = p
+	}
+
+	want := []Property{
+		{"Instrument:ExposureTime", "Float32", "60"},
+		{"Observation:Time:Start", "TimePoint", "2024-03-01T21:04:05.250Z"},
+		{"Observation:Object:Name", "String", "M42 & <Trapezium>"},
+		{"Instrument:Camera:XBinning", "Int32", "2"},
+		{"Instrument:Camera:Gain", "Float32", "1.5"},
+		{"Instrument:Telescope:FocalLength", "Float32", "1"},
+		{"Instrument:Filter:Name", "String", "Ha"},
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("got %d properties, wanted %d", len(got), len(want))
+	}
+
+	for _, w := range want {
+		if got[w.ID] != w {
+			t.Errorf("got %+v, wanted %+v", got[w.ID], w)
+		}
+	}
+
+	if got := Properties(nil); len(got) != 0 {
+		t.Errorf("got %v, wanted no properties without a header", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	mono := alpacago.NewImage(alpacago.ImageElementInt32, 50, 40, 1)
+
+	for i := range mono.Pix {
+		mono.Pix[i] = float64(1000 + i%7)
+	}
+
+	colour := alpacago.NewImage(alpacago.ImageElementSingle, 20, 10, 3)
+
+	for i := range colour.Pix {
+		colour.Pix[i] = float64(i) / 8
+	}
+
+	tests := []struct {
+		name        string
+		image       *alpacago.Image
+		opts        Options
+		format      string
+		compression string
+	}{
+		{"Uncompressed", mono, Options{}, "UInt16", ""},
+		{"Zlib", mono, Options{Compression: Zlib}, "UInt16", "zlib:4000"},
+		{"ZlibShuffled", mono, Options{Compression: Zlib, Shuffle: true, Level: 9}, "UInt16", "zlib+sh:4000:2"},
+		{"LZ4", mono, Options{Compression: LZ4}, "UInt16", "lz4:4000"},
+		{"LZ4Shuffled", colour, Options{Compression: LZ4, Shuffle: true}, "Float32", "lz4+sh:2400:4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+
+			if err := Write(&b, tt.image, newHeader(), tt.opts); err != nil {
+				t.Fatalf("got %q", err)
+			}
+
+			f, data := readXISF(t, b.Bytes())
+
+			if f.Image.SampleFormat != tt.format || f.Image.Compression != tt.compression || f.Image.ImageType != "Light" {
+				t.Errorf("got %s compressed %q of type %s, wanted %s compressed %q of type Light", f.Image.SampleFormat, f.Image.Compression, f.Image.ImageType, tt.format, tt.compression)
+			}
+
+			for i, v := range tt.image.Pix {
+				var got float64
+
+				if tt.format == "UInt16" {
+					got = float64(binary.LittleEndian.Uint16(data[2*i:]))
+				} else {
+					got = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:])))
+				}
+
+				if got != v {
+					t.Fatalf("got %v at %d, wanted %v", got, i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteHeader(t *testing.T) {
+	var b bytes.Buffer
+
+	image := alpacago.NewImage(alpacago.ImageElementInt32, 4, 2, 1)
+
+	if err := Write(&b, image, newHeader(), Options{}); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	f, _ := readXISF(t, b.Bytes())
+
+	if f.Image.Geometry != "4:2:1" || f.Image.ColorSpace != "Gray" || f.Image.Bounds != "" {
+		t.Errorf("got geometry %s in %s with bounds %q, wanted 4:2:1 in Gray without bounds", f.Image.Geometry, f.Image.ColorSpace, f.Image.Bounds)
+	}
+
+	// The Bayer pattern is offset by one pixel in x:
+	if f.Image.ColorFilterArray.Pattern != "GRBG" {
+		t.Errorf("got %q, wanted GRBG", f.Image.ColorFilterArray.Pattern)
+	}
+
+	keywords := map[string]string{}
+
+	for _, k := range f.Image.Keywords {
+		keywords[k.Name] = k.Value
+	}
+
+	if _, ok := keywords["SIMPLE"]; ok || keywords["OBJECT"] != "'M42 & <Trapezium>'" || keywords["EXPTIME"] != "60.0" {
+		t.Errorf("got %v, wanted the FITS keywords other than SIMPLE", keywords)
+	}
+
+	for _, p := range f.Image.Properties {
+		if p.ID == "Observation:Object:Name" && p.Text != "M42 & <Trapezium>" {
+			t.Errorf("got %q, wanted the escaped name of the object", p.Text)
+		}
+	}
+
+	if len(f.Metadata.Properties) != 2 || f.Metadata.Properties[1].Text != CREATOR_APPLICATION {
+		t.Errorf("got %+v, wanted the creation time and creator application", f.Metadata.Properties)
+	}
+
+	colour := alpacago.NewImage(alpacago.ImageElementSingle, 2, 2, 3)
+
+	colour.Pix[0] = -1
+
+	b.Reset()
+
+	if err := Write(&b, colour, nil, Options{}); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if f, _ := readXISF(t, b.Bytes()); f.Image.ColorSpace != "RGB" || f.Image.Bounds != "-1:0" || f.Image.ColorFilterArray.Pattern != "" {
+		t.Errorf("got %s with bounds %q, wanted RGB with bounds -1:0", f.Image.ColorSpace, f.Image.Bounds)
+	}
+}
+
+func TestSampleFormat(t *testing.T) {
+	tests := []struct {
+		elementType alpacago.ImageArrayElementType
+		value       float64
+		want        string
+	}{
+		{alpacago.ImageElementByte, 200, "UInt8"},
+		{alpacago.ImageElementUInt16, 60000, "UInt16"},
+		{alpacago.ImageElementInt32, 60000, "UInt16"},
+		{alpacago.ImageElementInt32, 70000, "UInt32"},
+		{alpacago.ImageElementInt32, -5, "Float32"},
+		{alpacago.ImageElementInt32, 16777217, "UInt32"},
+		{alpacago.ImageElementInt32, -16777217, "Float64"},
+		{alpacago.ImageElementDouble, 0.5, "Float64"},
+	}
+
+	for _, tt := range tests {
+		image := alpacago.NewImage(tt.elementType, 2, 1, 1)
+
+		image.Pix[1] = tt.value
+
+		if got, _ := SampleFormat(image); got != tt.want {
+			t.Errorf("got %s for %v of %v, wanted %s", got, tt.value, tt.elementType, tt.want)
+		}
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frame.xisf")
+
+	image := alpacago.NewImage(alpacago.ImageElementInt32, 8, 8, 1)
+
+	if err := WriteFile(path, image, nil, Options{Compression: Zlib}); err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("got %q", err)
+	}
+
+	if _, data := readXISF(t, b); len(data) != 128 {
+		t.Errorf("got %d bytes, wanted 128", len(data))
+	}
+}